@@ -13,6 +13,8 @@ const (
 	RepositoryEndpoint   = "REPOSITORY_ENDPOINT"
 	RepositoryURL        = "REPOSITORY_URL"
 
+	SkipIntegrityCheck = "SKIP_INTEGRITY_CHECK"
+
 	Hostname = "HOSTNAME"
 
 	TargetName        = "TARGET_NAME"
@@ -22,9 +24,25 @@ const (
 	TargetMountPath   = "TARGET_MOUNT_PATH"
 	TargetDirectories = "TARGET_DIRECTORIES"
 
+	TargetExclude          = "TARGET_EXCLUDE"
+	TargetInclude          = "TARGET_INCLUDE"
+	TargetExcludeIfPresent = "TARGET_EXCLUDE_IF_PRESENT"
+	TargetIgnoreFileName   = "TARGET_IGNORE_FILE_NAME"
+	TargetExcludeCaches    = "TARGET_EXCLUDE_CACHES"
+	TargetOneFileSystem    = "TARGET_ONE_FILE_SYSTEM"
+
+	SnapshotTags = "SNAPSHOT_TAGS"
+
 	RestoreDirectories = "RESTORE_DIRECTORIES"
 	RestoreSnapshots   = "RESTORE_SNAPSHOTS"
 
+	RestoreInclude        = "RESTORE_INCLUDE"
+	RestoreExclude        = "RESTORE_EXCLUDE"
+	RestoreDestination    = "RESTORE_DESTINATION"
+	RestoreConflictPolicy = "RESTORE_CONFLICT_POLICY"
+	RestoreSnapshotTags   = "RESTORE_SNAPSHOT_TAGS"
+	RestoreSnapshotBefore = "RESTORE_SNAPSHOT_BEFORE"
+
 	RetentionKeepLast    = "RETENTION_KEEP_LAST"
 	RetentionKeepHourly  = "RETENTION_KEEP_HOURLY"
 	RetentionKeepDaily   = "RETENTION_KEEP_DAILY"
@@ -32,8 +50,13 @@ const (
 	RetentionKeepMonthly = "RETENTION_KEEP_MONTHLY"
 	RetentionKeepYearly  = "RETENTION_KEEP_YEARLY"
 	RetentionKeepTags    = "RETENTION_KEEP_TAGS"
+	RetentionKeepWithin  = "RETENTION_KEEP_WITHIN"
 	RetentionPrune       = "RETENTION_PRUNE"
 	RetentionDryRun      = "RETENTION_DRY_RUN"
+	RetentionGroupBy     = "RETENTION_GROUP_BY"
+	RetentionFilterHost  = "RETENTION_FILTER_HOST"
+	RetentionFilterTags  = "RETENTION_FILTER_TAGS"
+	RetentionFilterPaths = "RETENTION_FILTER_PATHS"
 
 	// default true
 	// false when TmpDir.DisableCaching is true in backupConfig/restoreSession
@@ -46,6 +46,11 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Snapshot{},
 		&SnapshotList{},
+		&SnapshotFileList{},
+		&SnapshotFilesOptions{},
+		&SnapshotDownloadOptions{},
+		&SnapshotDiff{},
+		&SnapshotDiffOptions{},
 	)
 	return nil
 }
@@ -21,7 +21,7 @@ import (
 )
 
 // +genclient
-// +genclient:skipVerbs=create,update,patch,deleteCollection,watch
+// +genclient:skipVerbs=create,watch
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 type Snapshot struct {
@@ -38,6 +38,11 @@ type SnapshotStatus struct {
 	UID      int
 	Gid      int
 	Tags     []string
+
+	Size                uint64
+	FileCount           int64
+	BackupSession       string
+	BackupConfiguration string
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -45,5 +50,99 @@ type SnapshotStatus struct {
 type SnapshotList struct {
 	metav1.TypeMeta
 	metav1.ListMeta
-	Items []Snapshot
+	Items    []Snapshot
+	Warnings []SnapshotListWarning
+}
+
+type SnapshotListWarning struct {
+	Repository string
+	Message    string
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SnapshotFileList is the list of the files and directories of a snapshot. It is served by "files" subresource of Snapshot.
+type SnapshotFileList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+	Items []SnapshotFile
+}
+
+type SnapshotFile struct {
+	Name    string
+	Path    string
+	Type    string
+	Size    uint64
+	Mode    string
+	ModTime metav1.Time
+	UID     uint32
+	Gid     uint32
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SnapshotFilesOptions is the query options of "files" subresource of Snapshot
+type SnapshotFilesOptions struct {
+	metav1.TypeMeta
+	Path     string
+	Limit    int64
+	Continue string
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SnapshotDownloadOptions is the query options of "download" subresource of Snapshot
+type SnapshotDownloadOptions struct {
+	metav1.TypeMeta
+	Path string
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SnapshotDiff shows the changes between two snapshots. It is served by "diff" subresource of Snapshot.
+type SnapshotDiff struct {
+	metav1.TypeMeta
+	From    string
+	To      string
+	Entries []SnapshotDiffEntry
+	Stats   SnapshotDiffStats
+}
+
+type SnapshotDiffChange string
+
+const (
+	SnapshotDiffAdded           SnapshotDiffChange = "Added"
+	SnapshotDiffRemoved         SnapshotDiffChange = "Removed"
+	SnapshotDiffModified        SnapshotDiffChange = "Modified"
+	SnapshotDiffTypeChanged     SnapshotDiffChange = "TypeChanged"
+	SnapshotDiffMetadataChanged SnapshotDiffChange = "MetadataChanged"
+)
+
+type SnapshotDiffEntry struct {
+	Path   string
+	Change SnapshotDiffChange
+}
+
+type SnapshotDiffStats struct {
+	FilesAdded       int64
+	FilesRemoved     int64
+	FilesChanged     int64
+	DirsAdded        int64
+	DirsRemoved      int64
+	OthersAdded      int64
+	OthersRemoved    int64
+	DataBlobsAdded   int64
+	DataBlobsRemoved int64
+	TreeBlobsAdded   int64
+	TreeBlobsRemoved int64
+	BytesAdded       int64
+	BytesRemoved     int64
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SnapshotDiffOptions is the query options of "diff" subresource of Snapshot
+type SnapshotDiffOptions struct {
+	metav1.TypeMeta
+	Against string
 }
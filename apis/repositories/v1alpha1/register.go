@@ -39,7 +39,7 @@ func init() {
 	// We only register manually written functions here. The registration of the
 	// generated functions takes place in the generated files. The separation
 	// makes the code compile even when the generated files are missing.
-	localSchemeBuilder.Register(addKnownTypes)
+	localSchemeBuilder.Register(addKnownTypes, addConversionFuncs)
 }
 
 // Adds the list of known types to the given scheme.
@@ -47,6 +47,11 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Snapshot{},
 		&SnapshotList{},
+		&SnapshotFileList{},
+		&SnapshotFilesOptions{},
+		&SnapshotDownloadOptions{},
+		&SnapshotDiff{},
+		&SnapshotDiffOptions{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -24,10 +24,29 @@ const (
 	ResourceKindSnapshot     = "Snapshot"
 	ResourcePluralSnapshot   = "snapshots"
 	ResourceSingularSnapshot = "snapshot"
+
+	ResourceKindSnapshotFileList = "SnapshotFileList"
+	SubresourceSnapshotFiles     = "files"
+	SubresourceSnapshotDownload  = "download"
+	SubresourceSnapshotDiff      = "diff"
+	ResourceKindSnapshotDiff     = "SnapshotDiff"
+)
+
+// Fields of Snapshot that can be used in field selectors
+const (
+	SnapshotFieldHostname            = "status.hostname"
+	SnapshotFieldPaths               = "status.paths"
+	SnapshotFieldTags                = "status.tags"
+	SnapshotFieldBackupSession       = "status.backupSession"
+	SnapshotFieldBackupConfiguration = "status.backupConfiguration"
+	// SnapshotFieldCreatedAfter and SnapshotFieldCreatedBefore select the snapshots taken in a time range.
+	// Their values are RFC3339 timestamps.
+	SnapshotFieldCreatedAfter  = "createdAfter"
+	SnapshotFieldCreatedBefore = "createdBefore"
 )
 
 // +genclient
-// +genclient:skipVerbs=create,update,patch,deleteCollection,watch
+// +genclient:skipVerbs=create,watch
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -45,6 +64,18 @@ type SnapshotStatus struct {
 	UID      int      `json:"uid"`
 	Gid      int      `json:"gid"`
 	Tags     []string `json:",omitempty"`
+	// Size is the total size of the files of the snapshot in bytes
+	// +optional
+	Size uint64 `json:"size,omitempty"`
+	// FileCount is the number of files of the snapshot
+	// +optional
+	FileCount int64 `json:"fileCount,omitempty"`
+	// BackupSession is the name of the BackupSession that has taken the snapshot
+	// +optional
+	BackupSession string `json:"backupSession,omitempty"`
+	// BackupConfiguration is the name of the BackupConfiguration of the BackupSession that has taken the snapshot
+	// +optional
+	BackupConfiguration string `json:"backupConfiguration,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -53,4 +84,134 @@ type SnapshotList struct {
 	metav1.TypeMeta `json:",inline"`
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []Snapshot `json:"items"`
+	// Warnings lists the repositories whose snapshots could not be listed. The list is partial if it is not empty.
+	// +optional
+	Warnings []SnapshotListWarning `json:"warnings,omitempty"`
+}
+
+type SnapshotListWarning struct {
+	// Repository is the name of the Repository whose snapshots could not be listed
+	Repository string `json:"repository"`
+	// Message is the reason of the failure
+	Message string `json:"message"`
+}
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SnapshotFileList is the list of the files and directories of a snapshot. It is served by "files" subresource of Snapshot.
+type SnapshotFileList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Continue token is set in the metadata if more files are available
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SnapshotFile `json:"items"`
+}
+
+type SnapshotFile struct {
+	// Name is the base name of the file
+	Name string `json:"name"`
+	// Path is the absolute path of the file inside the snapshot
+	Path string `json:"path"`
+	// Type is the type of the file. Known types are "file", "dir" and "symlink".
+	Type string `json:"type"`
+	// Size is the size of the file in bytes
+	// +optional
+	Size uint64 `json:"size,omitempty"`
+	// Mode is the permission and mode bits of the file (i.e. "-rw-r--r--")
+	Mode string `json:"mode"`
+	// ModTime is the last modification time of the file
+	ModTime metav1.Time `json:"mtime"`
+	// UID is the user id of the owner of the file
+	UID uint32 `json:"uid"`
+	// Gid is the group id of the owner of the file
+	Gid uint32 `json:"gid"`
+}
+
+// +k8s:conversion-gen:explicit-from=net/url.Values
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SnapshotFilesOptions is the query options of "files" subresource of Snapshot
+type SnapshotFilesOptions struct {
+	metav1.TypeMeta `json:",inline"`
+	// Path lists only the files under this path. Default is "/".
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Limit is the maximum number of files to return. All files are returned if it is not set.
+	// +optional
+	Limit int64 `json:"limit,omitempty"`
+	// Continue is the token returned in the metadata of the previous page to get the next page
+	// +optional
+	Continue string `json:"continue,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SnapshotDownloadOptions is the query options of "download" subresource of Snapshot
+type SnapshotDownloadOptions struct {
+	metav1.TypeMeta `json:",inline"`
+	// Path is the absolute path of the file or directory inside the snapshot to download.
+	// A file is returned as is and a directory is returned as a tar stream.
+	Path string `json:"path"`
+}
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SnapshotDiff shows the changes between two snapshots. It is served by "diff" subresource of Snapshot.
+type SnapshotDiff struct {
+	metav1.TypeMeta `json:",inline"`
+	// From is the name of the older snapshot
+	From string `json:"from"`
+	// To is the name of the newer snapshot
+	To string `json:"to"`
+	// Entries are the files and directories that have been changed
+	// +optional
+	Entries []SnapshotDiffEntry `json:"entries,omitempty"`
+	// Stats is the summary of the changes
+	Stats SnapshotDiffStats `json:"stats"`
+}
+
+type SnapshotDiffChange string
+
+const (
+	SnapshotDiffAdded           SnapshotDiffChange = "Added"
+	SnapshotDiffRemoved         SnapshotDiffChange = "Removed"
+	SnapshotDiffModified        SnapshotDiffChange = "Modified"
+	SnapshotDiffTypeChanged     SnapshotDiffChange = "TypeChanged"
+	SnapshotDiffMetadataChanged SnapshotDiffChange = "MetadataChanged"
+)
+
+type SnapshotDiffEntry struct {
+	// Path is the absolute path of the file or directory inside the snapshots
+	Path string `json:"path"`
+	// Change indicates how the entry has been changed
+	Change SnapshotDiffChange `json:"change"`
+}
+
+type SnapshotDiffStats struct {
+	FilesAdded       int64 `json:"filesAdded"`
+	FilesRemoved     int64 `json:"filesRemoved"`
+	FilesChanged     int64 `json:"filesChanged"`
+	DirsAdded        int64 `json:"dirsAdded"`
+	DirsRemoved      int64 `json:"dirsRemoved"`
+	OthersAdded      int64 `json:"othersAdded"`
+	OthersRemoved    int64 `json:"othersRemoved"`
+	DataBlobsAdded   int64 `json:"dataBlobsAdded"`
+	DataBlobsRemoved int64 `json:"dataBlobsRemoved"`
+	TreeBlobsAdded   int64 `json:"treeBlobsAdded"`
+	TreeBlobsRemoved int64 `json:"treeBlobsRemoved"`
+	// BytesAdded is the size of the blobs that are referenced only by the newer snapshot.
+	// restic reports it in human readable form. So, it is accurate up to three significant decimals of the unit.
+	BytesAdded int64 `json:"bytesAdded"`
+	// BytesRemoved is the size of the blobs that are referenced only by the older snapshot
+	BytesRemoved int64 `json:"bytesRemoved"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SnapshotDiffOptions is the query options of "diff" subresource of Snapshot
+type SnapshotDiffOptions struct {
+	metav1.TypeMeta `json:",inline"`
+	// Against is the name of the older snapshot of the same repository to compare with
+	Against string `json:"against"`
 }
@@ -45,6 +45,86 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotDiff)(nil), (*repositories.SnapshotDiff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SnapshotDiff_To_repositories_SnapshotDiff(a.(*SnapshotDiff), b.(*repositories.SnapshotDiff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*repositories.SnapshotDiff)(nil), (*SnapshotDiff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_repositories_SnapshotDiff_To_v1alpha1_SnapshotDiff(a.(*repositories.SnapshotDiff), b.(*SnapshotDiff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotDiffEntry)(nil), (*repositories.SnapshotDiffEntry)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SnapshotDiffEntry_To_repositories_SnapshotDiffEntry(a.(*SnapshotDiffEntry), b.(*repositories.SnapshotDiffEntry), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*repositories.SnapshotDiffEntry)(nil), (*SnapshotDiffEntry)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_repositories_SnapshotDiffEntry_To_v1alpha1_SnapshotDiffEntry(a.(*repositories.SnapshotDiffEntry), b.(*SnapshotDiffEntry), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotDiffOptions)(nil), (*repositories.SnapshotDiffOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SnapshotDiffOptions_To_repositories_SnapshotDiffOptions(a.(*SnapshotDiffOptions), b.(*repositories.SnapshotDiffOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*repositories.SnapshotDiffOptions)(nil), (*SnapshotDiffOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_repositories_SnapshotDiffOptions_To_v1alpha1_SnapshotDiffOptions(a.(*repositories.SnapshotDiffOptions), b.(*SnapshotDiffOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotDiffStats)(nil), (*repositories.SnapshotDiffStats)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SnapshotDiffStats_To_repositories_SnapshotDiffStats(a.(*SnapshotDiffStats), b.(*repositories.SnapshotDiffStats), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*repositories.SnapshotDiffStats)(nil), (*SnapshotDiffStats)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_repositories_SnapshotDiffStats_To_v1alpha1_SnapshotDiffStats(a.(*repositories.SnapshotDiffStats), b.(*SnapshotDiffStats), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotDownloadOptions)(nil), (*repositories.SnapshotDownloadOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SnapshotDownloadOptions_To_repositories_SnapshotDownloadOptions(a.(*SnapshotDownloadOptions), b.(*repositories.SnapshotDownloadOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*repositories.SnapshotDownloadOptions)(nil), (*SnapshotDownloadOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_repositories_SnapshotDownloadOptions_To_v1alpha1_SnapshotDownloadOptions(a.(*repositories.SnapshotDownloadOptions), b.(*SnapshotDownloadOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotFile)(nil), (*repositories.SnapshotFile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SnapshotFile_To_repositories_SnapshotFile(a.(*SnapshotFile), b.(*repositories.SnapshotFile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*repositories.SnapshotFile)(nil), (*SnapshotFile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_repositories_SnapshotFile_To_v1alpha1_SnapshotFile(a.(*repositories.SnapshotFile), b.(*SnapshotFile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotFileList)(nil), (*repositories.SnapshotFileList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SnapshotFileList_To_repositories_SnapshotFileList(a.(*SnapshotFileList), b.(*repositories.SnapshotFileList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*repositories.SnapshotFileList)(nil), (*SnapshotFileList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_repositories_SnapshotFileList_To_v1alpha1_SnapshotFileList(a.(*repositories.SnapshotFileList), b.(*SnapshotFileList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotFilesOptions)(nil), (*repositories.SnapshotFilesOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SnapshotFilesOptions_To_repositories_SnapshotFilesOptions(a.(*SnapshotFilesOptions), b.(*repositories.SnapshotFilesOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*repositories.SnapshotFilesOptions)(nil), (*SnapshotFilesOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_repositories_SnapshotFilesOptions_To_v1alpha1_SnapshotFilesOptions(a.(*repositories.SnapshotFilesOptions), b.(*SnapshotFilesOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SnapshotList)(nil), (*repositories.SnapshotList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_SnapshotList_To_repositories_SnapshotList(a.(*SnapshotList), b.(*repositories.SnapshotList), scope)
 	}); err != nil {
@@ -55,6 +135,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotListWarning)(nil), (*repositories.SnapshotListWarning)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SnapshotListWarning_To_repositories_SnapshotListWarning(a.(*SnapshotListWarning), b.(*repositories.SnapshotListWarning), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*repositories.SnapshotListWarning)(nil), (*SnapshotListWarning)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_repositories_SnapshotListWarning_To_v1alpha1_SnapshotListWarning(a.(*repositories.SnapshotListWarning), b.(*SnapshotListWarning), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SnapshotStatus)(nil), (*repositories.SnapshotStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_SnapshotStatus_To_repositories_SnapshotStatus(a.(*SnapshotStatus), b.(*repositories.SnapshotStatus), scope)
 	}); err != nil {
@@ -94,9 +184,226 @@ func Convert_repositories_Snapshot_To_v1alpha1_Snapshot(in *repositories.Snapsho
 	return autoConvert_repositories_Snapshot_To_v1alpha1_Snapshot(in, out, s)
 }
 
+func autoConvert_v1alpha1_SnapshotDiff_To_repositories_SnapshotDiff(in *SnapshotDiff, out *repositories.SnapshotDiff, s conversion.Scope) error {
+	out.From = in.From
+	out.To = in.To
+	out.Entries = *(*[]repositories.SnapshotDiffEntry)(unsafe.Pointer(&in.Entries))
+	if err := Convert_v1alpha1_SnapshotDiffStats_To_repositories_SnapshotDiffStats(&in.Stats, &out.Stats, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_SnapshotDiff_To_repositories_SnapshotDiff is an autogenerated conversion function.
+func Convert_v1alpha1_SnapshotDiff_To_repositories_SnapshotDiff(in *SnapshotDiff, out *repositories.SnapshotDiff, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SnapshotDiff_To_repositories_SnapshotDiff(in, out, s)
+}
+
+func autoConvert_repositories_SnapshotDiff_To_v1alpha1_SnapshotDiff(in *repositories.SnapshotDiff, out *SnapshotDiff, s conversion.Scope) error {
+	out.From = in.From
+	out.To = in.To
+	out.Entries = *(*[]SnapshotDiffEntry)(unsafe.Pointer(&in.Entries))
+	if err := Convert_repositories_SnapshotDiffStats_To_v1alpha1_SnapshotDiffStats(&in.Stats, &out.Stats, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_repositories_SnapshotDiff_To_v1alpha1_SnapshotDiff is an autogenerated conversion function.
+func Convert_repositories_SnapshotDiff_To_v1alpha1_SnapshotDiff(in *repositories.SnapshotDiff, out *SnapshotDiff, s conversion.Scope) error {
+	return autoConvert_repositories_SnapshotDiff_To_v1alpha1_SnapshotDiff(in, out, s)
+}
+
+func autoConvert_v1alpha1_SnapshotDiffEntry_To_repositories_SnapshotDiffEntry(in *SnapshotDiffEntry, out *repositories.SnapshotDiffEntry, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Change = repositories.SnapshotDiffChange(in.Change)
+	return nil
+}
+
+// Convert_v1alpha1_SnapshotDiffEntry_To_repositories_SnapshotDiffEntry is an autogenerated conversion function.
+func Convert_v1alpha1_SnapshotDiffEntry_To_repositories_SnapshotDiffEntry(in *SnapshotDiffEntry, out *repositories.SnapshotDiffEntry, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SnapshotDiffEntry_To_repositories_SnapshotDiffEntry(in, out, s)
+}
+
+func autoConvert_repositories_SnapshotDiffEntry_To_v1alpha1_SnapshotDiffEntry(in *repositories.SnapshotDiffEntry, out *SnapshotDiffEntry, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Change = SnapshotDiffChange(in.Change)
+	return nil
+}
+
+// Convert_repositories_SnapshotDiffEntry_To_v1alpha1_SnapshotDiffEntry is an autogenerated conversion function.
+func Convert_repositories_SnapshotDiffEntry_To_v1alpha1_SnapshotDiffEntry(in *repositories.SnapshotDiffEntry, out *SnapshotDiffEntry, s conversion.Scope) error {
+	return autoConvert_repositories_SnapshotDiffEntry_To_v1alpha1_SnapshotDiffEntry(in, out, s)
+}
+
+func autoConvert_v1alpha1_SnapshotDiffOptions_To_repositories_SnapshotDiffOptions(in *SnapshotDiffOptions, out *repositories.SnapshotDiffOptions, s conversion.Scope) error {
+	out.Against = in.Against
+	return nil
+}
+
+// Convert_v1alpha1_SnapshotDiffOptions_To_repositories_SnapshotDiffOptions is an autogenerated conversion function.
+func Convert_v1alpha1_SnapshotDiffOptions_To_repositories_SnapshotDiffOptions(in *SnapshotDiffOptions, out *repositories.SnapshotDiffOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SnapshotDiffOptions_To_repositories_SnapshotDiffOptions(in, out, s)
+}
+
+func autoConvert_repositories_SnapshotDiffOptions_To_v1alpha1_SnapshotDiffOptions(in *repositories.SnapshotDiffOptions, out *SnapshotDiffOptions, s conversion.Scope) error {
+	out.Against = in.Against
+	return nil
+}
+
+// Convert_repositories_SnapshotDiffOptions_To_v1alpha1_SnapshotDiffOptions is an autogenerated conversion function.
+func Convert_repositories_SnapshotDiffOptions_To_v1alpha1_SnapshotDiffOptions(in *repositories.SnapshotDiffOptions, out *SnapshotDiffOptions, s conversion.Scope) error {
+	return autoConvert_repositories_SnapshotDiffOptions_To_v1alpha1_SnapshotDiffOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_SnapshotDiffStats_To_repositories_SnapshotDiffStats(in *SnapshotDiffStats, out *repositories.SnapshotDiffStats, s conversion.Scope) error {
+	out.FilesAdded = in.FilesAdded
+	out.FilesRemoved = in.FilesRemoved
+	out.FilesChanged = in.FilesChanged
+	out.DirsAdded = in.DirsAdded
+	out.DirsRemoved = in.DirsRemoved
+	out.OthersAdded = in.OthersAdded
+	out.OthersRemoved = in.OthersRemoved
+	out.DataBlobsAdded = in.DataBlobsAdded
+	out.DataBlobsRemoved = in.DataBlobsRemoved
+	out.TreeBlobsAdded = in.TreeBlobsAdded
+	out.TreeBlobsRemoved = in.TreeBlobsRemoved
+	out.BytesAdded = in.BytesAdded
+	out.BytesRemoved = in.BytesRemoved
+	return nil
+}
+
+// Convert_v1alpha1_SnapshotDiffStats_To_repositories_SnapshotDiffStats is an autogenerated conversion function.
+func Convert_v1alpha1_SnapshotDiffStats_To_repositories_SnapshotDiffStats(in *SnapshotDiffStats, out *repositories.SnapshotDiffStats, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SnapshotDiffStats_To_repositories_SnapshotDiffStats(in, out, s)
+}
+
+func autoConvert_repositories_SnapshotDiffStats_To_v1alpha1_SnapshotDiffStats(in *repositories.SnapshotDiffStats, out *SnapshotDiffStats, s conversion.Scope) error {
+	out.FilesAdded = in.FilesAdded
+	out.FilesRemoved = in.FilesRemoved
+	out.FilesChanged = in.FilesChanged
+	out.DirsAdded = in.DirsAdded
+	out.DirsRemoved = in.DirsRemoved
+	out.OthersAdded = in.OthersAdded
+	out.OthersRemoved = in.OthersRemoved
+	out.DataBlobsAdded = in.DataBlobsAdded
+	out.DataBlobsRemoved = in.DataBlobsRemoved
+	out.TreeBlobsAdded = in.TreeBlobsAdded
+	out.TreeBlobsRemoved = in.TreeBlobsRemoved
+	out.BytesAdded = in.BytesAdded
+	out.BytesRemoved = in.BytesRemoved
+	return nil
+}
+
+// Convert_repositories_SnapshotDiffStats_To_v1alpha1_SnapshotDiffStats is an autogenerated conversion function.
+func Convert_repositories_SnapshotDiffStats_To_v1alpha1_SnapshotDiffStats(in *repositories.SnapshotDiffStats, out *SnapshotDiffStats, s conversion.Scope) error {
+	return autoConvert_repositories_SnapshotDiffStats_To_v1alpha1_SnapshotDiffStats(in, out, s)
+}
+
+func autoConvert_v1alpha1_SnapshotDownloadOptions_To_repositories_SnapshotDownloadOptions(in *SnapshotDownloadOptions, out *repositories.SnapshotDownloadOptions, s conversion.Scope) error {
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_v1alpha1_SnapshotDownloadOptions_To_repositories_SnapshotDownloadOptions is an autogenerated conversion function.
+func Convert_v1alpha1_SnapshotDownloadOptions_To_repositories_SnapshotDownloadOptions(in *SnapshotDownloadOptions, out *repositories.SnapshotDownloadOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SnapshotDownloadOptions_To_repositories_SnapshotDownloadOptions(in, out, s)
+}
+
+func autoConvert_repositories_SnapshotDownloadOptions_To_v1alpha1_SnapshotDownloadOptions(in *repositories.SnapshotDownloadOptions, out *SnapshotDownloadOptions, s conversion.Scope) error {
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_repositories_SnapshotDownloadOptions_To_v1alpha1_SnapshotDownloadOptions is an autogenerated conversion function.
+func Convert_repositories_SnapshotDownloadOptions_To_v1alpha1_SnapshotDownloadOptions(in *repositories.SnapshotDownloadOptions, out *SnapshotDownloadOptions, s conversion.Scope) error {
+	return autoConvert_repositories_SnapshotDownloadOptions_To_v1alpha1_SnapshotDownloadOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_SnapshotFile_To_repositories_SnapshotFile(in *SnapshotFile, out *repositories.SnapshotFile, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Path = in.Path
+	out.Type = in.Type
+	out.Size = in.Size
+	out.Mode = in.Mode
+	out.ModTime = in.ModTime
+	out.UID = in.UID
+	out.Gid = in.Gid
+	return nil
+}
+
+// Convert_v1alpha1_SnapshotFile_To_repositories_SnapshotFile is an autogenerated conversion function.
+func Convert_v1alpha1_SnapshotFile_To_repositories_SnapshotFile(in *SnapshotFile, out *repositories.SnapshotFile, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SnapshotFile_To_repositories_SnapshotFile(in, out, s)
+}
+
+func autoConvert_repositories_SnapshotFile_To_v1alpha1_SnapshotFile(in *repositories.SnapshotFile, out *SnapshotFile, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Path = in.Path
+	out.Type = in.Type
+	out.Size = in.Size
+	out.Mode = in.Mode
+	out.ModTime = in.ModTime
+	out.UID = in.UID
+	out.Gid = in.Gid
+	return nil
+}
+
+// Convert_repositories_SnapshotFile_To_v1alpha1_SnapshotFile is an autogenerated conversion function.
+func Convert_repositories_SnapshotFile_To_v1alpha1_SnapshotFile(in *repositories.SnapshotFile, out *SnapshotFile, s conversion.Scope) error {
+	return autoConvert_repositories_SnapshotFile_To_v1alpha1_SnapshotFile(in, out, s)
+}
+
+func autoConvert_v1alpha1_SnapshotFileList_To_repositories_SnapshotFileList(in *SnapshotFileList, out *repositories.SnapshotFileList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]repositories.SnapshotFile)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha1_SnapshotFileList_To_repositories_SnapshotFileList is an autogenerated conversion function.
+func Convert_v1alpha1_SnapshotFileList_To_repositories_SnapshotFileList(in *SnapshotFileList, out *repositories.SnapshotFileList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SnapshotFileList_To_repositories_SnapshotFileList(in, out, s)
+}
+
+func autoConvert_repositories_SnapshotFileList_To_v1alpha1_SnapshotFileList(in *repositories.SnapshotFileList, out *SnapshotFileList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]SnapshotFile)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_repositories_SnapshotFileList_To_v1alpha1_SnapshotFileList is an autogenerated conversion function.
+func Convert_repositories_SnapshotFileList_To_v1alpha1_SnapshotFileList(in *repositories.SnapshotFileList, out *SnapshotFileList, s conversion.Scope) error {
+	return autoConvert_repositories_SnapshotFileList_To_v1alpha1_SnapshotFileList(in, out, s)
+}
+
+func autoConvert_v1alpha1_SnapshotFilesOptions_To_repositories_SnapshotFilesOptions(in *SnapshotFilesOptions, out *repositories.SnapshotFilesOptions, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Limit = in.Limit
+	out.Continue = in.Continue
+	return nil
+}
+
+// Convert_v1alpha1_SnapshotFilesOptions_To_repositories_SnapshotFilesOptions is an autogenerated conversion function.
+func Convert_v1alpha1_SnapshotFilesOptions_To_repositories_SnapshotFilesOptions(in *SnapshotFilesOptions, out *repositories.SnapshotFilesOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SnapshotFilesOptions_To_repositories_SnapshotFilesOptions(in, out, s)
+}
+
+func autoConvert_repositories_SnapshotFilesOptions_To_v1alpha1_SnapshotFilesOptions(in *repositories.SnapshotFilesOptions, out *SnapshotFilesOptions, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Limit = in.Limit
+	out.Continue = in.Continue
+	return nil
+}
+
+// Convert_repositories_SnapshotFilesOptions_To_v1alpha1_SnapshotFilesOptions is an autogenerated conversion function.
+func Convert_repositories_SnapshotFilesOptions_To_v1alpha1_SnapshotFilesOptions(in *repositories.SnapshotFilesOptions, out *SnapshotFilesOptions, s conversion.Scope) error {
+	return autoConvert_repositories_SnapshotFilesOptions_To_v1alpha1_SnapshotFilesOptions(in, out, s)
+}
+
 func autoConvert_v1alpha1_SnapshotList_To_repositories_SnapshotList(in *SnapshotList, out *repositories.SnapshotList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	out.Items = *(*[]repositories.Snapshot)(unsafe.Pointer(&in.Items))
+	out.Warnings = *(*[]repositories.SnapshotListWarning)(unsafe.Pointer(&in.Warnings))
 	return nil
 }
 
@@ -108,6 +415,7 @@ func Convert_v1alpha1_SnapshotList_To_repositories_SnapshotList(in *SnapshotList
 func autoConvert_repositories_SnapshotList_To_v1alpha1_SnapshotList(in *repositories.SnapshotList, out *SnapshotList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	out.Items = *(*[]Snapshot)(unsafe.Pointer(&in.Items))
+	out.Warnings = *(*[]SnapshotListWarning)(unsafe.Pointer(&in.Warnings))
 	return nil
 }
 
@@ -116,6 +424,28 @@ func Convert_repositories_SnapshotList_To_v1alpha1_SnapshotList(in *repositories
 	return autoConvert_repositories_SnapshotList_To_v1alpha1_SnapshotList(in, out, s)
 }
 
+func autoConvert_v1alpha1_SnapshotListWarning_To_repositories_SnapshotListWarning(in *SnapshotListWarning, out *repositories.SnapshotListWarning, s conversion.Scope) error {
+	out.Repository = in.Repository
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1alpha1_SnapshotListWarning_To_repositories_SnapshotListWarning is an autogenerated conversion function.
+func Convert_v1alpha1_SnapshotListWarning_To_repositories_SnapshotListWarning(in *SnapshotListWarning, out *repositories.SnapshotListWarning, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SnapshotListWarning_To_repositories_SnapshotListWarning(in, out, s)
+}
+
+func autoConvert_repositories_SnapshotListWarning_To_v1alpha1_SnapshotListWarning(in *repositories.SnapshotListWarning, out *SnapshotListWarning, s conversion.Scope) error {
+	out.Repository = in.Repository
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_repositories_SnapshotListWarning_To_v1alpha1_SnapshotListWarning is an autogenerated conversion function.
+func Convert_repositories_SnapshotListWarning_To_v1alpha1_SnapshotListWarning(in *repositories.SnapshotListWarning, out *SnapshotListWarning, s conversion.Scope) error {
+	return autoConvert_repositories_SnapshotListWarning_To_v1alpha1_SnapshotListWarning(in, out, s)
+}
+
 func autoConvert_v1alpha1_SnapshotStatus_To_repositories_SnapshotStatus(in *SnapshotStatus, out *repositories.SnapshotStatus, s conversion.Scope) error {
 	out.Tree = in.Tree
 	out.Paths = *(*[]string)(unsafe.Pointer(&in.Paths))
@@ -124,6 +454,10 @@ func autoConvert_v1alpha1_SnapshotStatus_To_repositories_SnapshotStatus(in *Snap
 	out.UID = in.UID
 	out.Gid = in.Gid
 	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
+	out.Size = in.Size
+	out.FileCount = in.FileCount
+	out.BackupSession = in.BackupSession
+	out.BackupConfiguration = in.BackupConfiguration
 	return nil
 }
 
@@ -140,6 +474,10 @@ func autoConvert_repositories_SnapshotStatus_To_v1alpha1_SnapshotStatus(in *repo
 	out.UID = in.UID
 	out.Gid = in.Gid
 	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
+	out.Size = in.Size
+	out.FileCount = in.FileCount
+	out.BackupSession = in.BackupSession
+	out.BackupConfiguration = in.BackupConfiguration
 	return nil
 }
 
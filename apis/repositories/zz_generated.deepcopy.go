@@ -51,6 +51,194 @@ func (in *Snapshot) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotDiff) DeepCopyInto(out *SnapshotDiff) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]SnapshotDiffEntry, len(*in))
+		copy(*out, *in)
+	}
+	out.Stats = in.Stats
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotDiff.
+func (in *SnapshotDiff) DeepCopy() *SnapshotDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SnapshotDiff) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotDiffEntry) DeepCopyInto(out *SnapshotDiffEntry) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotDiffEntry.
+func (in *SnapshotDiffEntry) DeepCopy() *SnapshotDiffEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotDiffEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotDiffOptions) DeepCopyInto(out *SnapshotDiffOptions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotDiffOptions.
+func (in *SnapshotDiffOptions) DeepCopy() *SnapshotDiffOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotDiffOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SnapshotDiffOptions) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotDiffStats) DeepCopyInto(out *SnapshotDiffStats) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotDiffStats.
+func (in *SnapshotDiffStats) DeepCopy() *SnapshotDiffStats {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotDiffStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotDownloadOptions) DeepCopyInto(out *SnapshotDownloadOptions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotDownloadOptions.
+func (in *SnapshotDownloadOptions) DeepCopy() *SnapshotDownloadOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotDownloadOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SnapshotDownloadOptions) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotFile) DeepCopyInto(out *SnapshotFile) {
+	*out = *in
+	in.ModTime.DeepCopyInto(&out.ModTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotFile.
+func (in *SnapshotFile) DeepCopy() *SnapshotFile {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotFileList) DeepCopyInto(out *SnapshotFileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SnapshotFile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotFileList.
+func (in *SnapshotFileList) DeepCopy() *SnapshotFileList {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotFileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SnapshotFileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotFilesOptions) DeepCopyInto(out *SnapshotFilesOptions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotFilesOptions.
+func (in *SnapshotFilesOptions) DeepCopy() *SnapshotFilesOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotFilesOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SnapshotFilesOptions) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SnapshotList) DeepCopyInto(out *SnapshotList) {
 	*out = *in
@@ -63,6 +251,11 @@ func (in *SnapshotList) DeepCopyInto(out *SnapshotList) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]SnapshotListWarning, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -84,6 +277,22 @@ func (in *SnapshotList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotListWarning) DeepCopyInto(out *SnapshotListWarning) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotListWarning.
+func (in *SnapshotListWarning) DeepCopy() *SnapshotListWarning {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotListWarning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SnapshotStatus) DeepCopyInto(out *SnapshotStatus) {
 	*out = *in
@@ -64,3 +64,13 @@ func (c Repository) CustomResourceDefinition() *apiextensions.CustomResourceDefi
 		},
 	})
 }
+
+// PasswordRotationIncomplete returns true if a password rotation has added the new key
+// but has not removed the old key yet. Such a rotation should be resumed.
+func PasswordRotationIncomplete(status *PasswordRotationStatus) bool {
+	return status != nil &&
+		status.Phase != PasswordRotationSucceeded &&
+		status.NewKeyID != "" &&
+		status.Step != "" &&
+		status.Step != PasswordRotationOldKeyRemoved
+}
@@ -29,6 +29,59 @@ type RepositorySpec struct {
 	// If true, delete respective restic repository
 	// +optional
 	WipeOut bool `json:"wipeOut,omitempty"`
+	// Maintenance specifies the maintenance tasks that are run periodically on the repository independent of backup
+	// +optional
+	Maintenance *RepositoryMaintenance `json:"maintenance,omitempty"`
+	// PasswordRotation specifies how to rotate the password of the restic repository.
+	// Rotation starts when the new password is added to the storage secret. An interrupted rotation
+	// is resumed from its last completed step.
+	// +optional
+	PasswordRotation *PasswordRotation `json:"passwordRotation,omitempty"`
+}
+
+type PasswordRotation struct {
+	// NewPasswordKey is the key of the storage secret that holds the new password.
+	// Defaults to "NEW_RESTIC_PASSWORD".
+	// +optional
+	NewPasswordKey string `json:"newPasswordKey,omitempty"`
+	// OldKeyRemovalDelay specifies how long to wait after updating the storage secret before removing the
+	// old key from the repository, so that the running backups can pick up the new password. Defaults to 2m.
+	// +optional
+	OldKeyRemovalDelay *metav1.Duration `json:"oldKeyRemovalDelay,omitempty"`
+}
+
+type RepositoryMaintenance struct {
+	// Check specifies the schedule and options for repository integrity check
+	// +optional
+	Check *IntegrityCheckTask `json:"check,omitempty"`
+	// Prune specifies the schedule and retention policy for removing old snapshots and unreferenced data
+	// +optional
+	Prune *PruneTask `json:"prune,omitempty"`
+	// DisableCheckAfterBackup indicates that repository integrity will not be checked after each backup.
+	// Use it along with a scheduled integrity check.
+	// +optional
+	DisableCheckAfterBackup bool `json:"disableCheckAfterBackup,omitempty"`
+}
+
+type IntegrityCheckTask struct {
+	// Schedule specifies when to run the integrity check in cron format
+	Schedule string `json:"schedule,omitempty"`
+	// ReadData indicates whether to read all the pack data to verify their integrity
+	// +optional
+	ReadData bool `json:"readData,omitempty"`
+	// ReadDataSubset specifies the subset of pack data to read in "n/t" format. i.e. "1/5" reads the first of five subsets.
+	// It is ignored if readData is true.
+	// +optional
+	ReadDataSubset string `json:"readDataSubset,omitempty"`
+}
+
+type PruneTask struct {
+	// Schedule specifies when to run the prune task in cron format
+	Schedule string `json:"schedule,omitempty"`
+	// RetentionPolicy specifies which snapshots to remove before pruning.
+	// If not specified, only the data that are not referenced by any snapshot are removed.
+	// +optional
+	RetentionPolicy *RetentionPolicy `json:"retentionPolicy,omitempty"`
 }
 
 type RepositoryStatus struct {
@@ -40,7 +93,7 @@ type RepositoryStatus struct {
 	FirstBackupTime *metav1.Time `json:"firstBackupTime,omitempty"`
 	// LastBackupTime indicates the timestamp when the latest backup was taken
 	LastBackupTime *metav1.Time `json:"lastBackupTime,omitempty"`
-	// Integrity shows result of repository integrity check after last backup
+	// Integrity shows result of the last repository integrity check
 	Integrity *bool `json:"integrity,omitempty"`
 	// Size show size of repository after last backup
 	Size string `json:"size,omitempty"`
@@ -48,6 +101,19 @@ type RepositoryStatus struct {
 	SnapshotCount int `json:"snapshotCount,omitempty"`
 	// SnapshotsRemovedOnLastCleanup shows number of old snapshots cleaned up according to retention policy on last backup session
 	SnapshotsRemovedOnLastCleanup int `json:"snapshotsRemovedOnLastCleanup,omitempty"`
+	// LastCheck shows the result of the last scheduled integrity check
+	// +optional
+	LastCheck *MaintenanceTaskStatus `json:"lastCheck,omitempty"`
+	// LastPrune shows the result of the last scheduled prune task
+	// +optional
+	LastPrune *MaintenanceTaskStatus `json:"lastPrune,omitempty"`
+	// PasswordRotation shows the progress of the last password rotation
+	// +optional
+	PasswordRotation *PasswordRotationStatus `json:"passwordRotation,omitempty"`
+	// PendingPrune shows the snapshots that have been deleted without removing their data. The data are
+	// removed by the next scheduled prune task.
+	// +optional
+	PendingPrune *PendingPruneStatus `json:"pendingPrune,omitempty"`
 
 	// Deprecated
 	LastSuccessfulBackupTime *metav1.Time `json:"lastSuccessfulBackupTime,omitempty"`
@@ -57,6 +123,83 @@ type RepositoryStatus struct {
 	BackupCount int64 `json:"backupCount,omitempty"`
 }
 
+type MaintenanceTaskPhase string
+
+const (
+	MaintenanceTaskSucceeded MaintenanceTaskPhase = "Succeeded"
+	MaintenanceTaskFailed    MaintenanceTaskPhase = "Failed"
+)
+
+const (
+	MaintenanceTaskCheck = "check"
+	MaintenanceTaskPrune = "prune"
+)
+
+type PendingPruneStatus struct {
+	// SnapshotsDeleted is the number of snapshots deleted since the last prune
+	SnapshotsDeleted int `json:"snapshotsDeleted,omitempty"`
+	// Since indicates when the first of these snapshots has been deleted
+	Since *metav1.Time `json:"since,omitempty"`
+}
+
+type MaintenanceTaskStatus struct {
+	// Phase indicates whether the task has succeeded or failed
+	Phase MaintenanceTaskPhase `json:"phase,omitempty"`
+	// StartTime indicates when the task has been started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime indicates when the task has been completed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Duration indicates the time taken to complete the task
+	Duration string `json:"duration,omitempty"`
+	// Error shows the reason of failure
+	Error string `json:"error,omitempty"`
+}
+
+const (
+	DefaultNewPasswordKey = "NEW_RESTIC_PASSWORD"
+)
+
+type PasswordRotationPhase string
+
+const (
+	PasswordRotationRunning   PasswordRotationPhase = "Running"
+	PasswordRotationSucceeded PasswordRotationPhase = "Succeeded"
+	PasswordRotationFailed    PasswordRotationPhase = "Failed"
+)
+
+// PasswordRotationStep indicates the last completed step of a password rotation
+type PasswordRotationStep string
+
+const (
+	PasswordRotationKeyAdded      PasswordRotationStep = "KeyAdded"
+	PasswordRotationKeyVerified   PasswordRotationStep = "KeyVerified"
+	PasswordRotationSecretUpdated PasswordRotationStep = "SecretUpdated"
+	PasswordRotationOldKeyRemoved PasswordRotationStep = "OldKeyRemoved"
+)
+
+type PasswordRotationStatus struct {
+	// Phase indicates whether the rotation is running, has succeeded or has failed
+	Phase PasswordRotationPhase `json:"phase,omitempty"`
+	// Step indicates the last completed step of the rotation
+	// +optional
+	Step PasswordRotationStep `json:"step,omitempty"`
+	// NewKeyID is the ID of the repository key that has been added for the new password
+	// +optional
+	NewKeyID string `json:"newKeyID,omitempty"`
+	// OldKeyID is the ID of the repository key of the old password that will be removed at the end of the rotation
+	// +optional
+	OldKeyID string `json:"oldKeyID,omitempty"`
+	// StartTime indicates when the rotation has been started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime indicates when the rotation has been completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Error shows the reason of failure
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 type RepositoryList struct {
@@ -69,3 +69,10 @@ func (c Restic) CustomResourceDefinition() *apiextensions.CustomResourceDefiniti
 		},
 	})
 }
+
+// HasKeepRule returns true if the policy specifies which snapshots to keep. Without any keep rule,
+// restic does not remove any snapshot.
+func (r RetentionPolicy) HasKeepRule() bool {
+	return r.KeepLast > 0 || r.KeepHourly > 0 || r.KeepDaily > 0 || r.KeepWeekly > 0 ||
+		r.KeepMonthly > 0 || r.KeepYearly > 0 || len(r.KeepTags) > 0 || r.KeepWithin != ""
+}
@@ -82,6 +82,7 @@ const (
 	KeepMonthly RetentionStrategy = "--keep-monthly"
 	KeepYearly  RetentionStrategy = "--keep-yearly"
 	KeepTag     RetentionStrategy = "--keep-tag"
+	KeepWithin  RetentionStrategy = "--keep-within"
 )
 
 type RetentionPolicy struct {
@@ -93,6 +94,44 @@ type RetentionPolicy struct {
 	KeepMonthly int      `json:"keepMonthly,omitempty"`
 	KeepYearly  int      `json:"keepYearly,omitempty"`
 	KeepTags    []string `json:"keepTags,omitempty"`
-	Prune       bool     `json:"prune,omitempty"`
-	DryRun      bool     `json:"dryRun,omitempty"`
+	// KeepWithin keeps all snapshots which have been made within this duration of the latest snapshot.
+	// The duration is specified in restic format i.e. "1y5m7d2h".
+	KeepWithin string `json:"keepWithin,omitempty"`
+	Prune      bool   `json:"prune,omitempty"`
+	DryRun     bool   `json:"dryRun,omitempty"`
+	// Filter specifies how to group the snapshots and which snapshots to consider while applying this policy.
+	// For a BackupConfiguration, if no filter is specified, only the snapshots of the backup host
+	// that has been taken by the BackupConfiguration are considered.
+	// +optional
+	Filter *RetentionFilter `json:"filter,omitempty"`
 }
+
+// SnapshotTagLegalHold is the tag of the snapshots that are under legal hold. These snapshots are
+// always kept by the retention policies and can't be deleted until the tag is removed.
+const SnapshotTagLegalHold = "legal-hold"
+
+type RetentionFilter struct {
+	// GroupBy specifies the criteria to group the snapshots. Policy is applied to each group separately.
+	// Supported values are "host", "paths" and "tags". Default is "host,paths".
+	// Grouping by tags puts only the snapshots with the same set of tags in a group. As the snapshots taken by a
+	// BackupConfiguration are tagged with their BackupSession, group them by "host" and "paths" instead.
+	// +optional
+	GroupBy []RetentionGroupBy `json:"groupBy,omitempty"`
+	// Host restricts the policy to the snapshots of this host only.
+	// +optional
+	Host string `json:"host,omitempty"`
+	// Tags restricts the policy to the snapshots that have all these tags.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// Paths restricts the policy to the snapshots that have all these paths.
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+}
+
+type RetentionGroupBy string
+
+const (
+	GroupByHost  RetentionGroupBy = "host"
+	GroupByPaths RetentionGroupBy = "paths"
+	GroupByTags  RetentionGroupBy = "tags"
+)
@@ -2,11 +2,14 @@ package v1alpha1
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	cron "gopkg.in/robfig/cron.v2"
 )
 
+var readDataSubsetRegex = regexp.MustCompile(`^[1-9][0-9]*/[1-9][0-9]*$`)
+
 func (r Restic) IsValid() error {
 	for i, fg := range r.Spec.FileGroups {
 		if fg.RetentionPolicyName == "" {
@@ -73,5 +76,20 @@ func (r Repository) IsValid() error {
 			return fmt.Errorf("wipe out operation is not supported for B2 backend")
 		}
 	}
+	if r.Spec.Maintenance != nil {
+		if check := r.Spec.Maintenance.Check; check != nil {
+			if _, err := cron.Parse(check.Schedule); err != nil {
+				return fmt.Errorf("spec.maintenance.check.schedule %s is invalid. Reason: %s", check.Schedule, err)
+			}
+			if check.ReadDataSubset != "" && !readDataSubsetRegex.MatchString(check.ReadDataSubset) {
+				return fmt.Errorf("spec.maintenance.check.readDataSubset %s is invalid. It must be in n/t format", check.ReadDataSubset)
+			}
+		}
+		if prune := r.Spec.Maintenance.Prune; prune != nil {
+			if _, err := cron.Parse(prune.Schedule); err != nil {
+				return fmt.Errorf("spec.maintenance.prune.schedule %s is invalid. Reason: %s", prune.Schedule, err)
+			}
+		}
+	}
 	return nil
 }
@@ -22,8 +22,9 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
-	v1 "kmodules.xyz/objectstore-api/api/v1"
+	apiv1 "kmodules.xyz/objectstore-api/api/v1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -47,6 +48,22 @@ func (in *FileGroup) DeepCopy() *FileGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrityCheckTask) DeepCopyInto(out *IntegrityCheckTask) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrityCheckTask.
+func (in *IntegrityCheckTask) DeepCopy() *IntegrityCheckTask {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrityCheckTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalTypedReference) DeepCopyInto(out *LocalTypedReference) {
 	*out = *in
@@ -63,6 +80,116 @@ func (in *LocalTypedReference) DeepCopy() *LocalTypedReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceTaskStatus) DeepCopyInto(out *MaintenanceTaskStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceTaskStatus.
+func (in *MaintenanceTaskStatus) DeepCopy() *MaintenanceTaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceTaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordRotation) DeepCopyInto(out *PasswordRotation) {
+	*out = *in
+	if in.OldKeyRemovalDelay != nil {
+		in, out := &in.OldKeyRemovalDelay, &out.OldKeyRemovalDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRotation.
+func (in *PasswordRotation) DeepCopy() *PasswordRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordRotationStatus) DeepCopyInto(out *PasswordRotationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRotationStatus.
+func (in *PasswordRotationStatus) DeepCopy() *PasswordRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingPruneStatus) DeepCopyInto(out *PendingPruneStatus) {
+	*out = *in
+	if in.Since != nil {
+		in, out := &in.Since, &out.Since
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingPruneStatus.
+func (in *PendingPruneStatus) DeepCopy() *PendingPruneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingPruneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PruneTask) DeepCopyInto(out *PruneTask) {
+	*out = *in
+	if in.RetentionPolicy != nil {
+		in, out := &in.RetentionPolicy, &out.RetentionPolicy
+		*out = new(RetentionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruneTask.
+func (in *PruneTask) DeepCopy() *PruneTask {
+	if in == nil {
+		return nil
+	}
+	out := new(PruneTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Recovery) DeepCopyInto(out *Recovery) {
 	*out = *in
@@ -135,7 +262,7 @@ func (in *RecoverySpec) DeepCopyInto(out *RecoverySpec) {
 	}
 	if in.RecoveredVolumes != nil {
 		in, out := &in.RecoveredVolumes, &out.RecoveredVolumes
-		*out = make([]v1.LocalSpec, len(*in))
+		*out = make([]apiv1.LocalSpec, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -251,10 +378,46 @@ func (in *RepositoryList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryMaintenance) DeepCopyInto(out *RepositoryMaintenance) {
+	*out = *in
+	if in.Check != nil {
+		in, out := &in.Check, &out.Check
+		*out = new(IntegrityCheckTask)
+		**out = **in
+	}
+	if in.Prune != nil {
+		in, out := &in.Prune, &out.Prune
+		*out = new(PruneTask)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryMaintenance.
+func (in *RepositoryMaintenance) DeepCopy() *RepositoryMaintenance {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryMaintenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepositorySpec) DeepCopyInto(out *RepositorySpec) {
 	*out = *in
 	in.Backend.DeepCopyInto(&out.Backend)
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(RepositoryMaintenance)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordRotation != nil {
+		in, out := &in.PasswordRotation, &out.PasswordRotation
+		*out = new(PasswordRotation)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -288,6 +451,26 @@ func (in *RepositoryStatus) DeepCopyInto(out *RepositoryStatus) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.LastCheck != nil {
+		in, out := &in.LastCheck, &out.LastCheck
+		*out = new(MaintenanceTaskStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastPrune != nil {
+		in, out := &in.LastPrune, &out.LastPrune
+		*out = new(MaintenanceTaskStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordRotation != nil {
+		in, out := &in.PasswordRotation, &out.PasswordRotation
+		*out = new(PasswordRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PendingPrune != nil {
+		in, out := &in.PendingPrune, &out.PendingPrune
+		*out = new(PendingPruneStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LastSuccessfulBackupTime != nil {
 		in, out := &in.LastSuccessfulBackupTime, &out.LastSuccessfulBackupTime
 		*out = (*in).DeepCopy()
@@ -426,6 +609,37 @@ func (in *RestoreStats) DeepCopy() *RestoreStats {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetentionFilter) DeepCopyInto(out *RetentionFilter) {
+	*out = *in
+	if in.GroupBy != nil {
+		in, out := &in.GroupBy, &out.GroupBy
+		*out = make([]RetentionGroupBy, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetentionFilter.
+func (in *RetentionFilter) DeepCopy() *RetentionFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(RetentionFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RetentionPolicy) DeepCopyInto(out *RetentionPolicy) {
 	*out = *in
@@ -434,6 +648,11 @@ func (in *RetentionPolicy) DeepCopyInto(out *RetentionPolicy) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(RetentionFilter)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
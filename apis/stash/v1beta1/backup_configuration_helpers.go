@@ -4,10 +4,13 @@ import (
 	"hash/fnv"
 	"strconv"
 
+	core "k8s.io/api/core/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	hashutil "k8s.io/kubernetes/pkg/util/hash"
 	crdutils "kmodules.xyz/client-go/apiextensions/v1beta1"
 	meta_util "kmodules.xyz/client-go/meta"
+	"stash.appscode.dev/stash/apis"
 )
 
 func (b BackupConfiguration) GetSpecHash() string {
@@ -35,9 +38,10 @@ func (b BackupConfiguration) CustomResourceDefinition() *apiextensions.CustomRes
 		Labels: crdutils.Labels{
 			LabelsMap: map[string]string{"app": "stash"},
 		},
-		SpecDefinitionName:    "stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfiguration",
-		EnableValidation:      true,
-		GetOpenAPIDefinitions: GetOpenAPIDefinitions,
+		SpecDefinitionName:      "stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfiguration",
+		EnableValidation:        true,
+		GetOpenAPIDefinitions:   GetOpenAPIDefinitions,
+		EnableStatusSubresource: apis.EnableStatusSubresource,
 		AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{
 			{
 				Name:     "Task",
@@ -54,6 +58,16 @@ func (b BackupConfiguration) CustomResourceDefinition() *apiextensions.CustomRes
 				Type:     "boolean",
 				JSONPath: ".spec.paused",
 			},
+			{
+				Name:     "Ready",
+				Type:     "string",
+				JSONPath: ".status.conditions[?(@.type==\"Ready\")].status",
+			},
+			{
+				Name:     "Last-Successful-Backup",
+				Type:     "date",
+				JSONPath: ".status.lastSuccessfulSession.completionTime",
+			},
 			{
 				Name:     "Age",
 				Type:     "date",
@@ -82,3 +96,47 @@ func upsertLabels(originalLabels, overrides map[string]string) map[string]string
 	}
 	return originalLabels
 }
+
+// GetCondition returns the condition of the given type. It returns nil if the condition does not exist.
+func (s BackupConfigurationStatus) GetCondition(condType BackupConfigurationConditionType) *BackupConfigurationCondition {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == condType {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsConditionTrue returns true if the condition of the given type exists and its status is "True"
+func (s BackupConfigurationStatus) IsConditionTrue(condType BackupConfigurationConditionType) bool {
+	cond := s.GetCondition(condType)
+	return cond != nil && cond.Status == core.ConditionTrue
+}
+
+// SetCondition adds or updates a condition. LastTransitionTime is updated only if the status of the condition has changed.
+func (s *BackupConfigurationStatus) SetCondition(cond BackupConfigurationCondition) {
+	cur := s.GetCondition(cond.Type)
+	if cur == nil {
+		if cond.LastTransitionTime.IsZero() {
+			cond.LastTransitionTime = metav1.Now()
+		}
+		s.Conditions = append(s.Conditions, cond)
+		return
+	}
+	if cur.Status != cond.Status {
+		cur.LastTransitionTime = metav1.Now()
+	}
+	cur.Status = cond.Status
+	cur.Reason = cond.Reason
+	cur.Message = cond.Message
+}
+
+// RemoveCondition removes the condition of the given type if it exists
+func (s *BackupConfigurationStatus) RemoveCondition(condType BackupConfigurationConditionType) {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == condType {
+			s.Conditions = append(s.Conditions[:i], s.Conditions[i+1:]...)
+			return
+		}
+	}
+}
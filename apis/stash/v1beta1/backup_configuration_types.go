@@ -21,7 +21,8 @@ const (
 type BackupConfiguration struct {
 	metav1.TypeMeta   `json:",inline,omitempty"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
-	Spec              BackupConfigurationSpec `json:"spec,omitempty"`
+	Spec              BackupConfigurationSpec   `json:"spec,omitempty"`
+	Status            BackupConfigurationStatus `json:"status,omitempty"`
 }
 
 type BackupConfigurationSpec struct {
@@ -52,6 +53,107 @@ type BackupConfigurationSpec struct {
 	// An `EmptyDir` will always be mounted at /tmp with this settings
 	//+optional
 	TempDir EmptyDirSettings `json:"tempDir,omitempty"`
+	// Hooks specifies the actions to take before or after backup
+	// +optional
+	Hooks *BackupHooks `json:"hooks,omitempty"`
+	// ConcurrencyPolicy specifies how to treat a new BackupSession if a previous BackupSession
+	// of this BackupConfiguration is still running. Valid values are:
+	// - "Allow" (default): allows the BackupSessions to run concurrently;
+	// - "Forbid": skips the new BackupSession;
+	// - "Replace": cancels the running BackupSession and starts the new one once the cancelled one has stopped.
+	// +optional
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	// ActiveDeadlineSeconds specifies the duration in seconds relative to the creation time of a BackupSession
+	// that the BackupSession may run. Once the deadline is exceeded, the running backup is stopped and
+	// the BackupSession is marked as "Failed". It must be a positive value.
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+	// RPO (Recovery Point Objective) specifies the maximum acceptable age of the newest successful backup.
+	// If the newest successful backup gets older than this, "BackupStale" condition is set to "True".
+	// +optional
+	RPO *metav1.Duration `json:"rpo,omitempty"`
+	// SuccessfulSessionsHistoryLimit specifies the number of succeeded BackupSessions to keep.
+	// Older BackupSessions are deleted along with their Jobs. If not specified, all of them are kept.
+	// +optional
+	SuccessfulSessionsHistoryLimit *int32 `json:"successfulSessionsHistoryLimit,omitempty"`
+	// FailedSessionsHistoryLimit specifies the number of failed BackupSessions to keep.
+	// Older BackupSessions are deleted along with their Jobs. The most recent failed BackupSession is
+	// always kept for debugging. If not specified, all of them are kept.
+	// +optional
+	FailedSessionsHistoryLimit *int32 `json:"failedSessionsHistoryLimit,omitempty"`
+	// SkippedSessionsHistoryLimit specifies the number of skipped BackupSessions to keep.
+	// Older BackupSessions are deleted. If not specified, all of them are kept.
+	// +optional
+	SkippedSessionsHistoryLimit *int32 `json:"skippedSessionsHistoryLimit,omitempty"`
+	// CancelledSessionsHistoryLimit specifies the number of cancelled BackupSessions to keep.
+	// Older BackupSessions are deleted along with their Jobs. If not specified, all of them are kept.
+	// +optional
+	CancelledSessionsHistoryLimit *int32 `json:"cancelledSessionsHistoryLimit,omitempty"`
+	// Notifiers refer to the Notifiers in the same namespace that will be notified about the outcomes of the
+	// BackupSessions, the staleness of the backup and the failed checks of the Repository
+	// +optional
+	Notifiers []core.LocalObjectReference `json:"notifiers,omitempty"`
+}
+
+// ConcurrencyPolicy describes how the BackupSessions of a BackupConfiguration will be handled.
+type ConcurrencyPolicy string
+
+const (
+	AllowConcurrent   ConcurrencyPolicy = "Allow"
+	ForbidConcurrent  ConcurrencyPolicy = "Forbid"
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+)
+
+type BackupConfigurationStatus struct {
+	// Conditions shows the current state of the backup setup of this BackupConfiguration
+	// +optional
+	Conditions []BackupConfigurationCondition `json:"conditions,omitempty"`
+	// LastSuccessfulSession refers to the most recent BackupSession that has succeeded
+	// +optional
+	LastSuccessfulSession *BackupSessionReference `json:"lastSuccessfulSession,omitempty"`
+	// LastFailedSession refers to the most recent BackupSession that has failed
+	// +optional
+	LastFailedSession *BackupSessionReference `json:"lastFailedSession,omitempty"`
+	// NextScheduleTime indicates when the next BackupSession will be triggered according to the schedule
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+}
+
+type BackupSessionReference struct {
+	// Name of the BackupSession
+	Name string `json:"name"`
+	// CompletionTime indicates when the BackupSession has been completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+type BackupConfigurationConditionType string
+
+const (
+	// BackupConfigurationReady indicates whether everything has been setup to take backup on schedule
+	BackupConfigurationReady BackupConfigurationConditionType = "Ready"
+	// CronJobCreated indicates whether the CronJob that triggers BackupSessions has been created
+	CronJobCreated BackupConfigurationConditionType = "CronJobCreated"
+	// SidecarInjected indicates whether the backup sidecar has been injected into the target workload
+	SidecarInjected BackupConfigurationConditionType = "SidecarInjected"
+	// BackupStale indicates whether the newest successful backup is older than the RPO
+	BackupStale BackupConfigurationConditionType = "BackupStale"
+)
+
+type BackupConfigurationCondition struct {
+	// Type of the condition
+	Type BackupConfigurationConditionType `json:"type"`
+	// Status of the condition, one of "True", "False" or "Unknown"
+	Status core.ConditionStatus `json:"status"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a brief machine readable explanation for the condition's last transition
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable description of the details of the last transition
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type EmptyDirSettings struct {
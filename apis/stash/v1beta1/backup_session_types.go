@@ -26,6 +26,10 @@ type BackupSession struct {
 type BackupSessionSpec struct {
 	// BackupConfiguration indicates the target BackupConfiguration crd
 	BackupConfiguration core.LocalObjectReference `json:"backupConfiguration,omitempty"`
+	// Cancel requests to stop the backup process of this session. Running backup will be interrupted
+	// and the session will be marked as "Cancelled". Once requested, cancellation can't be withdrawn.
+	// +optional
+	Cancel bool `json:"cancel,omitempty"`
 }
 
 type BackupSessionPhase string
@@ -36,6 +40,7 @@ const (
 	BackupSessionSucceeded BackupSessionPhase = "Succeeded"
 	BackupSessionFailed    BackupSessionPhase = "Failed"
 	BackupSessionSkipped   BackupSessionPhase = "Skipped"
+	BackupSessionCancelled BackupSessionPhase = "Cancelled"
 	BackupSessionUnknown   BackupSessionPhase = "Unknown"
 )
 
@@ -44,6 +49,7 @@ type HostBackupPhase string
 const (
 	HostBackupSucceeded HostBackupPhase = "Succeeded"
 	HostBackupFailed    HostBackupPhase = "Failed"
+	HostBackupCancelled HostBackupPhase = "Cancelled"
 )
 
 type BackupSessionStatus struct {
@@ -61,9 +67,45 @@ type BackupSessionStatus struct {
 	// SessionDuration specify total time taken to complete current backup session (sum of backup duration of all hosts)
 	// +optional
 	SessionDuration string `json:"sessionDuration,omitempty"`
+	// CompletionTime is the time when the BackupSession has succeeded or failed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 	// Stats shows statistics of individual hosts for this backup session
 	// +optional
 	Stats []HostBackupStats `json:"stats,omitempty"`
+	// Progress shows the progress of the hosts that are being backed up
+	// +optional
+	Progress []HostBackupProgress `json:"progress,omitempty"`
+}
+
+type HostBackupProgress struct {
+	// Hostname indicates name of the host that is being backed up
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+	// Directory indicates the directory that is being backed up
+	// +optional
+	Directory string `json:"directory,omitempty"`
+	// PercentDone indicates the percentage of data of the directory that has been processed
+	// +optional
+	PercentDone int32 `json:"percentDone,omitempty"`
+	// TotalBytes indicates total size of the data to process in bytes
+	// +optional
+	TotalBytes int64 `json:"totalBytes,omitempty"`
+	// BytesDone indicates size of the data that has been processed in bytes
+	// +optional
+	BytesDone int64 `json:"bytesDone,omitempty"`
+	// TotalFiles indicates total number of files to process
+	// +optional
+	TotalFiles int64 `json:"totalFiles,omitempty"`
+	// FilesDone indicates number of files that has been processed
+	// +optional
+	FilesDone int64 `json:"filesDone,omitempty"`
+	// ETA indicates the estimated time remaining to complete backup of the directory
+	// +optional
+	ETA string `json:"eta,omitempty"`
+	// LastUpdateTime indicates when the progress has been updated last time
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
 }
 
 type HostBackupStats struct {
@@ -82,6 +124,9 @@ type HostBackupStats struct {
 	// Error indicates string value of error in case of backup failure
 	// +optional
 	Error string `json:"error,omitempty"`
+	// Hooks shows the result of the hooks that has been executed for this host
+	// +optional
+	Hooks []HookStats `json:"hooks,omitempty"`
 }
 
 type SnapshotStats struct {
@@ -0,0 +1,83 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"text/template"
+	"time"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	crdutils "kmodules.xyz/client-go/apiextensions/v1beta1"
+)
+
+func (n Notifier) CustomResourceDefinition() *apiextensions.CustomResourceDefinition {
+	return crdutils.NewCustomResourceDefinition(crdutils.Config{
+		Group:         SchemeGroupVersion.Group,
+		Plural:        ResourcePluralNotifier,
+		Singular:      ResourceSingularNotifier,
+		Kind:          ResourceKindNotifier,
+		Categories:    []string{"stash", "appscode"},
+		ResourceScope: string(apiextensions.NamespaceScoped),
+		Versions: []apiextensions.CustomResourceDefinitionVersion{
+			{
+				Name:    SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Labels: crdutils.Labels{
+			LabelsMap: map[string]string{"app": "stash"},
+		},
+		SpecDefinitionName:    "stash.appscode.dev/stash/apis/stash/v1beta1.Notifier",
+		EnableValidation:      true,
+		GetOpenAPIDefinitions: GetOpenAPIDefinitions,
+		AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{
+			{
+				Name:     "Age",
+				Type:     "date",
+				JSONPath: ".metadata.creationTimestamp",
+			},
+		},
+	})
+}
+
+// Subscribes returns true if the Notifier should be notified about the event
+func (n Notifier) Subscribes(event NotificationEvent) bool {
+	if len(n.Spec.Events) == 0 {
+		return true
+	}
+	for _, e := range n.Spec.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxRetries returns the number of times a failed notification will be retried
+func (n Notifier) MaxRetries() int {
+	if n.Spec.Retry != nil && n.Spec.Retry.MaxRetries != nil {
+		return int(*n.Spec.Retry.MaxRetries)
+	}
+	return int(DefaultNotificationMaxRetries)
+}
+
+// Backoff returns the delay before the first retry of a failed notification
+func (n Notifier) Backoff() time.Duration {
+	if n.Spec.Retry != nil && n.Spec.Retry.Backoff != nil {
+		return n.Spec.Retry.Backoff.Duration
+	}
+	return DefaultNotificationBackoff
+}
+
+// BodyTemplate parses the template of the notification body. It returns nil if no template has been specified.
+func (w WebhookNotifier) BodyTemplate() (*template.Template, error) {
+	if w.Body == "" {
+		return nil, nil
+	}
+	return template.New("body").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}).Option("missingkey=error").Parse(w.Body)
+}
@@ -0,0 +1,125 @@
+package v1beta1
+
+import (
+	"time"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindNotifier     = "Notifier"
+	ResourceSingularNotifier = "notifier"
+	ResourcePluralNotifier   = "notifiers"
+)
+
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Notifier sends notifications about the outcomes of backup and restore to a webhook.
+// BackupConfigurations and RestoreSessions opt in to a Notifier by referring it in their "notifiers" field.
+type Notifier struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              NotifierSpec `json:"spec,omitempty"`
+}
+
+type NotifierSpec struct {
+	// Events specifies the events to notify about. All events are notified if it is empty.
+	// +optional
+	Events []NotificationEvent `json:"events,omitempty"`
+	// Webhook specifies the HTTP endpoint to send the notifications to
+	Webhook WebhookNotifier `json:"webhook"`
+	// Retry specifies how to retry a failed notification
+	// +optional
+	Retry *NotificationRetryPolicy `json:"retry,omitempty"`
+	// RateLimit specifies the maximum number of notifications to send in a period.
+	// The notifications exceeding the limit are dropped.
+	// +optional
+	RateLimit *NotificationRateLimit `json:"rateLimit,omitempty"`
+}
+
+type NotificationEvent string
+
+const (
+	NotificationBackupSessionSucceeded  NotificationEvent = "BackupSessionSucceeded"
+	NotificationBackupSessionFailed     NotificationEvent = "BackupSessionFailed"
+	NotificationBackupSessionSkipped    NotificationEvent = "BackupSessionSkipped"
+	NotificationBackupSessionCancelled  NotificationEvent = "BackupSessionCancelled"
+	NotificationBackupStale             NotificationEvent = "BackupStale"
+	NotificationRestoreSessionSucceeded NotificationEvent = "RestoreSessionSucceeded"
+	NotificationRestoreSessionFailed    NotificationEvent = "RestoreSessionFailed"
+	NotificationRestoreSessionCancelled NotificationEvent = "RestoreSessionCancelled"
+	NotificationRepositoryCheckFailed   NotificationEvent = "RepositoryCheckFailed"
+)
+
+type WebhookNotifier struct {
+	// URL of the webhook. Use URLFrom instead if the URL contains credentials (i.e. Slack incoming webhook).
+	// +optional
+	URL string `json:"url,omitempty"`
+	// URLFrom selects a key of a Secret in the namespace of the Notifier that holds the URL of the webhook.
+	// The Secret must have the label "stash.appscode.com/notifier-secret: true".
+	// +optional
+	URLFrom *core.SecretKeySelector `json:"urlFrom,omitempty"`
+	// Method is the HTTP method used to send the notifications. Default value is "POST".
+	// +optional
+	Method string `json:"method,omitempty"`
+	// Headers specifies the HTTP headers to send with the notifications
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+	// HeadersFrom refers to a Secret in the namespace of the Notifier whose keys and values are sent as HTTP headers.
+	// Use it for the headers holding credentials (i.e. Authorization).
+	// The Secret must have the label "stash.appscode.com/notifier-secret: true".
+	// +optional
+	HeadersFrom *core.LocalObjectReference `json:"headersFrom,omitempty"`
+	// Body is a Go template that renders the body of the notification. The fields of the notification
+	// (i.e. {{ .Event }}, {{ .Namespace }}, {{ .Name }}, {{ .Message }}) are available in the template.
+	// The "json" function quotes a value as JSON string. The notification is sent as JSON if it is empty.
+	// +optional
+	Body string `json:"body,omitempty"`
+	// ContentType is the value of the Content-Type header. Default value is "application/json".
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
+	// Timeout of a single request. Default value is 10s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// InsecureSkipTLSVerify disables the verification of the certificate of the webhook
+	// +optional
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+}
+
+type NotificationRetryPolicy struct {
+	// MaxRetries specifies the number of times a failed notification is retried. Default value is 3.
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+	// Backoff specifies the delay before the first retry. The delay is doubled for each subsequent retry.
+	// Default value is 5s.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+}
+
+type NotificationRateLimit struct {
+	// Limit specifies the maximum number of notifications to send in a period
+	Limit int32 `json:"limit"`
+	// Period over which the limit is applied
+	Period metav1.Duration `json:"period"`
+}
+
+const (
+	DefaultNotificationMaxRetries int32 = 3
+	DefaultNotificationBackoff          = 5 * time.Second
+	DefaultNotificationTimeout          = 10 * time.Second
+)
+
+// LabelNotifierSecret must be set to "true" in the Secrets referred by a Notifier. Other Secrets are never read
+// for a Notifier. Otherwise, anyone allowed to create a Notifier could send any Secret of the namespace to a webhook.
+const LabelNotifierSecret = StashKey + "/notifier-secret"
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type NotifierList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Notifier `json:"items,omitempty"`
+}
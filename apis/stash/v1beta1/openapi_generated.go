@@ -300,37 +300,55 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kmodules.xyz/offshoot-api/api/v1.ServiceSpec":                                schema_kmodulesxyz_offshoot_api_api_v1_ServiceSpec(ref),
 		"kmodules.xyz/offshoot-api/api/v1.ServiceTemplateSpec":                        schema_kmodulesxyz_offshoot_api_api_v1_ServiceTemplateSpec(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfiguration":             schema_stash_apis_stash_v1beta1_BackupConfiguration(ref),
+		"stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfigurationCondition":    schema_stash_apis_stash_v1beta1_BackupConfigurationCondition(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfigurationList":         schema_stash_apis_stash_v1beta1_BackupConfigurationList(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfigurationSpec":         schema_stash_apis_stash_v1beta1_BackupConfigurationSpec(ref),
+		"stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfigurationStatus":       schema_stash_apis_stash_v1beta1_BackupConfigurationStatus(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfigurationTemplate":     schema_stash_apis_stash_v1beta1_BackupConfigurationTemplate(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfigurationTemplateList": schema_stash_apis_stash_v1beta1_BackupConfigurationTemplateList(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfigurationTemplateSpec": schema_stash_apis_stash_v1beta1_BackupConfigurationTemplateSpec(ref),
+		"stash.appscode.dev/stash/apis/stash/v1beta1.BackupHooks":                     schema_stash_apis_stash_v1beta1_BackupHooks(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.BackupSession":                   schema_stash_apis_stash_v1beta1_BackupSession(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.BackupSessionList":               schema_stash_apis_stash_v1beta1_BackupSessionList(ref),
+		"stash.appscode.dev/stash/apis/stash/v1beta1.BackupSessionReference":          schema_stash_apis_stash_v1beta1_BackupSessionReference(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.BackupSessionSpec":               schema_stash_apis_stash_v1beta1_BackupSessionSpec(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.BackupSessionStatus":             schema_stash_apis_stash_v1beta1_BackupSessionStatus(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.BackupTarget":                    schema_stash_apis_stash_v1beta1_BackupTarget(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.EmptyDirSettings":                schema_stash_apis_stash_v1beta1_EmptyDirSettings(ref),
+		"stash.appscode.dev/stash/apis/stash/v1beta1.ExecHook":                        schema_stash_apis_stash_v1beta1_ExecHook(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.FileStats":                       schema_stash_apis_stash_v1beta1_FileStats(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.Function":                        schema_stash_apis_stash_v1beta1_Function(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.FunctionList":                    schema_stash_apis_stash_v1beta1_FunctionList(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.FunctionRef":                     schema_stash_apis_stash_v1beta1_FunctionRef(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.FunctionSpec":                    schema_stash_apis_stash_v1beta1_FunctionSpec(ref),
+		"stash.appscode.dev/stash/apis/stash/v1beta1.HTTPHook":                        schema_stash_apis_stash_v1beta1_HTTPHook(ref),
+		"stash.appscode.dev/stash/apis/stash/v1beta1.HookSpec":                        schema_stash_apis_stash_v1beta1_HookSpec(ref),
+		"stash.appscode.dev/stash/apis/stash/v1beta1.HookStats":                       schema_stash_apis_stash_v1beta1_HookStats(ref),
+		"stash.appscode.dev/stash/apis/stash/v1beta1.HostBackupProgress":              schema_stash_apis_stash_v1beta1_HostBackupProgress(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.HostBackupStats":                 schema_stash_apis_stash_v1beta1_HostBackupStats(ref),
+		"stash.appscode.dev/stash/apis/stash/v1beta1.HostRestoreProgress":             schema_stash_apis_stash_v1beta1_HostRestoreProgress(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.HostRestoreStats":                schema_stash_apis_stash_v1beta1_HostRestoreStats(ref),
+		"stash.appscode.dev/stash/apis/stash/v1beta1.NotificationRateLimit":           schema_stash_apis_stash_v1beta1_NotificationRateLimit(ref),
+		"stash.appscode.dev/stash/apis/stash/v1beta1.NotificationRetryPolicy":         schema_stash_apis_stash_v1beta1_NotificationRetryPolicy(ref),
+		"stash.appscode.dev/stash/apis/stash/v1beta1.Notifier":                        schema_stash_apis_stash_v1beta1_Notifier(ref),
+		"stash.appscode.dev/stash/apis/stash/v1beta1.NotifierList":                    schema_stash_apis_stash_v1beta1_NotifierList(ref),
+		"stash.appscode.dev/stash/apis/stash/v1beta1.NotifierSpec":                    schema_stash_apis_stash_v1beta1_NotifierSpec(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.Param":                           schema_stash_apis_stash_v1beta1_Param(ref),
+		"stash.appscode.dev/stash/apis/stash/v1beta1.RestoreHooks":                    schema_stash_apis_stash_v1beta1_RestoreHooks(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.RestoreSession":                  schema_stash_apis_stash_v1beta1_RestoreSession(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.RestoreSessionList":              schema_stash_apis_stash_v1beta1_RestoreSessionList(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.RestoreSessionSpec":              schema_stash_apis_stash_v1beta1_RestoreSessionSpec(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.RestoreSessionStatus":            schema_stash_apis_stash_v1beta1_RestoreSessionStatus(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.RestoreTarget":                   schema_stash_apis_stash_v1beta1_RestoreTarget(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.Rule":                            schema_stash_apis_stash_v1beta1_Rule(ref),
+		"stash.appscode.dev/stash/apis/stash/v1beta1.SnapshotSelector":                schema_stash_apis_stash_v1beta1_SnapshotSelector(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.SnapshotStats":                   schema_stash_apis_stash_v1beta1_SnapshotStats(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.TargetRef":                       schema_stash_apis_stash_v1beta1_TargetRef(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.Task":                            schema_stash_apis_stash_v1beta1_Task(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.TaskList":                        schema_stash_apis_stash_v1beta1_TaskList(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.TaskRef":                         schema_stash_apis_stash_v1beta1_TaskRef(ref),
 		"stash.appscode.dev/stash/apis/stash/v1beta1.TaskSpec":                        schema_stash_apis_stash_v1beta1_TaskSpec(ref),
+		"stash.appscode.dev/stash/apis/stash/v1beta1.WebhookNotifier":                 schema_stash_apis_stash_v1beta1_WebhookNotifier(ref),
 	}
 }
 
@@ -13472,11 +13490,65 @@ func schema_stash_apis_stash_v1beta1_BackupConfiguration(ref common.ReferenceCal
 							Ref: ref("stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfigurationSpec"),
 						},
 					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfigurationStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfigurationSpec", "stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfigurationStatus"},
+	}
+}
+
+func schema_stash_apis_stash_v1beta1_BackupConfigurationCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of the condition",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status of the condition, one of \"True\", \"False\" or \"Unknown\"",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastTransitionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastTransitionTime is the last time the condition transitioned from one status to another",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason is a brief machine readable explanation for the condition's last transition",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is a human readable description of the details of the last transition",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
+				Required: []string{"type", "status"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfigurationSpec"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -13587,11 +13659,123 @@ func schema_stash_apis_stash_v1beta1_BackupConfigurationSpec(ref common.Referenc
 							Ref:         ref("stash.appscode.dev/stash/apis/stash/v1beta1.EmptyDirSettings"),
 						},
 					},
+					"hooks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hooks specifies the actions to take before or after backup",
+							Ref:         ref("stash.appscode.dev/stash/apis/stash/v1beta1.BackupHooks"),
+						},
+					},
+					"concurrencyPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConcurrencyPolicy specifies how to treat a new BackupSession if a previous BackupSession of this BackupConfiguration is still running. Valid values are: - \"Allow\" (default): allows the BackupSessions to run concurrently; - \"Forbid\": skips the new BackupSession; - \"Replace\": cancels the running BackupSession and starts the new one once the cancelled one has stopped.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"activeDeadlineSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActiveDeadlineSeconds specifies the duration in seconds relative to the creation time of a BackupSession that the BackupSession may run. Once the deadline is exceeded, the running backup is stopped and the BackupSession is marked as \"Failed\". It must be a positive value.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"rpo": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RPO (Recovery Point Objective) specifies the maximum acceptable age of the newest successful backup. If the newest successful backup gets older than this, \"BackupStale\" condition is set to \"True\".",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"successfulSessionsHistoryLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SuccessfulSessionsHistoryLimit specifies the number of succeeded BackupSessions to keep. Older BackupSessions are deleted along with their Jobs. If not specified, all of them are kept.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"failedSessionsHistoryLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailedSessionsHistoryLimit specifies the number of failed BackupSessions to keep. Older BackupSessions are deleted along with their Jobs. The most recent failed BackupSession is always kept for debugging. If not specified, all of them are kept.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"skippedSessionsHistoryLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SkippedSessionsHistoryLimit specifies the number of skipped BackupSessions to keep. Older BackupSessions are deleted. If not specified, all of them are kept.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"cancelledSessionsHistoryLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CancelledSessionsHistoryLimit specifies the number of cancelled BackupSessions to keep. Older BackupSessions are deleted along with their Jobs. If not specified, all of them are kept.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"notifiers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Notifiers refer to the Notifiers in the same namespace that will be notified about the outcomes of the BackupSessions, the staleness of the backup and the failed checks of the Repository",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.LocalObjectReference"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.LocalObjectReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "kmodules.xyz/offshoot-api/api/v1.RuntimeSettings", "stash.appscode.dev/stash/apis/stash/v1alpha1.RetentionPolicy", "stash.appscode.dev/stash/apis/stash/v1beta1.BackupHooks", "stash.appscode.dev/stash/apis/stash/v1beta1.BackupTarget", "stash.appscode.dev/stash/apis/stash/v1beta1.EmptyDirSettings", "stash.appscode.dev/stash/apis/stash/v1beta1.TaskRef"},
+	}
+}
+
+func schema_stash_apis_stash_v1beta1_BackupConfigurationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions shows the current state of the backup setup of this BackupConfiguration",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfigurationCondition"),
+									},
+								},
+							},
+						},
+					},
+					"lastSuccessfulSession": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSuccessfulSession refers to the most recent BackupSession that has succeeded",
+							Ref:         ref("stash.appscode.dev/stash/apis/stash/v1beta1.BackupSessionReference"),
+						},
+					},
+					"lastFailedSession": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastFailedSession refers to the most recent BackupSession that has failed",
+							Ref:         ref("stash.appscode.dev/stash/apis/stash/v1beta1.BackupSessionReference"),
+						},
+					},
+					"nextScheduleTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NextScheduleTime indicates when the next BackupSession will be triggered according to the schedule",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.LocalObjectReference", "kmodules.xyz/offshoot-api/api/v1.RuntimeSettings", "stash.appscode.dev/stash/apis/stash/v1alpha1.RetentionPolicy", "stash.appscode.dev/stash/apis/stash/v1beta1.BackupTarget", "stash.appscode.dev/stash/apis/stash/v1beta1.EmptyDirSettings", "stash.appscode.dev/stash/apis/stash/v1beta1.TaskRef"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfigurationCondition", "stash.appscode.dev/stash/apis/stash/v1beta1.BackupSessionReference"},
 	}
 }
 
@@ -13697,6 +13881,18 @@ func schema_stash_apis_stash_v1beta1_BackupConfigurationTemplateSpec(ref common.
 							Format:      "",
 						},
 					},
+					"maintenance": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Maintenance specifies the maintenance tasks that are run periodically on the repository independent of backup",
+							Ref:         ref("stash.appscode.dev/stash/apis/stash/v1alpha1.RepositoryMaintenance"),
+						},
+					},
+					"passwordRotation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PasswordRotation specifies how to rotate the password of the restic repository. Rotation starts when the new password is added to the storage secret. An interrupted rotation is resumed from its last completed step.",
+							Ref:         ref("stash.appscode.dev/stash/apis/stash/v1alpha1.PasswordRotation"),
+						},
+					},
 					"schedule": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
@@ -13731,7 +13927,34 @@ func schema_stash_apis_stash_v1beta1_BackupConfigurationTemplateSpec(ref common.
 			},
 		},
 		Dependencies: []string{
-			"kmodules.xyz/objectstore-api/api/v1.Backend", "kmodules.xyz/offshoot-api/api/v1.RuntimeSettings", "stash.appscode.dev/stash/apis/stash/v1alpha1.RetentionPolicy", "stash.appscode.dev/stash/apis/stash/v1beta1.EmptyDirSettings", "stash.appscode.dev/stash/apis/stash/v1beta1.TaskRef"},
+			"kmodules.xyz/objectstore-api/api/v1.Backend", "kmodules.xyz/offshoot-api/api/v1.RuntimeSettings", "stash.appscode.dev/stash/apis/stash/v1alpha1.PasswordRotation", "stash.appscode.dev/stash/apis/stash/v1alpha1.RepositoryMaintenance", "stash.appscode.dev/stash/apis/stash/v1alpha1.RetentionPolicy", "stash.appscode.dev/stash/apis/stash/v1beta1.EmptyDirSettings", "stash.appscode.dev/stash/apis/stash/v1beta1.TaskRef"},
+	}
+}
+
+func schema_stash_apis_stash_v1beta1_BackupHooks(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BackupHooks specifies the actions that Stash should take before or after backup.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"preBackup": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PreBackup is called immediately before a backup is taken for a host. Backup is aborted if this hook fails unless its failurePolicy is \"Continue\".",
+							Ref:         ref("stash.appscode.dev/stash/apis/stash/v1beta1.HookSpec"),
+						},
+					},
+					"postBackup": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PostBackup is called immediately after a backup is taken for a host. It is executed even if the backup has failed, so that the target can be reverted to its normal state.",
+							Ref:         ref("stash.appscode.dev/stash/apis/stash/v1beta1.HookSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"stash.appscode.dev/stash/apis/stash/v1beta1.HookSpec"},
 	}
 }
 
@@ -13823,6 +14046,34 @@ func schema_stash_apis_stash_v1beta1_BackupSessionList(ref common.ReferenceCallb
 	}
 }
 
+func schema_stash_apis_stash_v1beta1_BackupSessionReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the BackupSession",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime indicates when the BackupSession has been completed",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_stash_apis_stash_v1beta1_BackupSessionSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -13835,6 +14086,13 @@ func schema_stash_apis_stash_v1beta1_BackupSessionSpec(ref common.ReferenceCallb
 							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
 						},
 					},
+					"cancel": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cancel requests to stop the backup process of this session. Running backup will be interrupted and the session will be marked as \"Cancelled\". Once requested, cancellation can't be withdrawn.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -13876,6 +14134,12 @@ func schema_stash_apis_stash_v1beta1_BackupSessionStatus(ref common.ReferenceCal
 							Format:      "",
 						},
 					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time when the BackupSession has succeeded or failed",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 					"stats": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Stats shows statistics of individual hosts for this backup session",
@@ -13889,11 +14153,24 @@ func schema_stash_apis_stash_v1beta1_BackupSessionStatus(ref common.ReferenceCal
 							},
 						},
 					},
+					"progress": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Progress shows the progress of the hosts that are being backed up",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("stash.appscode.dev/stash/apis/stash/v1beta1.HostBackupProgress"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/appscode/go/encoding/json/types.IntHash", "stash.appscode.dev/stash/apis/stash/v1beta1.HostBackupStats"},
+			"github.com/appscode/go/encoding/json/types.IntHash", "k8s.io/apimachinery/pkg/apis/meta/v1.Time", "stash.appscode.dev/stash/apis/stash/v1beta1.HostBackupProgress", "stash.appscode.dev/stash/apis/stash/v1beta1.HostBackupStats"},
 	}
 }
 
@@ -13950,49 +14227,160 @@ func schema_stash_apis_stash_v1beta1_BackupTarget(ref common.ReferenceCallback)
 							Format:      "",
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"k8s.io/api/core/v1.VolumeMount", "stash.appscode.dev/stash/apis/stash/v1beta1.TargetRef"},
-	}
-}
-
-func schema_stash_apis_stash_v1beta1_EmptyDirSettings(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"medium": {
+					"tags": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Tags specifies additional tags to add to the snapshots taken for this target. Stash always tags the snapshots with the BackupSession, BackupConfiguration and target kind/name. A tag can't contain comma.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
 						},
 					},
-					"sizeLimit": {
+					"exclude": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+							Description: "Exclude specifies the patterns of the files and directories to exclude from backup. The patterns follow restic's \"--exclude\" syntax.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
 						},
 					},
-					"disableCaching": {
+					"include": {
 						SchemaProps: spec.SchemaProps{
-							Description: "More info: https://github.com/restic/restic/blob/master/doc/manual_rest.rst#caching",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Include specifies the patterns of the files and directories to backup. The patterns are relative to each target directory. If specified, only the matching files and directories are backed up. The patterns follow restic's syntax i.e. \"*\", \"?\" and \"[...]\" match inside a path component and \"**\" matches any number of path components.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
-	}
-}
-
-func schema_stash_apis_stash_v1beta1_FileStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
+					"excludeIfPresent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExcludeIfPresent excludes a directory if it contains a file with any of these names (i.e. \".nobackup\")",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"ignoreFileName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IgnoreFileName is the name of a file (i.e. \".stashignore\") in the root of each target directory that lists the patterns to exclude, one pattern per line.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"excludeCaches": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExcludeCaches excludes the directories that contain a CACHEDIR.TAG file",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"oneFileSystem": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OneFileSystem prevents backup from crossing the file system boundaries of the target directories",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.VolumeMount", "stash.appscode.dev/stash/apis/stash/v1beta1.TargetRef"},
+	}
+}
+
+func schema_stash_apis_stash_v1beta1_EmptyDirSettings(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"medium": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"sizeLimit": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"disableCaching": {
+						SchemaProps: spec.SchemaProps{
+							Description: "More info: https://github.com/restic/restic/blob/master/doc/manual_rest.rst#caching",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_stash_apis_stash_v1beta1_ExecHook(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"container": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Container is the name of the container where the command will be executed. If not specified, the first container of the pod other than stash will be used. For the targets that are backed up or restored by a job, the command is executed inside the job container.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"command": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Command is the command line to execute inside the container. The command is not run inside a shell, so traditional shell instructions ('|', etc) won't work. To use a shell, you need to explicitly call out to that shell.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"command"},
+			},
+		},
+	}
+}
+
+func schema_stash_apis_stash_v1beta1_FileStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
@@ -14252,119 +14640,622 @@ func schema_stash_apis_stash_v1beta1_FunctionSpec(ref common.ReferenceCallback)
 							},
 						},
 					},
-					"runtimeSettings": {
+					"runtimeSettings": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RuntimeSettings allow to specify Resources, LivenessProbe, ReadinessProbe, Lifecycle, SecurityContext etc.",
+							Ref:         ref("kmodules.xyz/offshoot-api/api/v1.ContainerRuntimeSettings"),
+						},
+					},
+					"podSecurityPolicyName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of PodSecurityPolicy(PSP) required by this function",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.ContainerPort", "k8s.io/api/core/v1.VolumeDevice", "k8s.io/api/core/v1.VolumeMount", "kmodules.xyz/offshoot-api/api/v1.ContainerRuntimeSettings"},
+	}
+}
+
+func schema_stash_apis_stash_v1beta1_HTTPHook(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"method": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Method is the http method to use. Supported values are \"GET\", \"POST\". Default value is \"GET\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"scheme": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Scheme to use for connecting to the host. Default value is \"HTTP\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"host": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Host name to connect to. Default value is \"localhost\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Port number to connect to.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path to access on the HTTP server.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"httpHeaders": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Custom headers to set in the request.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.HTTPHeader"),
+									},
+								},
+							},
+						},
+					},
+					"body": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Body to send with a POST request.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"port"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.HTTPHeader"},
+	}
+}
+
+func schema_stash_apis_stash_v1beta1_HookSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HookSpec specifies a single action to take. Exactly one of \"exec\" or \"http\" must be specified.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"exec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Exec specifies a command to execute inside a container.",
+							Ref:         ref("stash.appscode.dev/stash/apis/stash/v1beta1.ExecHook"),
+						},
+					},
+					"http": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HTTP specifies a http request to send.",
+							Ref:         ref("stash.appscode.dev/stash/apis/stash/v1beta1.HTTPHook"),
+						},
+					},
+					"failurePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailurePolicy specifies what to do if the hook fails. Supported values are \"Abort\", \"Continue\". Default value is \"Abort\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeoutSeconds specifies the number of seconds after which the hook is considered as failed. Default value is 30 seconds.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"stash.appscode.dev/stash/apis/stash/v1beta1.ExecHook", "stash.appscode.dev/stash/apis/stash/v1beta1.HTTPHook"},
+	}
+}
+
+func schema_stash_apis_stash_v1beta1_HookStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name indicates which hook has been executed i.e. \"preBackup\", \"postBackup\", \"preRestore\" or \"postRestore\"",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase indicates whether the hook has succeeded or failed",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"failurePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailurePolicy indicates the failure policy that has been applied for this hook",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration indicates time taken to execute the hook",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"error": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Error indicates string value of error in case of hook failure",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_stash_apis_stash_v1beta1_HostBackupProgress(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"hostname": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hostname indicates name of the host that is being backed up",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"directory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Directory indicates the directory that is being backed up",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"percentDone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PercentDone indicates the percentage of data of the directory that has been processed",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"totalBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TotalBytes indicates total size of the data to process in bytes",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"bytesDone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BytesDone indicates size of the data that has been processed in bytes",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"totalFiles": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TotalFiles indicates total number of files to process",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"filesDone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FilesDone indicates number of files that has been processed",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"eta": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ETA indicates the estimated time remaining to complete backup of the directory",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastUpdateTime indicates when the progress has been updated last time",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_stash_apis_stash_v1beta1_HostBackupStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"hostname": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hostname indicate name of the host that has been backed up",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase indicates backup phase of this host",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"snapshots": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Snapshots specifies the stats of individual snapshots that has been taken for this host in current backup session",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("stash.appscode.dev/stash/apis/stash/v1beta1.SnapshotStats"),
+									},
+								},
+							},
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration indicates total time taken to complete backup for this hosts",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"error": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Error indicates string value of error in case of backup failure",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"hooks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hooks shows the result of the hooks that has been executed for this host",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("stash.appscode.dev/stash/apis/stash/v1beta1.HookStats"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"stash.appscode.dev/stash/apis/stash/v1beta1.HookStats", "stash.appscode.dev/stash/apis/stash/v1beta1.SnapshotStats"},
+	}
+}
+
+func schema_stash_apis_stash_v1beta1_HostRestoreProgress(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"hostname": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hostname indicates name of the host that is being restored",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"bytesRestored": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BytesRestored indicates size of the data that has been restored in bytes",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"filesRestored": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FilesRestored indicates number of files that has been restored",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"lastUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastUpdateTime indicates when the progress has been updated last time",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_stash_apis_stash_v1beta1_HostRestoreStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"hostname": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hostname indicate name of the host that has been restored",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase indicates restore phase of this host",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration indicates total time taken to complete restore for this hosts",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"error": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Error indicates string value of error in case of restore failure",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"hooks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hooks shows the result of the hooks that has been executed for this host",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("stash.appscode.dev/stash/apis/stash/v1beta1.HookStats"),
+									},
+								},
+							},
+						},
+					},
+					"snapshots": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Snapshots indicates the IDs of the snapshots that have been restored for this host",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"stash.appscode.dev/stash/apis/stash/v1beta1.HookStats"},
+	}
+}
+
+func schema_stash_apis_stash_v1beta1_NotificationRateLimit(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"limit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Limit specifies the maximum number of notifications to send in a period",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"period": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Period over which the limit is applied",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+				Required: []string{"limit", "period"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_stash_apis_stash_v1beta1_NotificationRetryPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxRetries": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRetries specifies the number of times a failed notification is retried. Default value is 3.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"backoff": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Backoff specifies the delay before the first retry. The delay is doubled for each subsequent retry. Default value is 5s.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_stash_apis_stash_v1beta1_Notifier(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Notifier sends notifications about the outcomes of backup and restore to a webhook. BackupConfigurations and RestoreSessions opt in to a Notifier by referring it in their \"notifiers\" field.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
 						SchemaProps: spec.SchemaProps{
-							Description: "RuntimeSettings allow to specify Resources, LivenessProbe, ReadinessProbe, Lifecycle, SecurityContext etc.",
-							Ref:         ref("kmodules.xyz/offshoot-api/api/v1.ContainerRuntimeSettings"),
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
 						},
 					},
-					"podSecurityPolicyName": {
+					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Name of PodSecurityPolicy(PSP) required by this function",
-							Type:        []string{"string"},
-							Format:      "",
+							Ref: ref("stash.appscode.dev/stash/apis/stash/v1beta1.NotifierSpec"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.ContainerPort", "k8s.io/api/core/v1.VolumeDevice", "k8s.io/api/core/v1.VolumeMount", "kmodules.xyz/offshoot-api/api/v1.ContainerRuntimeSettings"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "stash.appscode.dev/stash/apis/stash/v1beta1.NotifierSpec"},
 	}
 }
 
-func schema_stash_apis_stash_v1beta1_HostBackupStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_stash_apis_stash_v1beta1_NotifierList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"hostname": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Hostname indicate name of the host that has been backed up",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"phase": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Phase indicates backup phase of this host",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"snapshots": {
+					"metadata": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Snapshots specifies the stats of individual snapshots that has been taken for this host in current backup session",
-							Type:        []string{"array"},
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("stash.appscode.dev/stash/apis/stash/v1beta1.SnapshotStats"),
+										Ref: ref("stash.appscode.dev/stash/apis/stash/v1beta1.Notifier"),
 									},
 								},
 							},
 						},
 					},
-					"duration": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Duration indicates total time taken to complete backup for this hosts",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"error": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Error indicates string value of error in case of backup failure",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"stash.appscode.dev/stash/apis/stash/v1beta1.SnapshotStats"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "stash.appscode.dev/stash/apis/stash/v1beta1.Notifier"},
 	}
 }
 
-func schema_stash_apis_stash_v1beta1_HostRestoreStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_stash_apis_stash_v1beta1_NotifierSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"hostname": {
+					"events": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Hostname indicate name of the host that has been restored",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Events specifies the events to notify about. All events are notified if it is empty.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
 						},
 					},
-					"phase": {
+					"webhook": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Phase indicates restore phase of this host",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Webhook specifies the HTTP endpoint to send the notifications to",
+							Ref:         ref("stash.appscode.dev/stash/apis/stash/v1beta1.WebhookNotifier"),
 						},
 					},
-					"duration": {
+					"retry": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Duration indicates total time taken to complete restore for this hosts",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Retry specifies how to retry a failed notification",
+							Ref:         ref("stash.appscode.dev/stash/apis/stash/v1beta1.NotificationRetryPolicy"),
 						},
 					},
-					"error": {
+					"rateLimit": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Error indicates string value of error in case of restore failure",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "RateLimit specifies the maximum number of notifications to send in a period. The notifications exceeding the limit are dropped.",
+							Ref:         ref("stash.appscode.dev/stash/apis/stash/v1beta1.NotificationRateLimit"),
 						},
 					},
 				},
+				Required: []string{"webhook"},
 			},
 		},
+		Dependencies: []string{
+			"stash.appscode.dev/stash/apis/stash/v1beta1.NotificationRateLimit", "stash.appscode.dev/stash/apis/stash/v1beta1.NotificationRetryPolicy", "stash.appscode.dev/stash/apis/stash/v1beta1.WebhookNotifier"},
 	}
 }
 
@@ -14394,6 +15285,33 @@ func schema_stash_apis_stash_v1beta1_Param(ref common.ReferenceCallback) common.
 	}
 }
 
+func schema_stash_apis_stash_v1beta1_RestoreHooks(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RestoreHooks specifies the actions that Stash should take before or after restore. As restore runs in an init-container, application containers are not running yet. So, exec hooks are executed inside the init-container itself.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"preRestore": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PreRestore is called immediately before restoring a host. Restore is aborted if this hook fails unless its failurePolicy is \"Continue\".",
+							Ref:         ref("stash.appscode.dev/stash/apis/stash/v1beta1.HookSpec"),
+						},
+					},
+					"postRestore": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PostRestore is called immediately after a host has been restored successfully.",
+							Ref:         ref("stash.appscode.dev/stash/apis/stash/v1beta1.HookSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"stash.appscode.dev/stash/apis/stash/v1beta1.HookSpec"},
+	}
+}
+
 func schema_stash_apis_stash_v1beta1_RestoreSession(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -14538,11 +15456,37 @@ func schema_stash_apis_stash_v1beta1_RestoreSessionSpec(ref common.ReferenceCall
 							Ref:         ref("stash.appscode.dev/stash/apis/stash/v1beta1.EmptyDirSettings"),
 						},
 					},
+					"hooks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hooks specifies the actions to take before or after restore",
+							Ref:         ref("stash.appscode.dev/stash/apis/stash/v1beta1.RestoreHooks"),
+						},
+					},
+					"cancel": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cancel requests to stop the restore process of this session. Running restore will be interrupted and the session will be marked as \"Cancelled\". Once requested, cancellation can't be withdrawn.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"notifiers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Notifiers refer to the Notifiers in the same namespace that will be notified about the outcome of the restore",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.LocalObjectReference"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.LocalObjectReference", "kmodules.xyz/offshoot-api/api/v1.RuntimeSettings", "stash.appscode.dev/stash/apis/stash/v1beta1.EmptyDirSettings", "stash.appscode.dev/stash/apis/stash/v1beta1.RestoreTarget", "stash.appscode.dev/stash/apis/stash/v1beta1.Rule", "stash.appscode.dev/stash/apis/stash/v1beta1.TaskRef"},
+			"k8s.io/api/core/v1.LocalObjectReference", "kmodules.xyz/offshoot-api/api/v1.RuntimeSettings", "stash.appscode.dev/stash/apis/stash/v1beta1.EmptyDirSettings", "stash.appscode.dev/stash/apis/stash/v1beta1.RestoreHooks", "stash.appscode.dev/stash/apis/stash/v1beta1.RestoreTarget", "stash.appscode.dev/stash/apis/stash/v1beta1.Rule", "stash.appscode.dev/stash/apis/stash/v1beta1.TaskRef"},
 	}
 }
 
@@ -14592,11 +15536,24 @@ func schema_stash_apis_stash_v1beta1_RestoreSessionStatus(ref common.ReferenceCa
 							},
 						},
 					},
+					"progress": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Progress shows the progress of the hosts that are being restored",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("stash.appscode.dev/stash/apis/stash/v1beta1.HostRestoreProgress"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/appscode/go/encoding/json/types.IntHash", "stash.appscode.dev/stash/apis/stash/v1beta1.HostRestoreStats"},
+			"github.com/appscode/go/encoding/json/types.IntHash", "stash.appscode.dev/stash/apis/stash/v1beta1.HostRestoreProgress", "stash.appscode.dev/stash/apis/stash/v1beta1.HostRestoreStats"},
 	}
 }
 
@@ -14708,9 +15665,100 @@ func schema_stash_apis_stash_v1beta1_Rule(ref common.ReferenceCallback) common.O
 							},
 						},
 					},
+					"snapshotSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SnapshotSelector selects the snapshots to restore by time, tags or BackupSession. If paths are specified, the latest matching snapshot of each path is restored. Otherwise, the latest matching snapshot of each backed up path of the source host is restored. Don't specify if you have specified snapshots field.",
+							Ref:         ref("stash.appscode.dev/stash/apis/stash/v1beta1.SnapshotSelector"),
+						},
+					},
+					"include": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Include specifies the patterns of the files and directories to restore from the selected snapshots or paths. The patterns follow restic's \"--include\" syntax. If specified, only the matching files are restored. It can't be used together with exclude or with \"SkipExisting\" and \"CleanTargetFirst\" conflict policies.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"exclude": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Exclude specifies the patterns of the files and directories that should not be restored. The patterns follow restic's \"--exclude\" syntax. It can't be used with \"SkipExisting\" conflict policy.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"destination": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Destination specifies an absolute directory path where the data will be restored. The original path of the data is preserved under this directory (i.e. \"/data\" is restored into \"<destination>/data\"). If not specified, the data is restored into its original path.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"conflictPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConflictPolicy specifies how to handle the files that already exist in the restore path. Valid values are: - \"Overwrite\" (default): overwrites the existing files; - \"SkipExisting\": keeps the existing files untouched and restores only the missing files; - \"CleanTargetFirst\": removes the existing content of the restore path before restoring.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"stash.appscode.dev/stash/apis/stash/v1beta1.SnapshotSelector"},
+	}
+}
+
+func schema_stash_apis_stash_v1beta1_SnapshotSelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"before": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Before selects the latest snapshot taken at or before this time",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"tags": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tags selects the snapshots that have all of these tags",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"backupSession": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BackupSession selects the snapshots taken by this BackupSession",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -14952,3 +16000,85 @@ func schema_stash_apis_stash_v1beta1_TaskSpec(ref common.ReferenceCallback) comm
 			"k8s.io/api/core/v1.Volume", "stash.appscode.dev/stash/apis/stash/v1beta1.FunctionRef"},
 	}
 }
+
+func schema_stash_apis_stash_v1beta1_WebhookNotifier(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URL of the webhook. Use URLFrom instead if the URL contains credentials (i.e. Slack incoming webhook).",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"urlFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URLFrom selects a key of a Secret in the namespace of the Notifier that holds the URL of the webhook. The Secret must have the label \"stash.appscode.com/notifier-secret: true\".",
+							Ref:         ref("k8s.io/api/core/v1.SecretKeySelector"),
+						},
+					},
+					"method": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Method is the HTTP method used to send the notifications. Default value is \"POST\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"headers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Headers specifies the HTTP headers to send with the notifications",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"headersFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HeadersFrom refers to a Secret in the namespace of the Notifier whose keys and values are sent as HTTP headers. Use it for the headers holding credentials (i.e. Authorization). The Secret must have the label \"stash.appscode.com/notifier-secret: true\".",
+							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
+						},
+					},
+					"body": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Body is a Go template that renders the body of the notification. The fields of the notification (i.e. {{ .Event }}, {{ .Namespace }}, {{ .Name }}, {{ .Message }}) are available in the template. The \"json\" function quotes a value as JSON string. The notification is sent as JSON if it is empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"contentType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ContentType is the value of the Content-Type header. Default value is \"application/json\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeout of a single request. Default value is 10s.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"insecureSkipTLSVerify": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InsecureSkipTLSVerify disables the verification of the certificate of the webhook",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.SecretKeySelector", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
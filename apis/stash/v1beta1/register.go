@@ -44,6 +44,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&RestoreSessionList{},
 		&Task{},
 		&TaskList{},
+		&Notifier{},
+		&NotifierList{},
 	)
 
 	scheme.AddKnownTypes(SchemeGroupVersion,
@@ -49,6 +49,16 @@ type RestoreSessionSpec struct {
 	// An `EmptyDir` will always be mounted at /tmp with this settings
 	//+optional
 	TempDir EmptyDirSettings `json:"tempDir,omitempty"`
+	// Hooks specifies the actions to take before or after restore
+	// +optional
+	Hooks *RestoreHooks `json:"hooks,omitempty"`
+	// Cancel requests to stop the restore process of this session. Running restore will be interrupted
+	// and the session will be marked as "Cancelled". Once requested, cancellation can't be withdrawn.
+	// +optional
+	Cancel bool `json:"cancel,omitempty"`
+	// Notifiers refer to the Notifiers in the same namespace that will be notified about the outcome of the restore
+	// +optional
+	Notifiers []core.LocalObjectReference `json:"notifiers,omitempty"`
 }
 
 type Rule struct {
@@ -67,8 +77,56 @@ type Rule struct {
 	// Don't specify if you have specified snapshots field.
 	// +optional
 	Paths []string `json:"paths,omitempty"`
+	// SnapshotSelector selects the snapshots to restore by time, tags or BackupSession.
+	// If paths are specified, the latest matching snapshot of each path is restored. Otherwise, the latest
+	// matching snapshot of each backed up path of the source host is restored.
+	// Don't specify if you have specified snapshots field.
+	// +optional
+	SnapshotSelector *SnapshotSelector `json:"snapshotSelector,omitempty"`
+	// Include specifies the patterns of the files and directories to restore from the selected snapshots or paths.
+	// The patterns follow restic's "--include" syntax. If specified, only the matching files are restored.
+	// It can't be used together with exclude or with "SkipExisting" and "CleanTargetFirst" conflict policies.
+	// +optional
+	Include []string `json:"include,omitempty"`
+	// Exclude specifies the patterns of the files and directories that should not be restored.
+	// The patterns follow restic's "--exclude" syntax. It can't be used with "SkipExisting" conflict policy.
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+	// Destination specifies an absolute directory path where the data will be restored. The original path of
+	// the data is preserved under this directory (i.e. "/data" is restored into "<destination>/data").
+	// If not specified, the data is restored into its original path.
+	// +optional
+	Destination string `json:"destination,omitempty"`
+	// ConflictPolicy specifies how to handle the files that already exist in the restore path.
+	// Valid values are:
+	// - "Overwrite" (default): overwrites the existing files;
+	// - "SkipExisting": keeps the existing files untouched and restores only the missing files;
+	// - "CleanTargetFirst": removes the existing content of the restore path before restoring.
+	// +optional
+	ConflictPolicy RestoreConflictPolicy `json:"conflictPolicy,omitempty"`
 }
 
+type SnapshotSelector struct {
+	// Before selects the latest snapshot taken at or before this time
+	// +optional
+	Before *metav1.Time `json:"before,omitempty"`
+	// Tags selects the snapshots that have all of these tags
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// BackupSession selects the snapshots taken by this BackupSession
+	// +optional
+	BackupSession string `json:"backupSession,omitempty"`
+}
+
+// RestoreConflictPolicy describes how the files that already exist in the restore path will be handled
+type RestoreConflictPolicy string
+
+const (
+	ConflictPolicyOverwrite        RestoreConflictPolicy = "Overwrite"
+	ConflictPolicySkipExisting     RestoreConflictPolicy = "SkipExisting"
+	ConflictPolicyCleanTargetFirst RestoreConflictPolicy = "CleanTargetFirst"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 type RestoreSessionList struct {
@@ -84,6 +142,7 @@ const (
 	RestoreSessionRunning   RestoreSessionPhase = "Running"
 	RestoreSessionSucceeded RestoreSessionPhase = "Succeeded"
 	RestoreSessionFailed    RestoreSessionPhase = "Failed"
+	RestoreSessionCancelled RestoreSessionPhase = "Cancelled"
 	RestoreSessionUnknown   RestoreSessionPhase = "Unknown"
 )
 
@@ -92,6 +151,7 @@ type HostRestorePhase string
 const (
 	HostRestoreSucceeded HostRestorePhase = "Succeeded"
 	HostRestoreFailed    HostRestorePhase = "Failed"
+	HostRestoreCancelled HostRestorePhase = "Cancelled"
 	HostRestoreUnknown   HostRestorePhase = "Unknown"
 )
 
@@ -113,6 +173,24 @@ type RestoreSessionStatus struct {
 	// Stats shows statistics of individual hosts for this restore session
 	// +optional
 	Stats []HostRestoreStats `json:"stats,omitempty"`
+	// Progress shows the progress of the hosts that are being restored
+	// +optional
+	Progress []HostRestoreProgress `json:"progress,omitempty"`
+}
+
+type HostRestoreProgress struct {
+	// Hostname indicates name of the host that is being restored
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+	// BytesRestored indicates size of the data that has been restored in bytes
+	// +optional
+	BytesRestored int64 `json:"bytesRestored,omitempty"`
+	// FilesRestored indicates number of files that has been restored
+	// +optional
+	FilesRestored int64 `json:"filesRestored,omitempty"`
+	// LastUpdateTime indicates when the progress has been updated last time
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
 }
 
 type HostRestoreStats struct {
@@ -128,4 +206,10 @@ type HostRestoreStats struct {
 	// Error indicates string value of error in case of restore failure
 	// +optional
 	Error string `json:"error,omitempty"`
+	// Hooks shows the result of the hooks that has been executed for this host
+	// +optional
+	Hooks []HookStats `json:"hooks,omitempty"`
+	// Snapshots indicates the IDs of the snapshots that have been restored for this host
+	// +optional
+	Snapshots []string `json:"snapshots,omitempty"`
 }
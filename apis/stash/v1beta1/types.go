@@ -34,6 +34,34 @@ type BackupTarget struct {
 	// Use this field only if the "driver" field is set to "volumeSnapshotter".
 	// +optional
 	VolumeSnapshotClassName string `json:"snapshotClassName,omitempty"`
+	// Tags specifies additional tags to add to the snapshots taken for this target.
+	// Stash always tags the snapshots with the BackupSession, BackupConfiguration and target kind/name.
+	// A tag can't contain comma.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// Exclude specifies the patterns of the files and directories to exclude from backup.
+	// The patterns follow restic's "--exclude" syntax.
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+	// Include specifies the patterns of the files and directories to backup. The patterns are relative
+	// to each target directory. If specified, only the matching files and directories are backed up.
+	// The patterns follow restic's syntax i.e. "*", "?" and "[...]" match inside a path component and
+	// "**" matches any number of path components.
+	// +optional
+	Include []string `json:"include,omitempty"`
+	// ExcludeIfPresent excludes a directory if it contains a file with any of these names (i.e. ".nobackup")
+	// +optional
+	ExcludeIfPresent []string `json:"excludeIfPresent,omitempty"`
+	// IgnoreFileName is the name of a file (i.e. ".stashignore") in the root of each target directory
+	// that lists the patterns to exclude, one pattern per line.
+	// +optional
+	IgnoreFileName string `json:"ignoreFileName,omitempty"`
+	// ExcludeCaches excludes the directories that contain a CACHEDIR.TAG file
+	// +optional
+	ExcludeCaches bool `json:"excludeCaches,omitempty"`
+	// OneFileSystem prevents backup from crossing the file system boundaries of the target directories
+	// +optional
+	OneFileSystem bool `json:"oneFileSystem,omitempty"`
 }
 
 type RestoreTarget struct {
@@ -59,3 +87,118 @@ type TargetRef struct {
 	Kind       string `json:"kind,omitempty"`
 	Name       string `json:"name,omitempty"`
 }
+
+// BackupHooks specifies the actions that Stash should take before or after backup.
+type BackupHooks struct {
+	// PreBackup is called immediately before a backup is taken for a host.
+	// Backup is aborted if this hook fails unless its failurePolicy is "Continue".
+	// +optional
+	PreBackup *HookSpec `json:"preBackup,omitempty"`
+	// PostBackup is called immediately after a backup is taken for a host.
+	// It is executed even if the backup has failed, so that the target can be reverted to its normal state.
+	// +optional
+	PostBackup *HookSpec `json:"postBackup,omitempty"`
+}
+
+// RestoreHooks specifies the actions that Stash should take before or after restore.
+// As restore runs in an init-container, application containers are not running yet.
+// So, exec hooks are executed inside the init-container itself.
+type RestoreHooks struct {
+	// PreRestore is called immediately before restoring a host.
+	// Restore is aborted if this hook fails unless its failurePolicy is "Continue".
+	// +optional
+	PreRestore *HookSpec `json:"preRestore,omitempty"`
+	// PostRestore is called immediately after a host has been restored successfully.
+	// +optional
+	PostRestore *HookSpec `json:"postRestore,omitempty"`
+}
+
+// HookSpec specifies a single action to take. Exactly one of "exec" or "http" must be specified.
+type HookSpec struct {
+	// Exec specifies a command to execute inside a container.
+	// +optional
+	Exec *ExecHook `json:"exec,omitempty"`
+	// HTTP specifies a http request to send.
+	// +optional
+	HTTP *HTTPHook `json:"http,omitempty"`
+	// FailurePolicy specifies what to do if the hook fails.
+	// Supported values are "Abort", "Continue".
+	// Default value is "Abort".
+	// +optional
+	FailurePolicy HookFailurePolicy `json:"failurePolicy,omitempty"`
+	// TimeoutSeconds specifies the number of seconds after which the hook is considered as failed.
+	// Default value is 30 seconds.
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+type ExecHook struct {
+	// Container is the name of the container where the command will be executed.
+	// If not specified, the first container of the pod other than stash will be used.
+	// For the targets that are backed up or restored by a job, the command is executed inside the job container.
+	// +optional
+	Container string `json:"container,omitempty"`
+	// Command is the command line to execute inside the container. The command is not run inside a shell,
+	// so traditional shell instructions ('|', etc) won't work. To use a shell, you need to explicitly call out to that shell.
+	Command []string `json:"command"`
+}
+
+type HTTPHook struct {
+	// Method is the http method to use. Supported values are "GET", "POST".
+	// Default value is "GET".
+	// +optional
+	Method string `json:"method,omitempty"`
+	// Scheme to use for connecting to the host.
+	// Default value is "HTTP".
+	// +optional
+	Scheme core.URIScheme `json:"scheme,omitempty"`
+	// Host name to connect to. Default value is "localhost".
+	// +optional
+	Host string `json:"host,omitempty"`
+	// Port number to connect to.
+	Port int32 `json:"port"`
+	// Path to access on the HTTP server.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Custom headers to set in the request.
+	// +optional
+	HTTPHeaders []core.HTTPHeader `json:"httpHeaders,omitempty"`
+	// Body to send with a POST request.
+	// +optional
+	Body string `json:"body,omitempty"`
+}
+
+type HookFailurePolicy string
+
+const (
+	HookFailurePolicyAbort    HookFailurePolicy = "Abort"
+	HookFailurePolicyContinue HookFailurePolicy = "Continue"
+)
+
+type HookPhase string
+
+const (
+	HookSucceeded HookPhase = "Succeeded"
+	HookFailed    HookPhase = "Failed"
+)
+
+const (
+	PreBackupHook   = "preBackup"
+	PostBackupHook  = "postBackup"
+	PreRestoreHook  = "preRestore"
+	PostRestoreHook = "postRestore"
+)
+
+type HookStats struct {
+	// Name indicates which hook has been executed i.e. "preBackup", "postBackup", "preRestore" or "postRestore"
+	Name string `json:"name,omitempty"`
+	// Phase indicates whether the hook has succeeded or failed
+	Phase HookPhase `json:"phase,omitempty"`
+	// FailurePolicy indicates the failure policy that has been applied for this hook
+	FailurePolicy HookFailurePolicy `json:"failurePolicy,omitempty"`
+	// Duration indicates time taken to execute the hook
+	Duration string `json:"duration,omitempty"`
+	// Error indicates string value of error in case of hook failure
+	// +optional
+	Error string `json:"error,omitempty"`
+}
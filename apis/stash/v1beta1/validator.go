@@ -1,6 +1,66 @@
 package v1beta1
 
-import "fmt"
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+func (b BackupConfiguration) IsValid() error {
+	// the tags are passed to the backup job as comma separated list
+	if b.Spec.Target != nil {
+		if err := validateTags(b.Spec.Target.Tags); err != nil {
+			return fmt.Errorf("\n\t"+
+				"Error: Invalid BackupConfiguration specification.\n\t"+
+				"Reason: %v.\n\t"+
+				"Hints: A tag must be non-empty and can't contain comma.", err)
+		}
+	}
+
+	switch b.Spec.ConcurrencyPolicy {
+	case "", AllowConcurrent, ForbidConcurrent, ReplaceConcurrent:
+	default:
+		return fmt.Errorf("\n\t"+
+			"Error: Invalid BackupConfiguration specification.\n\t"+
+			"Reason: Unknown concurrencyPolicy %q.\n\t"+
+			"Hints: Valid values are %q, %q and %q.", b.Spec.ConcurrencyPolicy, AllowConcurrent, ForbidConcurrent, ReplaceConcurrent)
+	}
+	if b.Spec.ActiveDeadlineSeconds != nil && *b.Spec.ActiveDeadlineSeconds <= 0 {
+		return fmt.Errorf("\n\t"+
+			"Error: Invalid BackupConfiguration specification.\n\t"+
+			"Reason: activeDeadlineSeconds is %d.\n\t"+
+			"Hints: Specify a positive value for activeDeadlineSeconds.", *b.Spec.ActiveDeadlineSeconds)
+	}
+
+	if b.Spec.Hooks == nil {
+		return nil
+	}
+	hooks := map[string]*HookSpec{
+		PreBackupHook:  b.Spec.Hooks.PreBackup,
+		PostBackupHook: b.Spec.Hooks.PostBackup,
+	}
+	for name, hook := range hooks {
+		if hook == nil {
+			continue
+		}
+		if err := hook.IsValid(); err != nil {
+			return fmt.Errorf("\n\t"+
+				"Error: Invalid BackupConfiguration specification.\n\t"+
+				"Reason: Invalid %s hook. %v.\n\t"+
+				"Hints: Specify exactly one of 'exec' or 'http' in a hook.", name, err)
+		}
+	}
+	return nil
+}
+
+func validateTags(tags []string) error {
+	for _, tag := range tags {
+		if tag == "" || strings.Contains(tag, ",") {
+			return fmt.Errorf("invalid tag %q", tag)
+		}
+	}
+	return nil
+}
 
 // TODO: complete
 func (r BackupSession) IsValid() error {
@@ -54,6 +114,108 @@ func (r RestoreSession) IsValid() error {
 				"Hints: A snpashot contains backup data of only one directory. So, you can't specify 'paths' if you specify snapshot field.", i)
 		}
 	}
+
+	// ensure that snapshots are not selected in multiple ways
+	for i, rule := range r.Spec.Rules {
+		if len(rule.Snapshots) != 0 && rule.SnapshotSelector != nil {
+			return fmt.Errorf("\n\t"+
+				"Error: Invalid RestoreSession specification.\n\t"+
+				"Reason: Both 'snapshots' and 'snapshotSelector' fields are specified in rule[%d].\n\t"+
+				"Hints: Either specify the snapshots explicitly or select them using 'snapshotSelector'.", i)
+		}
+		// the tags are passed to the restore job as comma separated list
+		if rule.SnapshotSelector != nil {
+			if err := validateTags(rule.SnapshotSelector.Tags); err != nil {
+				return fmt.Errorf("\n\t"+
+					"Error: Invalid RestoreSession specification.\n\t"+
+					"Reason: %v in 'snapshotSelector' of rule[%d].\n\t"+
+					"Hints: A tag must be non-empty and can't contain comma.", err, i)
+			}
+		}
+	}
+
+	// ensure that destination is an absolute path and the conflict policy is known
+	for i, rule := range r.Spec.Rules {
+		if rule.Destination != "" && !path.IsAbs(rule.Destination) {
+			return fmt.Errorf("\n\t"+
+				"Error: Invalid RestoreSession specification.\n\t"+
+				"Reason: 'destination' of rule[%d] is not an absolute path.\n\t"+
+				"Hints: Specify an absolute directory path where the data will be restored.", i)
+		}
+		switch rule.ConflictPolicy {
+		case "", ConflictPolicyOverwrite, ConflictPolicySkipExisting, ConflictPolicyCleanTargetFirst:
+		default:
+			return fmt.Errorf("\n\t"+
+				"Error: Invalid RestoreSession specification.\n\t"+
+				"Reason: Unknown conflictPolicy %q in rule[%d].\n\t"+
+				"Hints: Valid values are %q, %q and %q.", rule.ConflictPolicy, i, ConflictPolicyOverwrite, ConflictPolicySkipExisting, ConflictPolicyCleanTargetFirst)
+		}
+	}
+
+	// ensure that the file selection can be applied by restic
+	for i, rule := range r.Spec.Rules {
+		if len(rule.Include) != 0 && len(rule.Exclude) != 0 {
+			return fmt.Errorf("\n\t"+
+				"Error: Invalid RestoreSession specification.\n\t"+
+				"Reason: Both 'include' and 'exclude' fields are specified in rule[%d].\n\t"+
+				"Hints: restic can't restore with both include and exclude patterns. Specify only one of them.", i)
+		}
+		if rule.ConflictPolicy == ConflictPolicySkipExisting && (len(rule.Include) != 0 || len(rule.Exclude) != 0) {
+			return fmt.Errorf("\n\t"+
+				"Error: Invalid RestoreSession specification.\n\t"+
+				"Reason: 'include' or 'exclude' is specified with %q conflictPolicy in rule[%d].\n\t"+
+				"Hints: %q selects the missing files to restore itself. It can't be combined with include or exclude patterns.", ConflictPolicySkipExisting, i, ConflictPolicySkipExisting)
+		}
+		if rule.ConflictPolicy == ConflictPolicyCleanTargetFirst && len(rule.Include) != 0 {
+			return fmt.Errorf("\n\t"+
+				"Error: Invalid RestoreSession specification.\n\t"+
+				"Reason: 'include' is specified with %q conflictPolicy in rule[%d].\n\t"+
+				"Hints: %q removes the whole restore path including the files that won't be restored.", ConflictPolicyCleanTargetFirst, i, ConflictPolicyCleanTargetFirst)
+		}
+	}
+
+	// ensure that the hooks are valid
+	if r.Spec.Hooks != nil {
+		hooks := map[string]*HookSpec{
+			PreRestoreHook:  r.Spec.Hooks.PreRestore,
+			PostRestoreHook: r.Spec.Hooks.PostRestore,
+		}
+		for name, hook := range hooks {
+			if hook == nil {
+				continue
+			}
+			if err := hook.IsValid(); err != nil {
+				return fmt.Errorf("\n\t"+
+					"Error: Invalid RestoreSession specification.\n\t"+
+					"Reason: Invalid %s hook. %v.\n\t"+
+					"Hints: Specify exactly one of 'exec' or 'http' in a hook.", name, err)
+			}
+			if hook.Exec != nil && hook.Exec.Container != "" {
+				return fmt.Errorf("\n\t"+
+					"Error: Invalid RestoreSession specification.\n\t"+
+					"Reason: 'container' is specified for %s exec hook.\n\t"+
+					"Hints: Application containers are not running during restore. Exec hooks are executed inside the stash init-container.", name)
+			}
+		}
+	}
+	return nil
+}
+
+func (h HookSpec) IsValid() error {
+	if h.Exec == nil && h.HTTP == nil {
+		return fmt.Errorf("neither 'exec' nor 'http' is specified")
+	}
+	if h.Exec != nil && h.HTTP != nil {
+		return fmt.Errorf("both 'exec' and 'http' are specified")
+	}
+	if h.Exec != nil && len(h.Exec.Command) == 0 {
+		return fmt.Errorf("no command specified for exec hook")
+	}
+	if h.FailurePolicy != "" &&
+		h.FailurePolicy != HookFailurePolicyAbort &&
+		h.FailurePolicy != HookFailurePolicyContinue {
+		return fmt.Errorf("unknown failurePolicy %q", h.FailurePolicy)
+	}
 	return nil
 }
 
@@ -67,3 +229,16 @@ func multipleRuleWithEmptyTargetHostError(ruleIndexes []int) string {
 	}
 	return fmt.Sprintf("%d rules found with empty targetHosts (Rules: %s)", len(ruleIndexes), ids)
 }
+
+func (n Notifier) IsValid() error {
+	if n.Spec.Webhook.URL == "" && n.Spec.Webhook.URLFrom == nil {
+		return fmt.Errorf("invalid Notifier %s/%s. Either url or urlFrom of the webhook must be specified", n.Namespace, n.Name)
+	}
+	if _, err := n.Spec.Webhook.BodyTemplate(); err != nil {
+		return fmt.Errorf("invalid Notifier %s/%s. Failed to parse the body template. Reason: %v", n.Namespace, n.Name, err)
+	}
+	if rl := n.Spec.RateLimit; rl != nil && (rl.Limit <= 0 || rl.Period.Duration <= 0) {
+		return fmt.Errorf("invalid Notifier %s/%s. Both limit and period of the rate limit must be positive", n.Namespace, n.Name)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"stash.appscode.dev/stash/apis"
+)
+
+func TestBackupConfigurationHooks(t *testing.T) {
+	execHook := &HookSpec{Exec: &ExecHook{Command: []string{"sync"}}}
+	testCases := []struct {
+		name    string
+		kind    string
+		driver  Snapshotter
+		hooks   *BackupHooks
+		invalid bool
+	}{
+		{name: "no hooks on job model", kind: apis.KindPersistentVolumeClaim},
+		{name: "hooks on sidecar model", kind: apis.KindDeployment, hooks: &BackupHooks{PreBackup: execHook}},
+		{name: "hooks on job model", kind: apis.KindAppBinding, hooks: &BackupHooks{PostBackup: execHook}},
+		{name: "hooks with volume snapshotter", kind: apis.KindStatefulSet, driver: VolumeSnapshotter, hooks: &BackupHooks{PreBackup: execHook}},
+		{name: "hook without action", kind: apis.KindDeployment, hooks: &BackupHooks{PreBackup: &HookSpec{}}, invalid: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bc := BackupConfiguration{
+				Spec: BackupConfigurationSpec{
+					Driver: tc.driver,
+					Target: &BackupTarget{Ref: TargetRef{Kind: tc.kind, Name: "target"}},
+					Hooks:  tc.hooks,
+				},
+			}
+			if err := bc.IsValid(); (err != nil) != tc.invalid {
+				t.Errorf("expected invalid: %v, got error: %v", tc.invalid, err)
+			}
+		})
+	}
+}
+
+func TestBackupConfigurationConcurrency(t *testing.T) {
+	deadline := func(seconds int64) *int64 { return &seconds }
+	testCases := []struct {
+		name                  string
+		concurrencyPolicy     ConcurrencyPolicy
+		activeDeadlineSeconds *int64
+		invalid               bool
+	}{
+		{name: "default settings"},
+		{name: "replace concurrent with deadline", concurrencyPolicy: ReplaceConcurrent, activeDeadlineSeconds: deadline(3600)},
+		{name: "forbid concurrent", concurrencyPolicy: ForbidConcurrent},
+		{name: "unknown concurrency policy", concurrencyPolicy: "Queue", invalid: true},
+		{name: "zero deadline", activeDeadlineSeconds: deadline(0), invalid: true},
+		{name: "negative deadline", activeDeadlineSeconds: deadline(-60), invalid: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bc := BackupConfiguration{
+				Spec: BackupConfigurationSpec{
+					Target:                &BackupTarget{Ref: TargetRef{Kind: apis.KindDeployment, Name: "target"}},
+					ConcurrencyPolicy:     tc.concurrencyPolicy,
+					ActiveDeadlineSeconds: tc.activeDeadlineSeconds,
+				},
+			}
+			if err := bc.IsValid(); (err != nil) != tc.invalid {
+				t.Errorf("expected invalid: %v, got error: %v", tc.invalid, err)
+			}
+		})
+	}
+}
+
+func TestRestoreSessionHooks(t *testing.T) {
+	execHook := &HookSpec{Exec: &ExecHook{Command: []string{"sync"}}}
+	testCases := []struct {
+		name    string
+		kind    string
+		invalid bool
+	}{
+		{name: "init-container model", kind: apis.KindStatefulSet},
+		{name: "job model", kind: apis.KindPersistentVolumeClaim},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rs := RestoreSession{
+				Spec: RestoreSessionSpec{
+					Target: &RestoreTarget{Ref: TargetRef{Kind: tc.kind, Name: "target"}},
+					Hooks:  &RestoreHooks{PostRestore: execHook},
+				},
+			}
+			if err := rs.IsValid(); (err != nil) != tc.invalid {
+				t.Errorf("expected invalid: %v, got error: %v", tc.invalid, err)
+			}
+		})
+	}
+}
+
+func TestSnapshotTags(t *testing.T) {
+	testCases := []struct {
+		name    string
+		tags    []string
+		invalid bool
+	}{
+		{name: "valid tags", tags: []string{"env=prod", "daily"}},
+		{name: "tag with comma", tags: []string{"a,b"}, invalid: true},
+		{name: "empty tag", tags: []string{""}, invalid: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bc := BackupConfiguration{
+				Spec: BackupConfigurationSpec{
+					Target: &BackupTarget{Ref: TargetRef{Kind: apis.KindDeployment, Name: "target"}, Tags: tc.tags},
+				},
+			}
+			if err := bc.IsValid(); (err != nil) != tc.invalid {
+				t.Errorf("BackupConfiguration: expected invalid: %v, got error: %v", tc.invalid, err)
+			}
+			rs := RestoreSession{
+				Spec: RestoreSessionSpec{
+					Rules: []Rule{{SnapshotSelector: &SnapshotSelector{Tags: tc.tags}}},
+				},
+			}
+			if err := rs.IsValid(); (err != nil) != tc.invalid {
+				t.Errorf("RestoreSession: expected invalid: %v, got error: %v", tc.invalid, err)
+			}
+		})
+	}
+}
+
+func TestRestoreSessionFileSelection(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rule    Rule
+		invalid bool
+	}{
+		{name: "include", rule: Rule{Include: []string{"/data/a"}}},
+		{name: "exclude with skip existing", rule: Rule{Exclude: []string{"/data/a"}, ConflictPolicy: ConflictPolicySkipExisting}, invalid: true},
+		{name: "include and exclude", rule: Rule{Include: []string{"/data"}, Exclude: []string{"/data/a"}}, invalid: true},
+		{name: "include with skip existing", rule: Rule{Include: []string{"/data/a"}, ConflictPolicy: ConflictPolicySkipExisting}, invalid: true},
+		{name: "include with clean target first", rule: Rule{Include: []string{"/data/a"}, ConflictPolicy: ConflictPolicyCleanTargetFirst}, invalid: true},
+		{name: "exclude with clean target first", rule: Rule{Exclude: []string{"/data/a"}, ConflictPolicy: ConflictPolicyCleanTargetFirst}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rs := RestoreSession{
+				Spec: RestoreSessionSpec{
+					Rules: []Rule{tc.rule},
+				},
+			}
+			if err := rs.IsValid(); (err != nil) != tc.invalid {
+				t.Errorf("expected invalid: %v, got error: %v", tc.invalid, err)
+			}
+		})
+	}
+}
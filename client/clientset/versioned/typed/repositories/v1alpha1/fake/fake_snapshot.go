@@ -22,6 +22,7 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
 	testing "k8s.io/client-go/testing"
 	v1alpha1 "stash.appscode.dev/stash/apis/repositories/v1alpha1"
 )
@@ -69,6 +70,17 @@ func (c *FakeSnapshots) List(opts v1.ListOptions) (result *v1alpha1.SnapshotList
 	return list, err
 }
 
+// Update takes the representation of a snapshot and updates it. Returns the server's representation of the snapshot, and an error, if there is any.
+func (c *FakeSnapshots) Update(snapshot *v1alpha1.Snapshot) (result *v1alpha1.Snapshot, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(snapshotsResource, c.ns, snapshot), &v1alpha1.Snapshot{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Snapshot), err
+}
+
 // UpdateStatus was generated because the type contains a Status member.
 // Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
 func (c *FakeSnapshots) UpdateStatus(snapshot *v1alpha1.Snapshot) (*v1alpha1.Snapshot, error) {
@@ -88,3 +100,22 @@ func (c *FakeSnapshots) Delete(name string, options *v1.DeleteOptions) error {
 
 	return err
 }
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeSnapshots) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(snapshotsResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.SnapshotList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched snapshot.
+func (c *FakeSnapshots) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Snapshot, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(snapshotsResource, c.ns, name, pt, data, subresources...), &v1alpha1.Snapshot{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Snapshot), err
+}
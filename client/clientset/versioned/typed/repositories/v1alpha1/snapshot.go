@@ -22,6 +22,7 @@ import (
 	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
 	rest "k8s.io/client-go/rest"
 	v1alpha1 "stash.appscode.dev/stash/apis/repositories/v1alpha1"
 	scheme "stash.appscode.dev/stash/client/clientset/versioned/scheme"
@@ -35,10 +36,13 @@ type SnapshotsGetter interface {
 
 // SnapshotInterface has methods to work with Snapshot resources.
 type SnapshotInterface interface {
+	Update(*v1alpha1.Snapshot) (*v1alpha1.Snapshot, error)
 	UpdateStatus(*v1alpha1.Snapshot) (*v1alpha1.Snapshot, error)
 	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
 	Get(name string, options v1.GetOptions) (*v1alpha1.Snapshot, error)
 	List(opts v1.ListOptions) (*v1alpha1.SnapshotList, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Snapshot, err error)
 	SnapshotExpansion
 }
 
@@ -86,6 +90,19 @@ func (c *snapshots) List(opts v1.ListOptions) (result *v1alpha1.SnapshotList, er
 	return
 }
 
+// Update takes the representation of a snapshot and updates it. Returns the server's representation of the snapshot, and an error, if there is any.
+func (c *snapshots) Update(snapshot *v1alpha1.Snapshot) (result *v1alpha1.Snapshot, err error) {
+	result = &v1alpha1.Snapshot{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("snapshots").
+		Name(snapshot.Name).
+		Body(snapshot).
+		Do().
+		Into(result)
+	return
+}
+
 // UpdateStatus was generated because the type contains a Status member.
 // Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
 
@@ -112,3 +129,33 @@ func (c *snapshots) Delete(name string, options *v1.DeleteOptions) error {
 		Do().
 		Error()
 }
+
+// DeleteCollection deletes a collection of objects.
+func (c *snapshots) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("snapshots").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched snapshot.
+func (c *snapshots) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Snapshot, err error) {
+	result = &v1alpha1.Snapshot{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("snapshots").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
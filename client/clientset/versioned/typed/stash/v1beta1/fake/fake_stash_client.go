@@ -44,6 +44,10 @@ func (c *FakeStashV1beta1) Functions() v1beta1.FunctionInterface {
 	return &FakeFunctions{c}
 }
 
+func (c *FakeStashV1beta1) Notifiers(namespace string) v1beta1.NotifierInterface {
+	return &FakeNotifiers{c, namespace}
+}
+
 func (c *FakeStashV1beta1) RestoreSessions(namespace string) v1beta1.RestoreSessionInterface {
 	return &FakeRestoreSessions{c, namespace}
 }
@@ -26,6 +26,8 @@ type BackupSessionExpansion interface{}
 
 type FunctionExpansion interface{}
 
+type NotifierExpansion interface{}
+
 type RestoreSessionExpansion interface{}
 
 type TaskExpansion interface{}
@@ -31,6 +31,7 @@ type StashV1beta1Interface interface {
 	BackupConfigurationTemplatesGetter
 	BackupSessionsGetter
 	FunctionsGetter
+	NotifiersGetter
 	RestoreSessionsGetter
 	TasksGetter
 }
@@ -56,6 +57,10 @@ func (c *StashV1beta1Client) Functions() FunctionInterface {
 	return newFunctions(c)
 }
 
+func (c *StashV1beta1Client) Notifiers(namespace string) NotifierInterface {
+	return newNotifiers(c, namespace)
+}
+
 func (c *StashV1beta1Client) RestoreSessions(namespace string) RestoreSessionInterface {
 	return newRestoreSessions(c, namespace)
 }
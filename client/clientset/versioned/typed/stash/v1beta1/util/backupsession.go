@@ -85,6 +85,8 @@ func TryUpdateBackupSession(c cs.StashV1beta1Interface, meta metav1.ObjectMeta,
 func UpdateBackupSessionStatusForHost(c cs.StashV1beta1Interface, backupSession *api_v1beta1.BackupSession, hostStats api_v1beta1.HostBackupStats) (*api_v1beta1.BackupSession, error) {
 
 	out, err := UpdateBackupSessionStatus(c, backupSession, func(in *api_v1beta1.BackupSessionStatus) *api_v1beta1.BackupSessionStatus {
+		// backup of this host has been completed. so, progress is no longer needed.
+		in.Progress = removeHostBackupProgress(in.Progress, hostStats.Hostname)
 		// if an entry already exist for this host then update it
 		for i, v := range backupSession.Status.Stats {
 			if v.Hostname == hostStats.Hostname {
@@ -99,6 +101,33 @@ func UpdateBackupSessionStatusForHost(c cs.StashV1beta1Interface, backupSession
 	return out, err
 }
 
+// UpdateBackupSessionProgressForHost adds or updates the progress entry of a host that is being backed up
+func UpdateBackupSessionProgressForHost(c cs.StashV1beta1Interface, backupSession *api_v1beta1.BackupSession, progress api_v1beta1.HostBackupProgress) (*api_v1beta1.BackupSession, error) {
+	return UpdateBackupSessionStatus(c, backupSession, func(in *api_v1beta1.BackupSessionStatus) *api_v1beta1.BackupSessionStatus {
+		in.Progress = upsertHostBackupProgress(in.Progress, progress)
+		return in
+	}, apis.EnableStatusSubresource)
+}
+
+func upsertHostBackupProgress(list []api_v1beta1.HostBackupProgress, progress api_v1beta1.HostBackupProgress) []api_v1beta1.HostBackupProgress {
+	for i := range list {
+		if list[i].Hostname == progress.Hostname {
+			list[i] = progress
+			return list
+		}
+	}
+	return append(list, progress)
+}
+
+func removeHostBackupProgress(list []api_v1beta1.HostBackupProgress, hostname string) []api_v1beta1.HostBackupProgress {
+	for i := range list {
+		if list[i].Hostname == hostname {
+			return append(list[:i], list[i+1:]...)
+		}
+	}
+	return list
+}
+
 func UpdateBackupSessionStatus(
 	c cs.StashV1beta1Interface,
 	in *api_v1beta1.BackupSession,
@@ -84,6 +84,8 @@ func TryUpdateRestoreSession(c cs.StashV1beta1Interface, meta metav1.ObjectMeta,
 
 func UpdateRestoreSessionStatusForHost(c cs.StashV1beta1Interface, restoreSession *api_v1beta1.RestoreSession, hostStats api_v1beta1.HostRestoreStats) (*api_v1beta1.RestoreSession, error) {
 	out, err := UpdateRestoreSessionStatus(c, restoreSession, func(in *api_v1beta1.RestoreSessionStatus) *api_v1beta1.RestoreSessionStatus {
+		// restore of this host has been completed. so, progress is no longer needed.
+		in.Progress = removeHostRestoreProgress(in.Progress, hostStats.Hostname)
 		// if an entry already exist for this host then update it
 		for i, v := range restoreSession.Status.Stats {
 			if v.Hostname == hostStats.Hostname {
@@ -98,6 +100,33 @@ func UpdateRestoreSessionStatusForHost(c cs.StashV1beta1Interface, restoreSessio
 	return out, err
 }
 
+// UpdateRestoreSessionProgressForHost adds or updates the progress entry of a host that is being restored
+func UpdateRestoreSessionProgressForHost(c cs.StashV1beta1Interface, restoreSession *api_v1beta1.RestoreSession, progress api_v1beta1.HostRestoreProgress) (*api_v1beta1.RestoreSession, error) {
+	return UpdateRestoreSessionStatus(c, restoreSession, func(in *api_v1beta1.RestoreSessionStatus) *api_v1beta1.RestoreSessionStatus {
+		in.Progress = upsertHostRestoreProgress(in.Progress, progress)
+		return in
+	}, apis.EnableStatusSubresource)
+}
+
+func upsertHostRestoreProgress(list []api_v1beta1.HostRestoreProgress, progress api_v1beta1.HostRestoreProgress) []api_v1beta1.HostRestoreProgress {
+	for i := range list {
+		if list[i].Hostname == progress.Hostname {
+			list[i] = progress
+			return list
+		}
+	}
+	return append(list, progress)
+}
+
+func removeHostRestoreProgress(list []api_v1beta1.HostRestoreProgress, hostname string) []api_v1beta1.HostRestoreProgress {
+	for i := range list {
+		if list[i].Hostname == hostname {
+			return append(list[:i], list[i+1:]...)
+		}
+	}
+	return list
+}
+
 func UpdateRestoreSessionStatus(
 	c cs.StashV1beta1Interface,
 	in *api_v1beta1.RestoreSession,
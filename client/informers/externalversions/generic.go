@@ -70,6 +70,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Stash().V1beta1().BackupSessions().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("functions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Stash().V1beta1().Functions().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("notifiers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Stash().V1beta1().Notifiers().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("restoresessions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Stash().V1beta1().RestoreSessions().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("tasks"):
@@ -32,6 +32,8 @@ type Interface interface {
 	BackupSessions() BackupSessionInformer
 	// Functions returns a FunctionInformer.
 	Functions() FunctionInformer
+	// Notifiers returns a NotifierInformer.
+	Notifiers() NotifierInformer
 	// RestoreSessions returns a RestoreSessionInformer.
 	RestoreSessions() RestoreSessionInformer
 	// Tasks returns a TaskInformer.
@@ -69,6 +71,11 @@ func (v *version) Functions() FunctionInformer {
 	return &functionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// Notifiers returns a NotifierInformer.
+func (v *version) Notifiers() NotifierInformer {
+	return &notifierInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // RestoreSessions returns a RestoreSessionInformer.
 func (v *version) RestoreSessions() RestoreSessionInformer {
 	return &restoreSessionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -42,6 +42,14 @@ type BackupSessionNamespaceListerExpansion interface{}
 // FunctionLister.
 type FunctionListerExpansion interface{}
 
+// NotifierListerExpansion allows custom methods to be added to
+// NotifierLister.
+type NotifierListerExpansion interface{}
+
+// NotifierNamespaceListerExpansion allows custom methods to be added to
+// NotifierNamespaceLister.
+type NotifierNamespaceListerExpansion interface{}
+
 // RestoreSessionListerExpansion allows custom methods to be added to
 // RestoreSessionLister.
 type RestoreSessionListerExpansion interface{}
@@ -51,6 +51,7 @@ func generateCRDDefinitions() {
 		stashv1beta1.BackupConfigurationTemplate{}.CustomResourceDefinition(),
 		stashv1beta1.RestoreSession{}.CustomResourceDefinition(),
 		stashv1beta1.Task{}.CustomResourceDefinition(),
+		stashv1beta1.Notifier{}.CustomResourceDefinition(),
 	}
 	genCRD(stashv1beta1.SchemeGroupVersion.Version, v1beta1CRDs)
 
@@ -12,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
@@ -20,6 +21,7 @@ import (
 	"k8s.io/kubernetes/pkg/apis/core"
 	"kmodules.xyz/client-go/tools/queue"
 	"stash.appscode.dev/stash/apis"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
 	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
 	cs "stash.appscode.dev/stash/client/clientset/versioned"
 	stash_scheme "stash.appscode.dev/stash/client/clientset/versioned/scheme"
@@ -27,12 +29,14 @@ import (
 	stashinformers "stash.appscode.dev/stash/client/informers/externalversions"
 	"stash.appscode.dev/stash/client/listers/stash/v1beta1"
 	"stash.appscode.dev/stash/pkg/eventer"
+	"stash.appscode.dev/stash/pkg/hooks"
 	"stash.appscode.dev/stash/pkg/restic"
 	"stash.appscode.dev/stash/pkg/status"
 	"stash.appscode.dev/stash/pkg/util"
 )
 
 type BackupSessionController struct {
+	Config               *rest.Config
 	K8sClient            kubernetes.Interface
 	StashClient          cs.Interface
 	MasterURL            string
@@ -153,9 +157,9 @@ func (c *BackupSessionController) processBackupSession(key string) error {
 		backupSession := obj.(*api_v1beta1.BackupSession)
 		glog.Infof("Sync/Add/Update for Backup Session %s", backupSession.GetName())
 
-		err := c.startBackupProcess(backupSession)
+		hookStats, err := c.startBackupProcess(backupSession)
 		if err != nil {
-			e2 := c.handleBackupFailure(backupSession, err)
+			e2 := c.handleBackupFailure(backupSession, hookStats, err)
 			err = errors.NewAggregate([]error{err, e2})
 			// log failure. don't fail the container as it may interrupt user's service
 			log.Infoln("failed to complete backup. Reason: ", err.Error())
@@ -164,31 +168,31 @@ func (c *BackupSessionController) processBackupSession(key string) error {
 	return nil
 }
 
-func (c *BackupSessionController) startBackupProcess(backupSession *api_v1beta1.BackupSession) error {
+func (c *BackupSessionController) startBackupProcess(backupSession *api_v1beta1.BackupSession) ([]api_v1beta1.HookStats, error) {
 	// get respective BackupConfiguration for BackupSession
 	backupConfiguration, err := c.StashClient.StashV1beta1().BackupConfigurations(backupSession.Namespace).Get(
 		backupSession.Spec.BackupConfiguration.Name,
 		metav1.GetOptions{},
 	)
 	if err != nil {
-		return fmt.Errorf("can't get BackupConfiguration for BackupSession %s/%s, reason: %s", backupSession.Namespace, backupSession.Name, err)
+		return nil, fmt.Errorf("can't get BackupConfiguration for BackupSession %s/%s, reason: %s", backupSession.Namespace, backupSession.Name, err)
 	}
 
 	// skip if BackupConfiguration paused
 	if backupConfiguration.Spec.Paused {
 		log.Infof("Skipping processing BackupSession %s/%s. Reason: Backup Configuration is paused.", backupSession.Namespace, backupSession.Name)
-		return nil
+		return nil, nil
 	}
 
 	host, err := util.GetHostName(backupConfiguration.Spec.Target)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// if BackupSession already has been processed for this host then skip further processing
 	if c.isBackupTakenForThisHost(backupSession, host) {
 		log.Infof("Skip processing BackupSession %s/%s. Reason: BackupSession has been processed already for host %q\n", backupSession.Namespace, backupSession.Name, host)
-		return nil
+		return nil, nil
 	}
 
 	// For Deployment, ReplicaSet and ReplicationController only leader pod is running this controller so no problem with restic repo lock.
@@ -199,22 +203,34 @@ func (c *BackupSessionController) startBackupProcess(backupSession *api_v1beta1.
 	case apis.KindDeployment, apis.KindReplicaSet, apis.KindReplicationController, apis.KindDeploymentConfig:
 		return c.backup(backupSession, backupConfiguration)
 	default:
-		return c.electBackupLeader(backupSession, backupConfiguration)
+		return nil, c.electBackupLeader(backupSession, backupConfiguration)
 	}
 }
 
-func (c *BackupSessionController) backup(backupSession *api_v1beta1.BackupSession, backupConfiguration *api_v1beta1.BackupConfiguration) error {
+// backup takes backup of the host along with executing the preBackup and postBackup hooks.
+// It returns the results of the hooks that has been executed so that they can be reported on failure too.
+func (c *BackupSessionController) backup(backupSession *api_v1beta1.BackupSession, backupConfiguration *api_v1beta1.BackupConfiguration) ([]api_v1beta1.HookStats, error) {
+
+	// get the latest BackupSession as it might have been cancelled while waiting for the leadership
+	backupSession, err := c.StashClient.StashV1beta1().BackupSessions(backupSession.Namespace).Get(backupSession.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if backupSession.Spec.Cancel {
+		log.Infof("Skipping backup for BackupSession %s/%s. Reason: BackupSession has been cancelled.", backupSession.Namespace, backupSession.Name)
+		return nil, nil
+	}
 
 	// get repository
 	repository, err := c.StashClient.StashV1alpha1().Repositories(backupConfiguration.Namespace).Get(backupConfiguration.Spec.Repository.Name, metav1.GetOptions{})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// get host name
 	host, err := util.GetHostName(backupConfiguration.Spec.Target)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// configure SourceHost, SecretDirectory, EnableCache and ScratchDirectory
@@ -228,7 +244,7 @@ func (c *BackupSessionController) backup(backupSession *api_v1beta1.BackupSessio
 	// configure setupOption
 	c.SetupOpt, err = util.SetupOptionsForRepository(*repository, extraOpt)
 	if err != nil {
-		return fmt.Errorf("setup option for repository fail")
+		return nil, fmt.Errorf("setup option for repository fail")
 	}
 
 	// apply nice/ionice settings
@@ -240,41 +256,90 @@ func (c *BackupSessionController) backup(backupSession *api_v1beta1.BackupSessio
 	// init restic wrapper
 	resticWrapper, err := restic.NewResticWrapper(c.SetupOpt)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	o := status.UpdateStatusOptions{
+		KubeClient:    c.K8sClient,
+		StashClient:   c.StashClient.(*cs.Clientset),
+		Namespace:     c.Namespace,
+		BackupSession: backupSession.Name,
+		Repository:    backupConfiguration.Spec.Repository.Name,
+	}
+	// interrupt the backup if the BackupSession is cancelled while it is running
+	stopCancelWatcher := o.WatchCancellation(resticWrapper.Cancel)
+	defer stopCancelWatcher()
+
+	hookExecutor := &hooks.HookExecutor{
+		Config:     c.Config,
+		KubeClient: c.K8sClient,
+		Namespace:  c.Namespace,
+		PodName:    os.Getenv(util.KeyPodName),
+	}
+	backupHooks := backupConfiguration.Spec.Hooks
+	if backupHooks == nil {
+		backupHooks = &api_v1beta1.BackupHooks{}
+	}
+
+	// execute preBackup hook. abort backup if the hook fails and its failure policy does not allow to continue.
+	if err = hookExecutor.Execute(api_v1beta1.PreBackupHook, backupHooks.PreBackup); err != nil {
+		return hookExecutor.Stats, err
 	}
 
 	// BackupOptions configuration
 	backupOpt := util.BackupOptionsForBackupConfig(*backupConfiguration, extraOpt)
-	backupOutput, err := resticWrapper.RunBackup(backupOpt)
-	if err != nil {
-		return err
+	backupOpt.Tags = util.SnapshotTags(backupSession.Name, *backupConfiguration)
+	// for multi-host targets, repository maintenance is run once after all hosts have been backed up
+	backupOpt.SkipRepositoryMaintenance = isMultiHostTarget(backupConfiguration.Spec.Target.Ref.Kind)
+	backupOpt.SkipCheck = util.SkipCheckAfterBackup(*repository)
+
+	// report progress of this host in BackupSession status while backup is running
+	progressReporter := o.NewProgressReporter(status.DefaultProgressUpdateInterval)
+	backupOpt.OnProgress = progressReporter.ReportBackupProgress
+	backupOutput, backupErr := resticWrapper.RunBackup(backupOpt)
+	progressReporter.Stop()
+
+	// execute postBackup hook even if backup has failed so that the target can return to its normal state
+	hookErr := hookExecutor.Execute(api_v1beta1.PostBackupHook, backupHooks.PostBackup)
+	if backupErr == restic.ErrCancelled {
+		if hookErr != nil {
+			log.Warningln("failed to execute postBackup hook. Reason: ", hookErr.Error())
+		}
+		return hookExecutor.Stats, c.handleBackupCancellation(o, resticWrapper, backupOutput, hookExecutor.Stats)
+	}
+	if backupErr != nil || hookErr != nil {
+		return hookExecutor.Stats, errors.NewAggregate([]error{backupErr, hookErr})
 	}
+	backupOutput.HostBackupStats.Hooks = hookExecutor.Stats
 
 	// if metrics are enabled then generate metrics
 	if c.Metrics.Enabled {
 		err := backupOutput.HandleMetrics(&c.Metrics, nil)
 		if err != nil {
-			return err
+			return hookExecutor.Stats, err
 		}
 	}
 
 	// Update Backup Session and Repository status
-	o := status.UpdateStatusOptions{
-		KubeClient:    c.K8sClient,
-		StashClient:   c.StashClient.(*cs.Clientset),
-		Namespace:     c.Namespace,
-		BackupSession: backupSession.Name,
-		Repository:    backupConfiguration.Spec.Repository.Name,
-	}
-
 	err = o.UpdatePostBackupStatus(backupOutput)
 	if err != nil {
-		return err
+		return hookExecutor.Stats, err
 	}
 
 	glog.Info("Backup has been completed successfully")
 
-	return nil
+	return hookExecutor.Stats, nil
+}
+
+// handleBackupCancellation removes the stale locks that the interrupted backup might have left in the repository
+// and records the stats of the directories that have been backed up before the cancellation.
+func (c *BackupSessionController) handleBackupCancellation(o status.UpdateStatusOptions, w *restic.ResticWrapper, backupOutput *restic.BackupOutput, hookStats []api_v1beta1.HookStats) error {
+	log.Infof("Backup has been cancelled for BackupSession %s/%s", o.Namespace, o.BackupSession)
+	if err := w.RemoveStaleLocks(); err != nil {
+		log.Warningln("failed to remove stale locks from the repository. Reason: ", err.Error())
+	}
+	backupOutput.HostBackupStats.Hooks = hookStats
+	return o.UpdatePostBackupStatus(backupOutput)
 }
 
 func (c *BackupSessionController) electLeaderPod(backupConfiguration *api_v1beta1.BackupConfiguration, stopCh <-chan struct{}) error {
@@ -362,15 +427,19 @@ func (c *BackupSessionController) electBackupLeader(backupSession *api_v1beta1.B
 			OnStartedLeading: func(ctx context.Context) {
 				log.Infoln("Got leadership, preparing for backup")
 				// run backup process
-				err := c.backup(backupSession, backupConfiguration)
+				hookStats, err := c.backup(backupSession, backupConfiguration)
 				if err != nil {
 					// send failure metrics and update BackupSession status
-					err = c.handleBackupFailure(backupSession, err)
-					// step down from leadership so that other replicas can start backup
-					cancel()
+					e2 := c.handleBackupFailure(backupSession, hookStats, err)
+					err = errors.NewAggregate([]error{err, e2})
 					// log failure. don't fail the container as it may interrupt user's service
 					log.Warningln("failed to complete backup. Reason: ", err.Error())
 				}
+				// if this is the last host of this BackupSession, run repository maintenance while holding the leadership
+				// so that it does not contend for the repository lock with other replicas
+				if err := c.ensureRepositoryMaintenance(backupSession, backupConfiguration); err != nil {
+					log.Warningln("failed to run repository maintenance. Reason: ", err.Error())
+				}
 				// backup process is complete. now, step down from leadership so that other replicas can start
 				cancel()
 			},
@@ -382,7 +451,113 @@ func (c *BackupSessionController) electBackupLeader(backupSession *api_v1beta1.B
 	return nil
 }
 
-func (c *BackupSessionController) handleBackupFailure(backupSession *api_v1beta1.BackupSession, backupErr error) error {
+// ensureRepositoryMaintenance runs check, cleanup and stats once for a BackupSession of a multi-host target.
+// Only the host that reports last will find that all hosts have reported and run the maintenance.
+func (c *BackupSessionController) ensureRepositoryMaintenance(backupSession *api_v1beta1.BackupSession, backupConfiguration *api_v1beta1.BackupConfiguration) error {
+	if !isMultiHostTarget(backupConfiguration.Spec.Target.Ref.Kind) {
+		return nil
+	}
+
+	// get the latest BackupSession as other hosts might have reported in the meantime
+	backupSession, err := c.StashClient.StashV1beta1().BackupSessions(backupSession.Namespace).Get(backupSession.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if backupSession.Spec.Cancel {
+		log.Infof("Skipping repository maintenance for BackupSession %s/%s. Reason: BackupSession has been cancelled.", backupSession.Namespace, backupSession.Name)
+		return nil
+	}
+	if backupSession.Status.TotalHosts == nil || *backupSession.Status.TotalHosts != int32(len(backupSession.Status.Stats)) {
+		log.Infof("Deferring repository maintenance for BackupSession %s/%s. Reason: all hosts haven't completed backup yet.", backupSession.Namespace, backupSession.Name)
+		return nil
+	}
+	// no need to run maintenance if none of the hosts has succeeded
+	succeeded := false
+	for _, hostStats := range backupSession.Status.Stats {
+		if hostStats.Phase == api_v1beta1.HostBackupSucceeded {
+			succeeded = true
+			break
+		}
+	}
+	if !succeeded {
+		return nil
+	}
+
+	log.Infof("Running repository maintenance for BackupSession %s/%s", backupSession.Namespace, backupSession.Name)
+	repoStats, err := c.runRepositoryMaintenance(backupConfiguration, retentionPoliciesForBackupSession(backupConfiguration, backupSession))
+	c.writeRepositoryMaintenanceEvent(backupSession, err)
+	if err != nil {
+		return err
+	}
+
+	o := status.UpdateStatusOptions{
+		KubeClient:  c.K8sClient,
+		StashClient: c.StashClient.(*cs.Clientset),
+		Namespace:   c.Namespace,
+		Repository:  backupConfiguration.Spec.Repository.Name,
+	}
+	return o.UpdateRepositoryStatus(*repoStats)
+}
+
+func (c *BackupSessionController) runRepositoryMaintenance(backupConfiguration *api_v1beta1.BackupConfiguration, retentionPolicies []api_v1alpha1.RetentionPolicy) (*restic.RepositoryStats, error) {
+	repository, err := c.StashClient.StashV1alpha1().Repositories(backupConfiguration.Namespace).Get(backupConfiguration.Spec.Repository.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	host, err := util.GetHostName(backupConfiguration.Spec.Target)
+	if err != nil {
+		return nil, err
+	}
+	setupOpt, err := util.SetupOptionsForRepository(*repository, util.ExtraOptions{
+		Host:        host,
+		SecretDir:   c.SetupOpt.SecretDir,
+		EnableCache: c.SetupOpt.EnableCache,
+		ScratchDir:  c.SetupOpt.ScratchDir,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resticWrapper, err := restic.NewResticWrapper(setupOpt)
+	if err != nil {
+		return nil, err
+	}
+	return resticWrapper.RunRepositoryMaintenance(retentionPolicies, util.SkipCheckAfterBackup(*repository))
+}
+
+// retentionPoliciesForBackupSession returns the retention policies to apply on the snapshots of all hosts of the
+// BackupSession. Without a user specified filter, the policy of the BackupConfiguration is restricted to each of
+// its hosts and to the snapshots tagged with the BackupConfiguration. So, the snapshots of the other
+// BackupConfigurations sharing the Repository are never removed.
+func retentionPoliciesForBackupSession(backupConfiguration *api_v1beta1.BackupConfiguration, backupSession *api_v1beta1.BackupSession) []api_v1alpha1.RetentionPolicy {
+	if backupConfiguration.Spec.RetentionPolicy.Filter != nil {
+		return []api_v1alpha1.RetentionPolicy{util.RetentionPolicyForBackupConfig(*backupConfiguration, "")}
+	}
+	var policies []api_v1alpha1.RetentionPolicy
+	for _, hostStats := range backupSession.Status.Stats {
+		policies = append(policies, util.RetentionPolicyForBackupConfig(*backupConfiguration, hostStats.Hostname))
+	}
+	return policies
+}
+
+func (c *BackupSessionController) writeRepositoryMaintenanceEvent(backupSession *api_v1beta1.BackupSession, err error) {
+	eventType, eventReason, eventMessage := core.EventTypeNormal, eventer.EventReasonRepositoryMaintenanceSucceeded,
+		fmt.Sprintf("Repository maintenance has been completed successfully for BackupSession %s/%s", backupSession.Namespace, backupSession.Name)
+	if err != nil {
+		eventType, eventReason, eventMessage = core.EventTypeWarning, eventer.EventReasonRepositoryMaintenanceFailed,
+			fmt.Sprintf("Failed to run repository maintenance for BackupSession %s/%s. Reason: %v", backupSession.Namespace, backupSession.Name, err)
+	}
+	_, rerr := eventer.CreateEvent(c.K8sClient, eventer.EventSourceBackupSidecar, backupSession, eventType, eventReason, eventMessage)
+	if rerr != nil {
+		log.Errorf("Failed to write repository maintenance event. Reason: %v", rerr)
+	}
+}
+
+// isMultiHostTarget returns true if each replica of the target is backed up as a separate host
+func isMultiHostTarget(kind string) bool {
+	return kind == apis.KindStatefulSet || kind == apis.KindDaemonSet
+}
+
+func (c *BackupSessionController) handleBackupFailure(backupSession *api_v1beta1.BackupSession, hookStats []api_v1beta1.HookStats, backupErr error) error {
 	backupConfiguration, err := c.StashClient.StashV1beta1().BackupConfigurations(backupSession.Namespace).Get(backupSession.Spec.BackupConfiguration.Name, metav1.GetOptions{})
 	if err != nil {
 		return err
@@ -397,6 +572,7 @@ func (c *BackupSessionController) handleBackupFailure(backupSession *api_v1beta1
 		Hostname: host,
 		Phase:    api_v1beta1.HostBackupFailed,
 		Error:    backupErr.Error(),
+		Hooks:    hookStats,
 	}
 
 	// add or update entry for this host in BackupSession status
@@ -469,10 +645,11 @@ func (c *BackupSessionController) writeBackupFailureEvent(backupSession *api_v1b
 
 func (c *BackupSessionController) isBackupTakenForThisHost(backupSession *api_v1beta1.BackupSession, host string) bool {
 
-	// if overall backupSession phase is "Succeeded" or "Failed" or "Skipped" then it has been processed already
+	// if overall backupSession phase is "Succeeded" or "Failed" or "Skipped" or "Cancelled" then it has been processed already
 	if backupSession.Status.Phase == api_v1beta1.BackupSessionSucceeded ||
 		backupSession.Status.Phase == api_v1beta1.BackupSessionFailed ||
-		backupSession.Status.Phase == api_v1beta1.BackupSessionSkipped {
+		backupSession.Status.Phase == api_v1beta1.BackupSessionSkipped ||
+		backupSession.Status.Phase == api_v1beta1.BackupSessionCancelled {
 		return true
 	}
 
@@ -0,0 +1,119 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+func TestRetentionPoliciesForBackupSession(t *testing.T) {
+	// two BackupConfigurations back up into the same Repository from the same hosts
+	newBackupConfig := func(name string, filter *api_v1alpha1.RetentionFilter) *api_v1beta1.BackupConfiguration {
+		return &api_v1beta1.BackupConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "demo"},
+			Spec: api_v1beta1.BackupConfigurationSpec{
+				Repository: core.LocalObjectReference{Name: "shared-repo"},
+				Target: &api_v1beta1.BackupTarget{
+					Ref: api_v1beta1.TargetRef{Kind: "StatefulSet", Name: name},
+				},
+				RetentionPolicy: api_v1alpha1.RetentionPolicy{Name: "keep-last-1", KeepLast: 1, Filter: filter},
+			},
+		}
+	}
+	appA := newBackupConfig("app-a", nil)
+	appB := newBackupConfig("app-b", nil)
+	custom := newBackupConfig("custom", &api_v1alpha1.RetentionFilter{Paths: []string{"/data"}})
+
+	type snapshot struct {
+		host string
+		tags []string
+	}
+	snapshots := []snapshot{
+		{host: "host-0", tags: util.SnapshotTags("app-a-1", *appA)},
+		{host: "host-1", tags: util.SnapshotTags("app-a-1", *appA)},
+		{host: "host-0", tags: util.SnapshotTags("app-b-1", *appB)},
+		{host: "host-1", tags: util.SnapshotTags("app-b-1", *appB)},
+	}
+	// selected mimics how restic selects the snapshots for "restic forget --host <host> --tag <tags>"
+	selected := func(filter *api_v1alpha1.RetentionFilter) []string {
+		var sessions []string
+		for _, s := range snapshots {
+			if filter.Host != "" && filter.Host != s.host {
+				continue
+			}
+			found := 0
+			for _, tag := range filter.Tags {
+				for _, t := range s.tags {
+					if t == tag {
+						found++
+						break
+					}
+				}
+			}
+			if found == len(filter.Tags) {
+				sessions = append(sessions, s.host+"/"+util.SnapshotLabelsFromTags(s.tags)[util.SnapshotLabelKey(util.TagBackupSession)])
+			}
+		}
+		return sessions
+	}
+	backupSession := &api_v1beta1.BackupSession{
+		Status: api_v1beta1.BackupSessionStatus{
+			Stats: []api_v1beta1.HostBackupStats{
+				{Hostname: "host-0"},
+				{Hostname: "host-1"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		config   *api_v1beta1.BackupConfiguration
+		filters  []*api_v1alpha1.RetentionFilter
+		sessions []string
+	}{
+		{
+			name:   "first config",
+			config: appA,
+			filters: []*api_v1alpha1.RetentionFilter{
+				{Host: "host-0", Tags: []string{"backup-configuration=app-a"}},
+				{Host: "host-1", Tags: []string{"backup-configuration=app-a"}},
+			},
+			sessions: []string{"host-0/app-a-1", "host-1/app-a-1"},
+		},
+		{
+			name:   "second config",
+			config: appB,
+			filters: []*api_v1alpha1.RetentionFilter{
+				{Host: "host-0", Tags: []string{"backup-configuration=app-b"}},
+				{Host: "host-1", Tags: []string{"backup-configuration=app-b"}},
+			},
+			sessions: []string{"host-0/app-b-1", "host-1/app-b-1"},
+		},
+		{
+			name:    "user specified filter",
+			config:  custom,
+			filters: []*api_v1alpha1.RetentionFilter{{Paths: []string{"/data"}}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policies := retentionPoliciesForBackupSession(tc.config, backupSession)
+			var filters []*api_v1alpha1.RetentionFilter
+			var sessions []string
+			for _, policy := range policies {
+				filters = append(filters, policy.Filter)
+				if tc.config.Spec.RetentionPolicy.Filter == nil {
+					sessions = append(sessions, selected(policy.Filter)...)
+				}
+			}
+			assert.Equal(t, tc.filters, filters)
+			// the snapshots of the other BackupConfiguration survive
+			assert.Equal(t, tc.sessions, sessions)
+		})
+	}
+}
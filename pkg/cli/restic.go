@@ -2,7 +2,6 @@ package cli
 
 import (
 	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 
@@ -10,6 +9,7 @@ import (
 	shell "github.com/codeskyblue/go-sh"
 	"github.com/pkg/errors"
 	api "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	"stash.appscode.dev/stash/pkg/restic"
 )
 
 const (
@@ -111,40 +111,7 @@ func (w *ResticWrapper) Forget(resource *api.Restic, fg api.FileGroup) error {
 	}
 
 	args := []interface{}{"forget"}
-	if retentionPolicy.KeepLast > 0 {
-		args = append(args, string(api.KeepLast))
-		args = append(args, strconv.Itoa(retentionPolicy.KeepLast))
-	}
-	if retentionPolicy.KeepHourly > 0 {
-		args = append(args, string(api.KeepHourly))
-		args = append(args, strconv.Itoa(retentionPolicy.KeepHourly))
-	}
-	if retentionPolicy.KeepDaily > 0 {
-		args = append(args, string(api.KeepDaily))
-		args = append(args, strconv.Itoa(retentionPolicy.KeepDaily))
-	}
-	if retentionPolicy.KeepWeekly > 0 {
-		args = append(args, string(api.KeepWeekly))
-		args = append(args, strconv.Itoa(retentionPolicy.KeepWeekly))
-	}
-	if retentionPolicy.KeepMonthly > 0 {
-		args = append(args, string(api.KeepMonthly))
-		args = append(args, strconv.Itoa(retentionPolicy.KeepMonthly))
-	}
-	if retentionPolicy.KeepYearly > 0 {
-		args = append(args, string(api.KeepYearly))
-		args = append(args, strconv.Itoa(retentionPolicy.KeepYearly))
-	}
-	for _, tag := range retentionPolicy.KeepTags {
-		args = append(args, string(api.KeepTag))
-		args = append(args, tag)
-	}
-	if retentionPolicy.Prune {
-		args = append(args, "--prune")
-	}
-	if retentionPolicy.DryRun {
-		args = append(args, "--dry-run")
-	}
+	args = append(args, restic.RetentionPolicyArgs(retentionPolicy)...)
 	if len(args) > 1 {
 		args = w.appendCacheDirFlag(args)
 		args = w.appendCaCertFlag(args)
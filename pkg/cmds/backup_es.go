@@ -13,6 +13,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	appcatalog_cs "kmodules.xyz/custom-resources/client/clientset/versioned"
 	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/status"
 )
 
 const (
@@ -27,6 +28,7 @@ func NewCmdBackupES() *cobra.Command {
 		masterURL      string
 		kubeconfigPath string
 		namespace      string
+		progressOpt    status.UpdateStatusOptions
 		appBindingName string
 		esArgs         string
 		outputDir      string
@@ -96,8 +98,22 @@ func NewCmdBackupES() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			// Run backup
-			backupOutput, backupErr := resticWrapper.RunBackup(backupOpt)
+			// interrupt restic if the job is deleted on cancellation of the session
+			stopCancelWatcher := cancelOnTermination(resticWrapper)
+			defer stopCancelWatcher()
+			// report progress in BackupSession status while backup is running
+			progressOpt.Namespace = namespace
+			progressReporter := newProgressReporter(progressOpt)
+			if progressReporter != nil {
+				backupOpt.OnProgress = progressReporter.ReportBackupProgress
+			}
+			// Run backup along with the hooks
+			backupOutput, backupErr := runBackupWithHooks(progressOpt, func() (*restic.BackupOutput, error) {
+				return resticWrapper.RunBackup(backupOpt)
+			})
+			if progressReporter != nil {
+				progressReporter.Stop()
+			}
 			// If metrics are enabled then generate metrics
 			if metrics.Enabled {
 				err := backupOutput.HandleMetrics(&metrics, backupErr)
@@ -121,6 +137,7 @@ func NewCmdBackupES() *cobra.Command {
 	cmd.Flags().StringVar(&masterURL, "master", masterURL, "The address of the Kubernetes API server (overrides any value in kubeconfig)")
 	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", kubeconfigPath, "Path to kubeconfig file with authorization information (the master location is set by the master flag).")
 	cmd.Flags().StringVar(&namespace, "namespace", "default", "Namespace of Backup/Restore Session")
+	cmd.Flags().StringVar(&progressOpt.BackupSession, "backup-session", progressOpt.BackupSession, "Name of the BackupSession to report progress and execute hooks (keep empty if you don't need them)")
 	cmd.Flags().StringVar(&appBindingName, "app-binding", appBindingName, "Name of the app binding")
 
 	cmd.Flags().StringVar(&setupOpt.Provider, "provider", setupOpt.Provider, "Backend provider (i.e. gcs, s3, azure etc)")
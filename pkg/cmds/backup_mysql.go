@@ -11,6 +11,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	appcatalog_cs "kmodules.xyz/custom-resources/client/clientset/versioned"
 	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/status"
 	"stash.appscode.dev/stash/pkg/util"
 )
 
@@ -29,6 +30,7 @@ func NewCmdBackupMySql() *cobra.Command {
 		masterURL      string
 		kubeconfigPath string
 		namespace      string
+		progressOpt    status.UpdateStatusOptions
 		appBindingName string
 		outputDir      string
 		mysqlArgs      = "--all-databases"
@@ -40,7 +42,8 @@ func NewCmdBackupMySql() *cobra.Command {
 			Host:          restic.DefaultHost,
 			StdinFileName: MySqlDumpFile,
 		}
-		metrics = restic.MetricsOptions{
+		retentionFilter util.RetentionFilterFlags
+		metrics         = restic.MetricsOptions{
 			JobName: JobMySqlBackup,
 		}
 	)
@@ -93,6 +96,9 @@ func NewCmdBackupMySql() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			// interrupt restic if the job is deleted on cancellation of the session
+			stopCancelWatcher := cancelOnTermination(resticWrapper)
+			defer stopCancelWatcher()
 
 			// set env for mysqldump
 			resticWrapper.SetEnv(EnvMySqlPassword, string(appBindingSecret.Data[MySqlPassword]))
@@ -111,8 +117,20 @@ func NewCmdBackupMySql() *cobra.Command {
 			// wait for DB ready
 			waitForDBReady(appBinding.Spec.ClientConfig.Service.Name, appBinding.Spec.ClientConfig.Service.Port)
 
-			// Run backup
-			backupOutput, backupErr := resticWrapper.RunBackup(backupOpt)
+			backupOpt.RetentionPolicy.Filter = retentionFilter.RetentionFilter()
+			// report progress in BackupSession status while backup is running
+			progressOpt.Namespace = namespace
+			progressReporter := newProgressReporter(progressOpt)
+			if progressReporter != nil {
+				backupOpt.OnProgress = progressReporter.ReportBackupProgress
+			}
+			// Run backup along with the hooks
+			backupOutput, backupErr := runBackupWithHooks(progressOpt, func() (*restic.BackupOutput, error) {
+				return resticWrapper.RunBackup(backupOpt)
+			})
+			if progressReporter != nil {
+				progressReporter.Stop()
+			}
 			// If metrics are enabled then generate metrics
 			if metrics.Enabled {
 				err := backupOutput.HandleMetrics(&metrics, backupErr)
@@ -136,6 +154,7 @@ func NewCmdBackupMySql() *cobra.Command {
 	cmd.Flags().StringVar(&masterURL, "master", masterURL, "The address of the Kubernetes API server (overrides any value in kubeconfig)")
 	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", kubeconfigPath, "Path to kubeconfig file with authorization information (the master location is set by the master flag).")
 	cmd.Flags().StringVar(&namespace, "namespace", "default", "Namespace of Backup/Restore Session")
+	cmd.Flags().StringVar(&progressOpt.BackupSession, "backup-session", progressOpt.BackupSession, "Name of the BackupSession to report progress and execute hooks (keep empty if you don't need them)")
 	cmd.Flags().StringVar(&appBindingName, "app-binding", appBindingName, "Name of the app binding")
 
 	cmd.Flags().StringVar(&setupOpt.Provider, "provider", setupOpt.Provider, "Backend provider (i.e. gcs, s3, azure etc)")
@@ -159,6 +178,13 @@ func NewCmdBackupMySql() *cobra.Command {
 	cmd.Flags().StringSliceVar(&backupOpt.RetentionPolicy.KeepTags, "retention-keep-tags", backupOpt.RetentionPolicy.KeepTags, "Specify value for retention strategy")
 	cmd.Flags().BoolVar(&backupOpt.RetentionPolicy.Prune, "retention-prune", backupOpt.RetentionPolicy.Prune, "Specify whether to prune old snapshot data")
 	cmd.Flags().BoolVar(&backupOpt.RetentionPolicy.DryRun, "retention-dry-run", backupOpt.RetentionPolicy.DryRun, "Specify whether to test retention policy without deleting actual data")
+	cmd.Flags().StringVar(&backupOpt.RetentionPolicy.KeepWithin, "retention-keep-within", backupOpt.RetentionPolicy.KeepWithin, "Specify value for retention strategy")
+	cmd.Flags().StringSliceVar(&retentionFilter.GroupBy, "retention-group-by", retentionFilter.GroupBy, "Specify how to group snapshots while applying retention policy (host, paths, tags)")
+	cmd.Flags().StringVar(&retentionFilter.Host, "retention-filter-host", retentionFilter.Host, "Apply retention policy only on the snapshots of this host")
+	cmd.Flags().StringSliceVar(&retentionFilter.Tags, "retention-filter-tags", retentionFilter.Tags, "Apply retention policy only on the snapshots having these tags")
+	cmd.Flags().StringSliceVar(&retentionFilter.Paths, "retention-filter-paths", retentionFilter.Paths, "Apply retention policy only on the snapshots having these paths")
+	cmd.Flags().StringSliceVar(&backupOpt.Tags, "tags", backupOpt.Tags, "Tags to add to the backup snapshots")
+	cmd.Flags().BoolVar(&backupOpt.SkipCheck, "skip-integrity-check", backupOpt.SkipCheck, "Specify whether to skip repository integrity check after backup")
 
 	cmd.Flags().StringVar(&outputDir, "output-dir", outputDir, "Directory where output.json file will be written (keep empty if you don't need to write output in file)")
 
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/util/errors"
 	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/status"
 	"stash.appscode.dev/stash/pkg/util"
 )
 
@@ -24,7 +25,9 @@ func NewCmdBackupPVC() *cobra.Command {
 			ScratchDir:  restic.DefaultScratchDir,
 			EnableCache: false,
 		}
-		metrics = restic.MetricsOptions{
+		retentionFilter util.RetentionFilterFlags
+		progressOpt     status.UpdateStatusOptions
+		metrics         = restic.MetricsOptions{
 			JobName: JobPVCBackup,
 		}
 	)
@@ -52,8 +55,22 @@ func NewCmdBackupPVC() *cobra.Command {
 			if err != nil {
 				return util.HandleResticError(outputDir, restic.DefaultOutputFileName, err)
 			}
-			// Run backup
-			backupOutput, backupErr := resticWrapper.RunBackup(backupOpt)
+			// interrupt restic if the job is deleted on cancellation of the session
+			stopCancelWatcher := cancelOnTermination(resticWrapper)
+			defer stopCancelWatcher()
+			backupOpt.RetentionPolicy.Filter = retentionFilter.RetentionFilter()
+			// report progress in BackupSession status while backup is running
+			progressReporter := newProgressReporter(progressOpt)
+			if progressReporter != nil {
+				backupOpt.OnProgress = progressReporter.ReportBackupProgress
+			}
+			// Run backup along with the hooks
+			backupOutput, backupErr := runBackupWithHooks(progressOpt, func() (*restic.BackupOutput, error) {
+				return resticWrapper.RunBackup(backupOpt)
+			})
+			if progressReporter != nil {
+				progressReporter.Stop()
+			}
 			// If metrics are enabled then generate metrics
 			if metrics.Enabled {
 				err := backupOutput.HandleMetrics(&metrics, backupErr)
@@ -84,6 +101,12 @@ func NewCmdBackupPVC() *cobra.Command {
 
 	cmd.Flags().StringVar(&backupOpt.Host, "hostname", backupOpt.Host, "Name of the host machine")
 	cmd.Flags().StringSliceVar(&backupOpt.BackupDirs, "backup-dirs", backupOpt.BackupDirs, "List of directories to be backed up")
+	cmd.Flags().Var(newPatternsValue(&backupOpt.Filter.Exclude), "exclude", "Patterns of the files and directories to exclude from backup")
+	cmd.Flags().Var(newPatternsValue(&backupOpt.Filter.Include), "include", "Patterns of the files and directories to backup relative to each backup directory")
+	cmd.Flags().Var(newPatternsValue(&backupOpt.Filter.ExcludeIfPresent), "exclude-if-present", "Exclude a directory if it contains a file with any of these names")
+	cmd.Flags().StringVar(&backupOpt.Filter.IgnoreFileName, "ignore-file-name", backupOpt.Filter.IgnoreFileName, "Name of the file in the root of each backup directory that lists the patterns to exclude")
+	cmd.Flags().BoolVar(&backupOpt.Filter.ExcludeCaches, "exclude-caches", backupOpt.Filter.ExcludeCaches, "Exclude the directories containing CACHEDIR.TAG file")
+	cmd.Flags().BoolVar(&backupOpt.Filter.OneFileSystem, "one-file-system", backupOpt.Filter.OneFileSystem, "Don't cross the file system boundaries of the backup directories")
 
 	cmd.Flags().IntVar(&backupOpt.RetentionPolicy.KeepLast, "retention-keep-last", backupOpt.RetentionPolicy.KeepLast, "Specify value for retention strategy")
 	cmd.Flags().IntVar(&backupOpt.RetentionPolicy.KeepHourly, "retention-keep-hourly", backupOpt.RetentionPolicy.KeepHourly, "Specify value for retention strategy")
@@ -94,6 +117,16 @@ func NewCmdBackupPVC() *cobra.Command {
 	cmd.Flags().StringSliceVar(&backupOpt.RetentionPolicy.KeepTags, "retention-keep-tags", backupOpt.RetentionPolicy.KeepTags, "Specify value for retention strategy")
 	cmd.Flags().BoolVar(&backupOpt.RetentionPolicy.Prune, "retention-prune", backupOpt.RetentionPolicy.Prune, "Specify whether to prune old snapshot data")
 	cmd.Flags().BoolVar(&backupOpt.RetentionPolicy.DryRun, "retention-dry-run", backupOpt.RetentionPolicy.DryRun, "Specify whether to test retention policy without deleting actual data")
+	cmd.Flags().StringVar(&backupOpt.RetentionPolicy.KeepWithin, "retention-keep-within", backupOpt.RetentionPolicy.KeepWithin, "Specify value for retention strategy")
+	cmd.Flags().StringSliceVar(&retentionFilter.GroupBy, "retention-group-by", retentionFilter.GroupBy, "Specify how to group snapshots while applying retention policy (host, paths, tags)")
+	cmd.Flags().StringVar(&retentionFilter.Host, "retention-filter-host", retentionFilter.Host, "Apply retention policy only on the snapshots of this host")
+	cmd.Flags().StringSliceVar(&retentionFilter.Tags, "retention-filter-tags", retentionFilter.Tags, "Apply retention policy only on the snapshots having these tags")
+	cmd.Flags().StringSliceVar(&retentionFilter.Paths, "retention-filter-paths", retentionFilter.Paths, "Apply retention policy only on the snapshots having these paths")
+	cmd.Flags().StringSliceVar(&backupOpt.Tags, "tags", backupOpt.Tags, "Tags to add to the backup snapshots")
+	cmd.Flags().BoolVar(&backupOpt.SkipCheck, "skip-integrity-check", backupOpt.SkipCheck, "Specify whether to skip repository integrity check after backup")
+
+	cmd.Flags().StringVar(&progressOpt.Namespace, "namespace", progressOpt.Namespace, "Namespace of the BackupSession")
+	cmd.Flags().StringVar(&progressOpt.BackupSession, "backup-session", progressOpt.BackupSession, "Name of the BackupSession to report progress and execute hooks (keep empty if you don't need them)")
 
 	cmd.Flags().StringVar(&outputDir, "output-dir", outputDir, "Directory where output.json file will be written (keep empty if you don't need to write output in file)")
 
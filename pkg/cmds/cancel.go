@@ -0,0 +1,36 @@
+package cmds
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/appscode/go/log"
+	"stash.appscode.dev/stash/pkg/restic"
+)
+
+// cancelOnTermination interrupts the running restic command when the container receives a termination signal.
+// The job of a BackupSession or RestoreSession is deleted when the session is cancelled, so the pod receives SIGTERM.
+// The returned function stops watching and removes the stale locks if the operation has been cancelled.
+func cancelOnTermination(w *restic.ResticWrapper) func() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGTERM, syscall.SIGINT)
+	doneCh := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-sigCh:
+			log.Infof("Received %s signal. Cancelling the running operation.", sig)
+			w.Cancel()
+		case <-doneCh:
+		}
+	}()
+	return func() {
+		signal.Stop(sigCh)
+		close(doneCh)
+		if w.IsCancelled() {
+			if err := w.RemoveStaleLocks(); err != nil {
+				log.Warningf("Failed to remove stale locks from the repository. Reason: %v", err)
+			}
+		}
+	}
+}
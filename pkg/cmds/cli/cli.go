@@ -61,6 +61,7 @@ func NewCLICmd() *cobra.Command {
 	cmd.AddCommand(NewBackupPVCmd())
 	cmd.AddCommand(NewDownloadCmd())
 	cmd.AddCommand(NewDeleteSnapshotCmd())
+	cmd.AddCommand(NewDiffSnapshotsCmd())
 
 	return cmd
 }
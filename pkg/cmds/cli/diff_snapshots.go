@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/appscode/go/flags"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+	repov1alpha1 "stash.appscode.dev/stash/apis/repositories/v1alpha1"
+)
+
+func NewDiffSnapshotsCmd() *cobra.Command {
+	var (
+		kubeConfig string
+		namespace  string
+		against    string
+		output     string
+	)
+
+	var cmd = &cobra.Command{
+		Use:               "diff-snapshots",
+		Short:             `Show changes between two snapshots`,
+		Long:              `Show the files and directories that have been changed between two snapshots of the same repository`,
+		Example:           `stash cli diff-snapshots <repository>-<new snapshot id> --against=<repository>-<old snapshot id>`,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("snapshot name not provided")
+			}
+			flags.EnsureRequiredFlags(cmd, "against")
+
+			c, err := newStashCLIController(kubeConfig)
+			if err != nil {
+				return err
+			}
+
+			diff := &repov1alpha1.SnapshotDiff{}
+			err = c.stashClient.RepositoriesV1alpha1().RESTClient().Get().
+				Namespace(namespace).
+				Resource(repov1alpha1.ResourcePluralSnapshot).
+				Name(args[0]).
+				SubResource(repov1alpha1.SubresourceSnapshotDiff).
+				Param("against", against).
+				Do().
+				Into(diff)
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				data, err := json.MarshalIndent(diff, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+			return printSnapshotDiff(diff)
+		},
+	}
+
+	cmd.Flags().StringVar(&kubeConfig, "kubeconfig", kubeConfig, "Path of the Kube config file.")
+	cmd.Flags().StringVar(&namespace, "namespace", "default", "Namespace of the Repository.")
+	cmd.Flags().StringVar(&against, "against", against, "Name of the older snapshot to compare with.")
+	cmd.Flags().StringVarP(&output, "output", "o", output, "Output format. One of: json.")
+
+	return cmd
+}
+
+func printSnapshotDiff(diff *repov1alpha1.SnapshotDiff) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "Comparing snapshot %s to %s\n\n", diff.From, diff.To)
+	fmt.Fprintln(w, "CHANGE\tPATH")
+	for _, entry := range diff.Entries {
+		fmt.Fprintf(w, "%s\t%s\n", entry.Change, entry.Path)
+	}
+	fmt.Fprintln(w)
+
+	s := diff.Stats
+	fmt.Fprintln(w, "\tNEW\tREMOVED\tCHANGED")
+	fmt.Fprintf(w, "Files\t%d\t%d\t%d\n", s.FilesAdded, s.FilesRemoved, s.FilesChanged)
+	fmt.Fprintf(w, "Dirs\t%d\t%d\t\n", s.DirsAdded, s.DirsRemoved)
+	fmt.Fprintf(w, "Others\t%d\t%d\t\n", s.OthersAdded, s.OthersRemoved)
+	fmt.Fprintf(w, "Data Blobs\t%d\t%d\t\n", s.DataBlobsAdded, s.DataBlobsRemoved)
+	fmt.Fprintf(w, "Tree Blobs\t%d\t%d\t\n", s.TreeBlobsAdded, s.TreeBlobsRemoved)
+	fmt.Fprintf(w, "Bytes\t%s\t%s\t\n",
+		resource.NewQuantity(s.BytesAdded, resource.BinarySI).String(),
+		resource.NewQuantity(s.BytesRemoved, resource.BinarySI).String())
+	return w.Flush()
+}
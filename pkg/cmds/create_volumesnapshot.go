@@ -12,12 +12,14 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 	"kmodules.xyz/client-go/meta"
 	"stash.appscode.dev/stash/apis"
 	"stash.appscode.dev/stash/apis/stash/v1beta1"
 	cs "stash.appscode.dev/stash/client/clientset/versioned"
+	"stash.appscode.dev/stash/pkg/hooks"
 	"stash.appscode.dev/stash/pkg/restic"
 	"stash.appscode.dev/stash/pkg/status"
 	"stash.appscode.dev/stash/pkg/util"
@@ -131,17 +133,35 @@ func (opt *VSoption) CreateVolumeSnapshot() error {
 		pvcList = []string{name}
 	}
 
-	objectMeta := []metav1.ObjectMeta{}
+	// no workload is running for the target in this job. so, exec hooks are executed inside this container.
+	hookExecutor := &hooks.HookExecutor{}
+	backupHooks := backupConfiguration.Spec.Hooks
+	if backupHooks == nil {
+		backupHooks = &v1beta1.BackupHooks{}
+	}
+	// execute preBackup hook. abort backup if the hook fails and its failure policy does not allow to continue.
+	if err = hookExecutor.Execute(v1beta1.PreBackupHook, backupHooks.PreBackup); err != nil {
+		return err
+	}
 
+	objectMeta := []metav1.ObjectMeta{}
+	var snapshotErr error
 	for _, pvcName := range pvcList {
 		parts := strings.Split(backupSession.Name, "-")
 		volumeSnapshot := opt.getVolumeSnapshotDefinition(backupConfiguration, pvcName, parts[len(parts)-1])
 		vs, err := opt.snapshotClient.VolumesnapshotV1alpha1().VolumeSnapshots(namespace).Create(&volumeSnapshot)
 		if err != nil {
-			return err
+			snapshotErr = err
+			break
 		}
 		objectMeta = append(objectMeta, vs.ObjectMeta)
 	}
+	// execute postBackup hook once the snapshots have been requested, even if it has failed,
+	// so that the target can return to its normal state
+	hookErr := hookExecutor.Execute(v1beta1.PostBackupHook, backupHooks.PostBackup)
+	if snapshotErr != nil || hookErr != nil {
+		return errors.NewAggregate([]error{snapshotErr, hookErr})
+	}
 
 	for i, pvcName := range pvcList {
 		err = util.WaitUntilVolumeSnapshotReady(opt.snapshotClient, objectMeta[i])
@@ -159,6 +179,7 @@ func (opt *VSoption) CreateVolumeSnapshot() error {
 			HostBackupStats: v1beta1.HostBackupStats{
 				Hostname: pvcName,
 				Phase:    v1beta1.HostBackupSucceeded,
+				Hooks:    hookExecutor.Stats,
 			},
 		}
 		// Volume Snapshot complete. Read current time and calculate total backup duration.
@@ -16,6 +16,7 @@ func NewCmdForget() *cobra.Command {
 		masterURL      string
 		kubeconfigPath string
 		repositoryName string
+		prune          = true
 	)
 
 	cmd := &cobra.Command{
@@ -39,12 +40,13 @@ func NewCmdForget() *cobra.Command {
 			}
 
 			r := snapshot.NewREST(config)
-			return r.ForgetVersionedSnapshots(repo, args, true)
+			return r.ForgetSnapshots(repo, args, prune)
 		},
 	}
 	cmd.Flags().StringVar(&masterURL, "master", masterURL, "The address of the Kubernetes API server (overrides any value in kubeconfig)")
 	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", kubeconfigPath, "Path to kubeconfig file with authorization information (the master location is set by the master flag).")
 	cmd.Flags().StringVar(&repositoryName, "repo-name", repositoryName, "Name of the Repository CRD.")
+	cmd.Flags().BoolVar(&prune, "prune", prune, "Specify whether to remove the data of the snapshots. If false, the data are removed by the next prune.")
 
 	return cmd
 }
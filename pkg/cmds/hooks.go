@@ -0,0 +1,126 @@
+package cmds
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	cs "stash.appscode.dev/stash/client/clientset/versioned"
+	"stash.appscode.dev/stash/pkg/hooks"
+	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/status"
+)
+
+// runBackupWithHooks executes the preBackup and postBackup hooks of the BackupConfiguration of the BackupSession
+// specified in the options around the backup. No workload is running for the targets that are backed up by a job.
+// So, exec hooks are executed inside the job container. The returned output holds the results of the hooks.
+func runBackupWithHooks(opt status.UpdateStatusOptions, backup func() (*restic.BackupOutput, error)) (*restic.BackupOutput, error) {
+	backupHooks, err := backupHooksForSession(opt)
+	if err != nil {
+		return &restic.BackupOutput{}, err
+	}
+	return executeBackupHooks(backupHooks, backup)
+}
+
+func executeBackupHooks(backupHooks *api_v1beta1.BackupHooks, backup func() (*restic.BackupOutput, error)) (*restic.BackupOutput, error) {
+	backupOutput := &restic.BackupOutput{}
+	hookExecutor := &hooks.HookExecutor{}
+
+	// execute preBackup hook. abort backup if the hook fails and its failure policy does not allow to continue.
+	if err := hookExecutor.Execute(api_v1beta1.PreBackupHook, backupHooks.PreBackup); err != nil {
+		backupOutput.HostBackupStats.Hooks = hookExecutor.Stats
+		return backupOutput, err
+	}
+
+	out, backupErr := backup()
+	if out != nil {
+		backupOutput = out
+	}
+	// execute postBackup hook even if backup has failed so that the target can return to its normal state
+	hookErr := hookExecutor.Execute(api_v1beta1.PostBackupHook, backupHooks.PostBackup)
+	backupOutput.HostBackupStats.Hooks = hookExecutor.Stats
+	return backupOutput, errors.NewAggregate([]error{backupErr, hookErr})
+}
+
+// runRestoreWithHooks executes the preRestore and postRestore hooks of the RestoreSession specified in the options
+// around the restore. Like the restore init-container, postRestore hook is executed only if the restore has succeeded.
+func runRestoreWithHooks(opt status.UpdateStatusOptions, restore func() (*restic.RestoreOutput, error)) (*restic.RestoreOutput, error) {
+	restoreHooks, err := restoreHooksForSession(opt)
+	if err != nil {
+		return &restic.RestoreOutput{}, err
+	}
+	return executeRestoreHooks(restoreHooks, restore)
+}
+
+func executeRestoreHooks(restoreHooks *api_v1beta1.RestoreHooks, restore func() (*restic.RestoreOutput, error)) (*restic.RestoreOutput, error) {
+	restoreOutput := &restic.RestoreOutput{}
+	hookExecutor := &hooks.HookExecutor{}
+
+	// execute preRestore hook. abort restore if the hook fails and its failure policy does not allow to continue.
+	if err := hookExecutor.Execute(api_v1beta1.PreRestoreHook, restoreHooks.PreRestore); err != nil {
+		restoreOutput.HostRestoreStats.Hooks = hookExecutor.Stats
+		return restoreOutput, err
+	}
+
+	out, err := restore()
+	if out != nil {
+		restoreOutput = out
+	}
+	if err == nil {
+		err = hookExecutor.Execute(api_v1beta1.PostRestoreHook, restoreHooks.PostRestore)
+	}
+	restoreOutput.HostRestoreStats.Hooks = hookExecutor.Stats
+	return restoreOutput, err
+}
+
+// backupHooksForSession returns the hooks of the BackupConfiguration of the BackupSession specified in the options.
+// It returns empty hooks if no BackupSession has been specified.
+func backupHooksForSession(opt status.UpdateStatusOptions) (*api_v1beta1.BackupHooks, error) {
+	if opt.BackupSession == "" {
+		return &api_v1beta1.BackupHooks{}, nil
+	}
+	stashClient, err := newStashClient()
+	if err != nil {
+		return nil, err
+	}
+	backupSession, err := stashClient.StashV1beta1().BackupSessions(opt.Namespace).Get(opt.BackupSession, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	backupConfig, err := stashClient.StashV1beta1().BackupConfigurations(opt.Namespace).Get(backupSession.Spec.BackupConfiguration.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if backupConfig.Spec.Hooks == nil {
+		return &api_v1beta1.BackupHooks{}, nil
+	}
+	return backupConfig.Spec.Hooks, nil
+}
+
+// restoreHooksForSession returns the hooks of the RestoreSession specified in the options.
+// It returns empty hooks if no RestoreSession has been specified.
+func restoreHooksForSession(opt status.UpdateStatusOptions) (*api_v1beta1.RestoreHooks, error) {
+	if opt.RestoreSession == "" {
+		return &api_v1beta1.RestoreHooks{}, nil
+	}
+	stashClient, err := newStashClient()
+	if err != nil {
+		return nil, err
+	}
+	restoreSession, err := stashClient.StashV1beta1().RestoreSessions(opt.Namespace).Get(opt.RestoreSession, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if restoreSession.Spec.Hooks == nil {
+		return &api_v1beta1.RestoreHooks{}, nil
+	}
+	return restoreSession.Spec.Hooks, nil
+}
+
+func newStashClient() (cs.Interface, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", "")
+	if err != nil {
+		return nil, err
+	}
+	return cs.NewForConfig(config)
+}
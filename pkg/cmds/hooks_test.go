@@ -0,0 +1,126 @@
+package cmds
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	"stash.appscode.dev/stash/pkg/restic"
+)
+
+func TestExecuteBackupHooks(t *testing.T) {
+	succeed := &api_v1beta1.HookSpec{Exec: &api_v1beta1.ExecHook{Command: []string{"true"}}}
+	fail := &api_v1beta1.HookSpec{Exec: &api_v1beta1.ExecHook{Command: []string{"false"}}}
+
+	testCases := []struct {
+		name      string
+		hooks     *api_v1beta1.BackupHooks
+		backupErr error
+		backedUp  bool
+		hooksRun  []string
+		invalid   bool
+	}{
+		{name: "no hooks", hooks: &api_v1beta1.BackupHooks{}, backedUp: true},
+		{
+			name:     "both hooks",
+			hooks:    &api_v1beta1.BackupHooks{PreBackup: succeed, PostBackup: succeed},
+			backedUp: true,
+			hooksRun: []string{api_v1beta1.PreBackupHook, api_v1beta1.PostBackupHook},
+		},
+		{
+			name:     "failed preBackup hook",
+			hooks:    &api_v1beta1.BackupHooks{PreBackup: fail, PostBackup: succeed},
+			hooksRun: []string{api_v1beta1.PreBackupHook},
+			invalid:  true,
+		},
+		{
+			name:      "postBackup hook after failed backup",
+			hooks:     &api_v1beta1.BackupHooks{PreBackup: succeed, PostBackup: succeed},
+			backupErr: fmt.Errorf("backup failed"),
+			backedUp:  true,
+			hooksRun:  []string{api_v1beta1.PreBackupHook, api_v1beta1.PostBackupHook},
+			invalid:   true,
+		},
+		{
+			name:     "failed postBackup hook",
+			hooks:    &api_v1beta1.BackupHooks{PostBackup: fail},
+			backedUp: true,
+			hooksRun: []string{api_v1beta1.PostBackupHook},
+			invalid:  true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backedUp := false
+			out, err := executeBackupHooks(tc.hooks, func() (*restic.BackupOutput, error) {
+				backedUp = true
+				return &restic.BackupOutput{}, tc.backupErr
+			})
+			assert.Equal(t, tc.invalid, err != nil, fmt.Sprintf("error: %v", err))
+			assert.Equal(t, tc.backedUp, backedUp)
+			var hooksRun []string
+			for _, stats := range out.HostBackupStats.Hooks {
+				hooksRun = append(hooksRun, stats.Name)
+			}
+			assert.Equal(t, tc.hooksRun, hooksRun)
+		})
+	}
+}
+
+func TestExecuteRestoreHooks(t *testing.T) {
+	succeed := &api_v1beta1.HookSpec{Exec: &api_v1beta1.ExecHook{Command: []string{"true"}}}
+	fail := &api_v1beta1.HookSpec{Exec: &api_v1beta1.ExecHook{Command: []string{"false"}}}
+
+	testCases := []struct {
+		name       string
+		hooks      *api_v1beta1.RestoreHooks
+		restoreErr error
+		restored   bool
+		hooksRun   []string
+		invalid    bool
+	}{
+		{
+			name:     "both hooks",
+			hooks:    &api_v1beta1.RestoreHooks{PreRestore: succeed, PostRestore: succeed},
+			restored: true,
+			hooksRun: []string{api_v1beta1.PreRestoreHook, api_v1beta1.PostRestoreHook},
+		},
+		{
+			name:     "failed preRestore hook",
+			hooks:    &api_v1beta1.RestoreHooks{PreRestore: fail, PostRestore: succeed},
+			hooksRun: []string{api_v1beta1.PreRestoreHook},
+			invalid:  true,
+		},
+		{
+			name:     "failed preRestore hook with continue policy",
+			hooks:    &api_v1beta1.RestoreHooks{PreRestore: &api_v1beta1.HookSpec{Exec: fail.Exec, FailurePolicy: api_v1beta1.HookFailurePolicyContinue}},
+			restored: true,
+			hooksRun: []string{api_v1beta1.PreRestoreHook},
+		},
+		{
+			name:       "no postRestore hook after failed restore",
+			hooks:      &api_v1beta1.RestoreHooks{PreRestore: succeed, PostRestore: succeed},
+			restoreErr: fmt.Errorf("restore failed"),
+			restored:   true,
+			hooksRun:   []string{api_v1beta1.PreRestoreHook},
+			invalid:    true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			restored := false
+			out, err := executeRestoreHooks(tc.hooks, func() (*restic.RestoreOutput, error) {
+				restored = true
+				return &restic.RestoreOutput{}, tc.restoreErr
+			})
+			assert.Equal(t, tc.invalid, err != nil, fmt.Sprintf("error: %v", err))
+			assert.Equal(t, tc.restored, restored)
+			var hooksRun []string
+			for _, stats := range out.HostRestoreStats.Hooks {
+				hooksRun = append(hooksRun, stats.Name)
+			}
+			assert.Equal(t, tc.hooksRun, hooksRun)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package cmds
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/appscode/go/flags"
+	"github.com/appscode/go/log"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"kmodules.xyz/client-go/meta"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	cs "stash.appscode.dev/stash/client/clientset/versioned"
+	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/status"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+type maintenanceOptions struct {
+	task     string
+	extraOpt util.ExtraOptions
+	status.UpdateStatusOptions
+}
+
+func NewCmdMaintainRepository() *cobra.Command {
+	var (
+		masterURL      string
+		kubeconfigPath string
+		opt            = maintenanceOptions{
+			extraOpt: util.ExtraOptions{
+				SecretDir:   util.StashSecretMountDir,
+				ScratchDir:  util.TmpDirMountPath,
+				EnableCache: true,
+			},
+			UpdateStatusOptions: status.UpdateStatusOptions{
+				Namespace: meta.Namespace(),
+			},
+		}
+	)
+
+	cmd := &cobra.Command{
+		Use:               "maintain-repository",
+		Short:             "Run a scheduled maintenance task on a Repository",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.EnsureRequiredFlags(cmd, "repository", "task")
+
+			config, err := clientcmd.BuildConfigFromFlags(masterURL, kubeconfigPath)
+			if err != nil {
+				return err
+			}
+			opt.KubeClient, err = kubernetes.NewForConfig(config)
+			if err != nil {
+				return err
+			}
+			opt.StashClient, err = cs.NewForConfig(config)
+			if err != nil {
+				return err
+			}
+			return opt.runMaintenanceTask()
+		},
+	}
+
+	cmd.Flags().StringVar(&masterURL, "master", masterURL, "The address of the Kubernetes API server (overrides any value in kubeconfig)")
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", kubeconfigPath, "Path to kubeconfig file with authorization information (the master location is set by the master flag).")
+
+	cmd.Flags().StringVar(&opt.Namespace, "namespace", opt.Namespace, "Namespace of the Repository")
+	cmd.Flags().StringVar(&opt.Repository, "repository", opt.Repository, "Name of the Repository")
+	cmd.Flags().StringVar(&opt.task, "task", opt.task, "Maintenance task to run (check, prune)")
+	cmd.Flags().StringVar(&opt.extraOpt.SecretDir, "secret-dir", opt.extraOpt.SecretDir, "Directory where storage secret has been mounted")
+	cmd.Flags().StringVar(&opt.extraOpt.ScratchDir, "scratch-dir", opt.extraOpt.ScratchDir, "Temporary directory")
+	cmd.Flags().BoolVar(&opt.extraOpt.EnableCache, "enable-cache", opt.extraOpt.EnableCache, "Specify whether to enable caching for restic")
+
+	return cmd
+}
+
+func (opt *maintenanceOptions) runMaintenanceTask() error {
+	repository, err := opt.StashClient.StashV1alpha1().Repositories(opt.Namespace).Get(opt.Repository, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if repository.Spec.Maintenance == nil {
+		return fmt.Errorf("no maintenance task has been specified for Repository %s/%s", repository.Namespace, repository.Name)
+	}
+
+	setupOpt, err := util.SetupOptionsForRepository(*repository, opt.extraOpt)
+	if err != nil {
+		return err
+	}
+	resticWrapper, err := restic.NewResticWrapper(setupOpt)
+	if err != nil {
+		return err
+	}
+
+	startTime := metav1.Now()
+	var repoStats *restic.RepositoryStats
+	switch opt.task {
+	case api_v1alpha1.MaintenanceTaskCheck:
+		if repository.Spec.Maintenance.Check == nil {
+			return fmt.Errorf("integrity check has not been scheduled for Repository %s/%s", repository.Namespace, repository.Name)
+		}
+		repoStats, err = resticWrapper.RunCheck(restic.CheckOptions{
+			ReadData:       repository.Spec.Maintenance.Check.ReadData,
+			ReadDataSubset: repository.Spec.Maintenance.Check.ReadDataSubset,
+		})
+	case api_v1alpha1.MaintenanceTaskPrune:
+		if repository.Spec.Maintenance.Prune == nil {
+			return fmt.Errorf("prune has not been scheduled for Repository %s/%s", repository.Namespace, repository.Name)
+		}
+		repoStats, err = resticWrapper.RunPrune(repository.Spec.Maintenance.Prune.RetentionPolicy)
+	default:
+		return fmt.Errorf("unknown maintenance task %q", opt.task)
+	}
+
+	completionTime := metav1.Now()
+	taskStatus := api_v1alpha1.MaintenanceTaskStatus{
+		Phase:          api_v1alpha1.MaintenanceTaskSucceeded,
+		StartTime:      &startTime,
+		CompletionTime: &completionTime,
+		Duration:       completionTime.Sub(startTime.Time).Round(time.Second).String(),
+	}
+	if err != nil {
+		taskStatus.Phase = api_v1alpha1.MaintenanceTaskFailed
+		taskStatus.Error = err.Error()
+	}
+	if serr := opt.UpdateRepositoryMaintenanceStatus(opt.task, repoStats, taskStatus); serr != nil {
+		log.Errorf("Failed to update status of Repository %s/%s. Reason: %v", repository.Namespace, repository.Name, serr)
+	}
+	return err
+}
@@ -0,0 +1,50 @@
+package cmds
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// patternsValue is a flag that holds a list of file patterns. The operator passes the patterns as a JSON array
+// because a pattern may contain commas (i.e. "*.{yaml,json}"). A comma separated list is still accepted
+// for the Functions that set the flag directly.
+type patternsValue struct {
+	patterns *[]string
+}
+
+var _ pflag.Value = &patternsValue{}
+
+func newPatternsValue(patterns *[]string) *patternsValue {
+	return &patternsValue{patterns: patterns}
+}
+
+func (v *patternsValue) Set(s string) error {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "":
+		*v.patterns = nil
+	case strings.HasPrefix(s, "["):
+		var patterns []string
+		if err := json.Unmarshal([]byte(s), &patterns); err != nil {
+			return err
+		}
+		*v.patterns = patterns
+	default:
+		*v.patterns = strings.Split(s, ",")
+	}
+	return nil
+}
+
+func (v *patternsValue) String() string {
+	if len(*v.patterns) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(*v.patterns)
+	return string(data)
+}
+
+func (v *patternsValue) Type() string {
+	return "patterns"
+}
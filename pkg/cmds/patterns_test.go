@@ -0,0 +1,34 @@
+package cmds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatternsValue(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		expected []string
+		invalid  bool
+	}{
+		{name: "empty", value: "", expected: nil},
+		{name: "json array", value: `["/data/*.log","/tmp"]`, expected: []string{"/data/*.log", "/tmp"}},
+		{name: "pattern with comma", value: `["*.{yaml,json}","/data/{a,b}/*"]`, expected: []string{"*.{yaml,json}", "/data/{a,b}/*"}},
+		{name: "comma separated list", value: "/data/*.log,/tmp", expected: []string{"/data/*.log", "/tmp"}},
+		{name: "malformed json array", value: `["*.log"`, invalid: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var patterns []string
+			err := newPatternsValue(&patterns).Set(tc.value)
+			if tc.invalid {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, patterns)
+		})
+	}
+}
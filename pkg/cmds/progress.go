@@ -0,0 +1,27 @@
+package cmds
+
+import (
+	"github.com/appscode/go/log"
+	"k8s.io/client-go/tools/clientcmd"
+	cs "stash.appscode.dev/stash/client/clientset/versioned"
+	"stash.appscode.dev/stash/pkg/status"
+)
+
+// newProgressReporter returns a ProgressReporter for the BackupSession or RestoreSession specified in the options.
+// Progress reporting is best effort. So, it returns nil if no session has been specified or the client can't be created.
+func newProgressReporter(opt status.UpdateStatusOptions) *status.ProgressReporter {
+	if opt.BackupSession == "" && opt.RestoreSession == "" {
+		return nil
+	}
+	config, err := clientcmd.BuildConfigFromFlags("", "")
+	if err != nil {
+		log.Warningf("Progress will not be reported. Reason: %v", err)
+		return nil
+	}
+	opt.StashClient, err = cs.NewForConfig(config)
+	if err != nil {
+		log.Warningf("Progress will not be reported. Reason: %v", err)
+		return nil
+	}
+	return opt.NewProgressReporter(status.DefaultProgressUpdateInterval)
+}
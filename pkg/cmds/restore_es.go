@@ -13,6 +13,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	appcatalog_cs "kmodules.xyz/custom-resources/client/clientset/versioned"
 	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/status"
 )
 
 func NewCmdRestoreES() *cobra.Command {
@@ -20,6 +21,7 @@ func NewCmdRestoreES() *cobra.Command {
 		masterURL      string
 		kubeconfigPath string
 		namespace      string
+		progressOpt    status.UpdateStatusOptions
 		appBindingName string
 		outputDir      string
 		esArgs         string
@@ -72,8 +74,22 @@ func NewCmdRestoreES() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			// Run restore
-			restoreOutput, restoreErr := resticWrapper.RunRestore(restoreOpt)
+			// interrupt restic if the job is deleted on cancellation of the session
+			stopCancelWatcher := cancelOnTermination(resticWrapper)
+			defer stopCancelWatcher()
+			// report progress in RestoreSession status while restore is running
+			progressOpt.Namespace = namespace
+			progressReporter := newProgressReporter(progressOpt)
+			if progressReporter != nil {
+				restoreOpt.OnProgress = progressReporter.ReportRestoreProgress
+			}
+			// Run restore along with the hooks
+			restoreOutput, restoreErr := runRestoreWithHooks(progressOpt, func() (*restic.RestoreOutput, error) {
+				return resticWrapper.RunRestore(restoreOpt)
+			})
+			if progressReporter != nil {
+				progressReporter.Stop()
+			}
 
 			// run separate shell to restore indices
 			esShell := sh.NewSession()
@@ -115,6 +131,7 @@ func NewCmdRestoreES() *cobra.Command {
 	cmd.Flags().StringVar(&masterURL, "master", masterURL, "The address of the Kubernetes API server (overrides any value in kubeconfig)")
 	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", kubeconfigPath, "Path to kubeconfig file with authorization information (the master location is set by the master flag).")
 	cmd.Flags().StringVar(&namespace, "namespace", "default", "Namespace of Backup/Restore Session")
+	cmd.Flags().StringVar(&progressOpt.RestoreSession, "restore-session", progressOpt.RestoreSession, "Name of the RestoreSession to report progress and execute hooks (keep empty if you don't need them)")
 	cmd.Flags().StringVar(&appBindingName, "app-binding", appBindingName, "Name of the app binding")
 
 	cmd.Flags().StringVar(&setupOpt.Provider, "provider", setupOpt.Provider, "Backend provider (i.e. gcs, s3, azure etc)")
@@ -12,6 +12,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	appcatalog_cs "kmodules.xyz/custom-resources/client/clientset/versioned"
 	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/status"
 	"stash.appscode.dev/stash/pkg/util"
 )
 
@@ -20,6 +21,7 @@ func NewCmdRestoreMongo() *cobra.Command {
 		masterURL      string
 		kubeconfigPath string
 		namespace      string
+		progressOpt    status.UpdateStatusOptions
 		appBindingName string
 		outputDir      string
 		mongoArgs      string
@@ -84,6 +86,9 @@ func NewCmdRestoreMongo() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			// interrupt restic if the job is deleted on cancellation of the session
+			stopCancelWatcher := cancelOnTermination(resticWrapper)
+			defer stopCancelWatcher()
 			// hide password, don't print cmd
 			resticWrapper.HideCMD()
 
@@ -105,8 +110,19 @@ func NewCmdRestoreMongo() *cobra.Command {
 			// wait for DB ready
 			waitForDBReady(appBinding.Spec.ClientConfig.Service.Name, appBinding.Spec.ClientConfig.Service.Port)
 
-			// Run dump
-			dumpOutput, backupErr := resticWrapper.Dump(dumpOpt)
+			// report progress in RestoreSession status while restore is running
+			progressOpt.Namespace = namespace
+			progressReporter := newProgressReporter(progressOpt)
+			if progressReporter != nil {
+				dumpOpt.OnProgress = progressReporter.ReportRestoreProgress
+			}
+			// Run dump along with the hooks
+			dumpOutput, backupErr := runRestoreWithHooks(progressOpt, func() (*restic.RestoreOutput, error) {
+				return resticWrapper.Dump(dumpOpt)
+			})
+			if progressReporter != nil {
+				progressReporter.Stop()
+			}
 			// If metrics are enabled then generate metrics
 			if metrics.Enabled {
 				err := dumpOutput.HandleMetrics(&metrics, backupErr)
@@ -130,6 +146,7 @@ func NewCmdRestoreMongo() *cobra.Command {
 	cmd.Flags().StringVar(&masterURL, "master", masterURL, "The address of the Kubernetes API server (overrides any value in kubeconfig)")
 	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", kubeconfigPath, "Path to kubeconfig file with authorization information (the master location is set by the master flag).")
 	cmd.Flags().StringVar(&namespace, "namespace", "default", "Namespace of Backup/Restore Session")
+	cmd.Flags().StringVar(&progressOpt.RestoreSession, "restore-session", progressOpt.RestoreSession, "Name of the RestoreSession to report progress and execute hooks (keep empty if you don't need them)")
 	cmd.Flags().StringVar(&appBindingName, "app-binding", appBindingName, "Name of the app binding")
 
 	cmd.Flags().StringVar(&setupOpt.Provider, "provider", setupOpt.Provider, "Backend provider (i.e. gcs, s3, azure etc)")
@@ -2,11 +2,13 @@ package cmds
 
 import (
 	"path/filepath"
+	"time"
 
 	"github.com/appscode/go/flags"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/util/errors"
 	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/status"
 	"stash.appscode.dev/stash/pkg/util"
 )
 
@@ -27,6 +29,9 @@ func NewCmdRestorePVC() *cobra.Command {
 		metrics = restic.MetricsOptions{
 			JobName: JobPVCRestore,
 		}
+		progressOpt    status.UpdateStatusOptions
+		snapshotTags   []string
+		snapshotBefore string
 	)
 
 	cmd := &cobra.Command{
@@ -36,6 +41,20 @@ func NewCmdRestorePVC() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags.EnsureRequiredFlags(cmd, "restore-dirs", "provider", "secret-dir")
 
+			// select the snapshots by tags or time if specified
+			if len(snapshotTags) > 0 || snapshotBefore != "" {
+				restoreOpt.SnapshotFilter = &restic.SnapshotFilter{
+					Tags: snapshotTags,
+				}
+				if snapshotBefore != "" {
+					before, err := time.Parse(time.RFC3339, snapshotBefore)
+					if err != nil {
+						return util.HandleResticError(outputDir, restic.DefaultOutputFileName, err)
+					}
+					restoreOpt.SnapshotFilter.Before = &before
+				}
+			}
+
 			// apply nice, ionice settings from env
 			var err error
 			setupOpt.Nice, err = util.NiceSettingsFromEnv()
@@ -52,8 +71,21 @@ func NewCmdRestorePVC() *cobra.Command {
 			if err != nil {
 				return util.HandleResticError(outputDir, restic.DefaultOutputFileName, err)
 			}
-			// Run restore
-			restoreOutput, restoreErr := resticWrapper.RunRestore(restoreOpt)
+			// interrupt restic if the job is deleted on cancellation of the session
+			stopCancelWatcher := cancelOnTermination(resticWrapper)
+			defer stopCancelWatcher()
+			// report progress in RestoreSession status while restore is running
+			progressReporter := newProgressReporter(progressOpt)
+			if progressReporter != nil {
+				restoreOpt.OnProgress = progressReporter.ReportRestoreProgress
+			}
+			// Run restore along with the hooks
+			restoreOutput, restoreErr := runRestoreWithHooks(progressOpt, func() (*restic.RestoreOutput, error) {
+				return resticWrapper.RunRestore(restoreOpt)
+			})
+			if progressReporter != nil {
+				progressReporter.Stop()
+			}
 			// If metrics are enabled then generate metrics
 			if metrics.Enabled {
 				err := restoreOutput.HandleMetrics(&metrics, restoreErr)
@@ -85,6 +117,15 @@ func NewCmdRestorePVC() *cobra.Command {
 	cmd.Flags().StringVar(&restoreOpt.Host, "hostname", restoreOpt.Host, "Name of the host machine")
 	cmd.Flags().StringSliceVar(&restoreOpt.RestoreDirs, "restore-dirs", restoreOpt.RestoreDirs, "List of directories to be restored")
 	cmd.Flags().StringSliceVar(&restoreOpt.Snapshots, "snapshots", restoreOpt.Snapshots, "List of snapshots to be restored")
+	cmd.Flags().StringSliceVar(&snapshotTags, "snapshot-tags", snapshotTags, "Restore the latest snapshots having all of these tags")
+	cmd.Flags().StringVar(&snapshotBefore, "snapshot-before", snapshotBefore, "Restore the latest snapshots taken at or before this time (RFC3339 format)")
+	cmd.Flags().Var(newPatternsValue(&restoreOpt.Include), "include", "Patterns of the files to restore")
+	cmd.Flags().Var(newPatternsValue(&restoreOpt.Exclude), "exclude", "Patterns of the files not to restore")
+	cmd.Flags().StringVar(&restoreOpt.Destination, "destination", restoreOpt.Destination, "Directory where the data will be restored (keep empty to restore into the original path)")
+	cmd.Flags().StringVar((*string)(&restoreOpt.ConflictPolicy), "conflict-policy", string(restoreOpt.ConflictPolicy), "How to handle the existing files (Overwrite, SkipExisting, CleanTargetFirst)")
+
+	cmd.Flags().StringVar(&progressOpt.Namespace, "namespace", progressOpt.Namespace, "Namespace of the RestoreSession")
+	cmd.Flags().StringVar(&progressOpt.RestoreSession, "restore-session", progressOpt.RestoreSession, "Name of the RestoreSession to report progress and execute hooks (keep empty if you don't need them)")
 
 	cmd.Flags().StringVar(&outputDir, "output-dir", outputDir, "Directory where output.json file will be written (keep empty if you don't need to write output in file)")
 
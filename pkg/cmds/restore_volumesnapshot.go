@@ -18,6 +18,7 @@ import (
 	"kmodules.xyz/client-go/meta"
 	"stash.appscode.dev/stash/apis/stash/v1beta1"
 	cs "stash.appscode.dev/stash/client/clientset/versioned"
+	"stash.appscode.dev/stash/pkg/hooks"
 	"stash.appscode.dev/stash/pkg/resolve"
 	"stash.appscode.dev/stash/pkg/restic"
 	"stash.appscode.dev/stash/pkg/status"
@@ -80,6 +81,17 @@ func (opt *VSoption) RestoreVolumeSnapshot() error {
 		return fmt.Errorf("restoreSession Target is nil")
 	}
 
+	// no workload is running for the target in this job. so, exec hooks are executed inside this container.
+	hookExecutor := &hooks.HookExecutor{}
+	restoreHooks := restoreSession.Spec.Hooks
+	if restoreHooks == nil {
+		restoreHooks = &v1beta1.RestoreHooks{}
+	}
+	// execute preRestore hook. abort restore if the hook fails and its failure policy does not allow to continue.
+	if err = hookExecutor.Execute(v1beta1.PreRestoreHook, restoreHooks.PreRestore); err != nil {
+		return err
+	}
+
 	pvcData := []PVC{}
 
 	if restoreSession.Spec.Target.Replicas == nil {
@@ -124,7 +136,7 @@ func (opt *VSoption) RestoreVolumeSnapshot() error {
 					Error:    fmt.Sprintf("%s already exixts", pvc.Name),
 				},
 			}
-			err := opt.updateRestoreSessionStatus(restoreOutput, startTime)
+			err := opt.updateRestoreSessionStatus(restoreOutput, hookExecutor.Stats, startTime)
 			if err != nil {
 				return err
 			}
@@ -148,7 +160,7 @@ func (opt *VSoption) RestoreVolumeSnapshot() error {
 					Error:    fmt.Sprintf("VolumeBindingMode is 'WaitForFirstConsumer'. Stash is unable to decide wheather the restore has succeeded or not as the PVC will not bind with respective PV until any workload mount it."),
 				},
 			}
-			err := opt.updateRestoreSessionStatus(restoreOutput, startTime)
+			err := opt.updateRestoreSessionStatus(restoreOutput, hookExecutor.Stats, startTime)
 			if err != nil {
 				return err
 			}
@@ -165,12 +177,13 @@ func (opt *VSoption) RestoreVolumeSnapshot() error {
 				Phase:    v1beta1.HostRestoreSucceeded,
 			},
 		}
-		err = opt.updateRestoreSessionStatus(restoreOutput, startTime)
+		err = opt.updateRestoreSessionStatus(restoreOutput, hookExecutor.Stats, startTime)
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+	// execute postRestore hook
+	return hookExecutor.Execute(v1beta1.PostRestoreHook, restoreHooks.PostRestore)
 }
 
 func (opt *VSoption) getPVCDefinition(data PVC) *corev1.PersistentVolumeClaim {
@@ -189,7 +202,7 @@ func (opt *VSoption) getPVCDefinition(data PVC) *corev1.PersistentVolumeClaim {
 	return &data.pvc
 }
 
-func (opt *VSoption) updateRestoreSessionStatus(restoreOutput restic.RestoreOutput, startTime time.Time) error {
+func (opt *VSoption) updateRestoreSessionStatus(restoreOutput restic.RestoreOutput, hookStats []v1beta1.HookStats, startTime time.Time) error {
 	// Update Backup Session
 	o := status.UpdateStatusOptions{
 		KubeClient:     opt.kubeClient,
@@ -200,5 +213,6 @@ func (opt *VSoption) updateRestoreSessionStatus(restoreOutput restic.RestoreOutp
 	// Volume Snapshot complete. Read current time and calculate total backup duration.
 	endTime := time.Now()
 	restoreOutput.HostRestoreStats.Duration = endTime.Sub(startTime).String()
+	restoreOutput.HostRestoreStats.Hooks = hookStats
 	return o.UpdatePostRestoreStatus(&restoreOutput)
 }
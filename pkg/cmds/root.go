@@ -74,6 +74,8 @@ func NewRootCmd() *cobra.Command {
 	rootCmd.AddCommand(NewCmdRestoreES())
 
 	rootCmd.AddCommand(NewCmdUpdateStatus())
+	rootCmd.AddCommand(NewCmdMaintainRepository())
+	rootCmd.AddCommand(NewCmdRotatePassword())
 
 	rootCmd.AddCommand(stash_cli.NewCLICmd())
 	rootCmd.AddCommand(docker.NewDockerCmd())
@@ -0,0 +1,278 @@
+package cmds
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/appscode/go/flags"
+	"github.com/appscode/go/log"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"kmodules.xyz/client-go/meta"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	cs "stash.appscode.dev/stash/client/clientset/versioned"
+	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/status"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+const DefaultOldKeyRemovalDelay = 2 * time.Minute
+
+type passwordRotationOptions struct {
+	newPasswordKey     string
+	oldKeyRemovalDelay time.Duration
+	extraOpt           util.ExtraOptions
+	status.UpdateStatusOptions
+}
+
+func NewCmdRotatePassword() *cobra.Command {
+	var (
+		masterURL      string
+		kubeconfigPath string
+		opt            = passwordRotationOptions{
+			newPasswordKey:     api_v1alpha1.DefaultNewPasswordKey,
+			oldKeyRemovalDelay: DefaultOldKeyRemovalDelay,
+			extraOpt: util.ExtraOptions{
+				SecretDir:  util.StashSecretMountDir,
+				ScratchDir: util.TmpDirMountPath,
+			},
+			UpdateStatusOptions: status.UpdateStatusOptions{
+				Namespace: meta.Namespace(),
+			},
+		}
+	)
+
+	cmd := &cobra.Command{
+		Use:               "rotate-password",
+		Short:             "Rotate the password of a restic repository",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.EnsureRequiredFlags(cmd, "repository")
+
+			config, err := clientcmd.BuildConfigFromFlags(masterURL, kubeconfigPath)
+			if err != nil {
+				return err
+			}
+			opt.KubeClient, err = kubernetes.NewForConfig(config)
+			if err != nil {
+				return err
+			}
+			opt.StashClient, err = cs.NewForConfig(config)
+			if err != nil {
+				return err
+			}
+
+			err = opt.rotatePassword()
+			if err != nil {
+				serr := opt.UpdateRepositoryPasswordRotationStatus(func(in *api_v1alpha1.PasswordRotationStatus) {
+					now := metav1.Now()
+					in.Phase = api_v1alpha1.PasswordRotationFailed
+					in.CompletionTime = &now
+					in.Error = err.Error()
+				})
+				if serr != nil {
+					log.Errorf("Failed to update status of Repository %s/%s. Reason: %v", opt.Namespace, opt.Repository, serr)
+				}
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&masterURL, "master", masterURL, "The address of the Kubernetes API server (overrides any value in kubeconfig)")
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", kubeconfigPath, "Path to kubeconfig file with authorization information (the master location is set by the master flag).")
+
+	cmd.Flags().StringVar(&opt.Namespace, "namespace", opt.Namespace, "Namespace of the Repository")
+	cmd.Flags().StringVar(&opt.Repository, "repository", opt.Repository, "Name of the Repository")
+	cmd.Flags().StringVar(&opt.newPasswordKey, "new-password-key", opt.newPasswordKey, "Key of the storage secret that holds the new password")
+	cmd.Flags().DurationVar(&opt.oldKeyRemovalDelay, "old-key-removal-delay", opt.oldKeyRemovalDelay, "Time to wait after updating the storage secret before removing the old key")
+	cmd.Flags().StringVar(&opt.extraOpt.SecretDir, "secret-dir", opt.extraOpt.SecretDir, "Directory where storage secret has been mounted")
+	cmd.Flags().StringVar(&opt.extraOpt.ScratchDir, "scratch-dir", opt.extraOpt.ScratchDir, "Temporary directory")
+
+	return cmd
+}
+
+// rotatePassword adds a key for the new password, verifies it, replaces the password in the storage secret
+// and finally removes the old key. Each completed step is recorded in the Repository status. If a previous
+// rotation has been interrupted, it is resumed from the last completed step.
+func (opt *passwordRotationOptions) rotatePassword() error {
+	repository, err := opt.StashClient.StashV1alpha1().Repositories(opt.Namespace).Get(opt.Repository, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	var prev api_v1alpha1.PasswordRotationStatus
+	if api_v1alpha1.PasswordRotationIncomplete(repository.Status.PasswordRotation) {
+		prev = *repository.Status.PasswordRotation
+		log.Infof("Resuming password rotation from step %q", prev.Step)
+	}
+	err = opt.UpdateRepositoryPasswordRotationStatus(func(in *api_v1alpha1.PasswordRotationStatus) {
+		now := metav1.Now()
+		if prev.Step == "" {
+			*in = api_v1alpha1.PasswordRotationStatus{
+				Phase:     api_v1alpha1.PasswordRotationRunning,
+				StartTime: &now,
+			}
+			return
+		}
+		in.Phase = api_v1alpha1.PasswordRotationRunning
+		in.CompletionTime = nil
+		in.Error = ""
+	})
+	if err != nil {
+		return err
+	}
+
+	setupOpt, err := util.SetupOptionsForRepository(*repository, opt.extraOpt)
+	if err != nil {
+		return err
+	}
+	newPasswordFile := filepath.Join(opt.extraOpt.SecretDir, opt.newPasswordKey)
+	newPassword, err := ioutil.ReadFile(newPasswordFile)
+	// the new password might have been moved already if the rotation was interrupted right after updating the secret
+	newPasswordFound := err == nil
+	if err != nil && !(os.IsNotExist(err) && prev.Step != "") {
+		return fmt.Errorf("failed to read new password. Reason: %v", err)
+	}
+	newKeyID, oldKeyID := prev.NewKeyID, prev.OldKeyID
+	// the mounted storage secret holds the old password until it is refreshed. so, the updated password is used explicitly.
+	var updatedPassword []byte
+
+	// the new password is removed from the storage secret once it has been updated
+	if prev.Step != api_v1alpha1.PasswordRotationSecretUpdated && newPasswordFound {
+		if len(newPassword) == 0 {
+			return fmt.Errorf("new password is empty")
+		}
+		oldWrapper, err := restic.NewResticWrapper(setupOpt)
+		if err != nil {
+			return err
+		}
+		if oldKeyID == "" {
+			keys, err := oldWrapper.ListKeys()
+			if err != nil {
+				return err
+			}
+			for _, key := range keys {
+				if key.Current {
+					oldKeyID = key.ID
+				}
+			}
+			if oldKeyID == "" {
+				return fmt.Errorf("failed to find the key of the current password")
+			}
+		}
+		if newKeyID == "" {
+			// add a key for the new password
+			newKeyID, err = oldWrapper.AddKey(newPasswordFile)
+			if err != nil {
+				return err
+			}
+			if err = opt.setRotationStep(api_v1alpha1.PasswordRotationKeyAdded, newKeyID, oldKeyID); err != nil {
+				return err
+			}
+		}
+
+		// verify that the new password opens the repository with the new key
+		newWrapper, err := restic.NewResticWrapper(setupOpt)
+		if err != nil {
+			return err
+		}
+		newWrapper.SetEnv(restic.RESTIC_PASSWORD, string(newPassword))
+		if err = verifyCurrentKey(newWrapper, newKeyID); err != nil {
+			return err
+		}
+		if err = opt.setRotationStep(api_v1alpha1.PasswordRotationKeyVerified, newKeyID, oldKeyID); err != nil {
+			return err
+		}
+
+		// replace the password in the storage secret. the update fails if the secret has been modified meanwhile.
+		secret, err := opt.KubeClient.CoreV1().Secrets(repository.Namespace).Get(repository.Spec.Backend.StorageSecretName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if string(secret.Data[opt.newPasswordKey]) != string(newPassword) {
+			return fmt.Errorf("new password in Secret %s/%s has been changed during rotation", secret.Namespace, secret.Name)
+		}
+		secret.Data[restic.RESTIC_PASSWORD] = newPassword
+		delete(secret.Data, opt.newPasswordKey)
+		if _, err = opt.KubeClient.CoreV1().Secrets(secret.Namespace).Update(secret); err != nil {
+			return err
+		}
+		updatedPassword = newPassword
+	}
+	if err = opt.setRotationStep(api_v1alpha1.PasswordRotationSecretUpdated, newKeyID, oldKeyID); err != nil {
+		return err
+	}
+
+	// the storage secret holds the new password now. so, it must open the repository with the new key.
+	wrapper, err := restic.NewResticWrapper(setupOpt)
+	if err != nil {
+		return err
+	}
+	if updatedPassword != nil {
+		wrapper.SetEnv(restic.RESTIC_PASSWORD, string(updatedPassword))
+	}
+	if err = verifyCurrentKey(wrapper, newKeyID); err != nil {
+		return err
+	}
+
+	// give the running backups time to pick up the new password before removing the old key
+	log.Infof("Waiting %s before removing the old key", opt.oldKeyRemovalDelay)
+	time.Sleep(opt.oldKeyRemovalDelay)
+	keys, err := wrapper.ListKeys()
+	if err != nil {
+		return err
+	}
+	if oldKeyID == "" {
+		log.Warningf("Key of the old password is unknown. Remove it manually from the repository.")
+	}
+	for _, key := range keys {
+		// the old key might have been removed before the rotation was interrupted
+		if key.ID == oldKeyID {
+			if err = wrapper.RemoveKey(oldKeyID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return opt.UpdateRepositoryPasswordRotationStatus(func(in *api_v1alpha1.PasswordRotationStatus) {
+		now := metav1.Now()
+		in.Phase = api_v1alpha1.PasswordRotationSucceeded
+		in.Step = api_v1alpha1.PasswordRotationOldKeyRemoved
+		in.CompletionTime = &now
+		in.Error = ""
+	})
+}
+
+// verifyCurrentKey ensures that the wrapper opens the repository with the key
+func verifyCurrentKey(wrapper *restic.ResticWrapper, keyID string) error {
+	keys, err := wrapper.ListKeys()
+	if err != nil {
+		return fmt.Errorf("failed to open the repository with the new password. Reason: %v", err)
+	}
+	return checkCurrentKey(keys, keyID)
+}
+
+// checkCurrentKey returns an error if the key is not the current key of the list
+func checkCurrentKey(keys []restic.Key, keyID string) error {
+	for _, key := range keys {
+		if key.Current {
+			if key.ID != keyID {
+				return fmt.Errorf("the new password opens the repository with key %s instead of the new key %s", key.ID, keyID)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to find the key used to open the repository")
+}
+
+func (opt *passwordRotationOptions) setRotationStep(step api_v1alpha1.PasswordRotationStep, newKeyID, oldKeyID string) error {
+	return opt.UpdateRepositoryPasswordRotationStatus(func(in *api_v1alpha1.PasswordRotationStatus) {
+		in.Step = step
+		in.NewKeyID = newKeyID
+		in.OldKeyID = oldKeyID
+	})
+}
@@ -0,0 +1,27 @@
+package cmds
+
+import (
+	"testing"
+
+	"stash.appscode.dev/stash/pkg/restic"
+)
+
+func TestCheckCurrentKey(t *testing.T) {
+	testCases := []struct {
+		name    string
+		keys    []restic.Key
+		keyID   string
+		invalid bool
+	}{
+		{name: "new key is current", keys: []restic.Key{{ID: "old"}, {ID: "new", Current: true}}, keyID: "new"},
+		{name: "old key is current", keys: []restic.Key{{ID: "old", Current: true}, {ID: "new"}}, keyID: "new", invalid: true},
+		{name: "no current key", keys: []restic.Key{{ID: "old"}, {ID: "new"}}, keyID: "new", invalid: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := checkCurrentKey(tc.keys, tc.keyID); (err != nil) != tc.invalid {
+				t.Errorf("expected invalid: %v, got error: %v", tc.invalid, err)
+			}
+		})
+	}
+}
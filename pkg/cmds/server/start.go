@@ -87,6 +87,7 @@ func (o StashOptions) Config() (*server.StashConfig, error) {
 		"/apis/admission.stash.appscode.com/v1alpha1/replicasetmutators",
 		"/apis/admission.stash.appscode.com/v1alpha1/deploymentconfigmutators",
 		"/apis/admission.stash.appscode.com/v1beta1/restoresessionvalidators",
+		"/apis/admission.stash.appscode.com/v1beta1/backupconfigurationvalidators",
 	}
 
 	extraConfig := controller.NewConfig(serverConfig.ClientConfig)
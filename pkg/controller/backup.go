@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/appscode/go/log"
 	core "k8s.io/api/core/v1"
 	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -121,10 +122,20 @@ func (c *StashController) applyBackupConfigurationLogic(w *wapi.Workload, caller
 	if newbc != nil && !util.BackupConfigurationEqual(oldbc, newbc) {
 		err := c.ensureBackupSidecar(w, newbc, caller)
 		if err != nil {
+			if caller == util.CallerController {
+				if serr := c.setSidecarInjectedCondition(newbc, err); serr != nil {
+					log.Errorf("failed to update status of BackupConfiguration %s/%s. Reason: %v", newbc.Namespace, newbc.Name, serr)
+				}
+			}
 			return false, err
 		}
 		return true, nil
 
+	} else if newbc != nil && caller == util.CallerController {
+		// sidecar has already been applied to the workload
+		if err := c.setSidecarInjectedCondition(newbc, nil); err != nil {
+			return false, err
+		}
 	} else if oldbc != nil && newbc == nil {
 		// there was BackupConfiguration before but it does not exist now.
 		// this means BackupConfiguration has been removed.
@@ -9,22 +9,51 @@ import (
 	core "k8s.io/api/core/v1"
 	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/reference"
 	batch_util "kmodules.xyz/client-go/batch/v1beta1"
 	core_util "kmodules.xyz/client-go/core/v1"
 	"kmodules.xyz/client-go/tools/queue"
+	"kmodules.xyz/webhook-runtime/admission"
+	hooks "kmodules.xyz/webhook-runtime/admission/v1beta1"
+	webhook "kmodules.xyz/webhook-runtime/admission/v1beta1/generic"
 	workload_api "kmodules.xyz/webhook-runtime/apis/workload/v1"
 	"stash.appscode.dev/stash/apis"
+	"stash.appscode.dev/stash/apis/stash"
 	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
 	stash_scheme "stash.appscode.dev/stash/client/clientset/versioned/scheme"
 	v1beta1_util "stash.appscode.dev/stash/client/clientset/versioned/typed/stash/v1beta1/util"
 	"stash.appscode.dev/stash/pkg/docker"
+	"stash.appscode.dev/stash/pkg/eventer"
 	"stash.appscode.dev/stash/pkg/util"
 )
 
 // TODO: Add validator that will reject to create BackupConfiguration if any Restic exist for target workload
 
+func (c *StashController) NewBackupConfigurationWebhook() hooks.AdmissionHook {
+	return webhook.NewGenericWebhook(
+		schema.GroupVersionResource{
+			Group:    "admission.stash.appscode.com",
+			Version:  "v1beta1",
+			Resource: "backupconfigurationvalidators",
+		},
+		"backupconfigurationvalidator",
+		[]string{stash.GroupName},
+		api_v1beta1.SchemeGroupVersion.WithKind(api_v1beta1.ResourceKindBackupConfiguration),
+		nil,
+		&admission.ResourceHandlerFuncs{
+			CreateFunc: func(obj runtime.Object) (runtime.Object, error) {
+				return nil, obj.(*api_v1beta1.BackupConfiguration).IsValid()
+			},
+			UpdateFunc: func(oldObj, newObj runtime.Object) (runtime.Object, error) {
+				return nil, newObj.(*api_v1beta1.BackupConfiguration).IsValid()
+			},
+		},
+	)
+}
+
 func (c *StashController) initBackupConfigurationWatcher() {
 	c.bcInformer = c.stashInformerFactory.Stash().V1beta1().BackupConfigurations().Informer()
 	c.bcQueue = queue.New(api_v1beta1.ResourceKindBackupConfiguration, c.MaxNumRequeues, c.NumThreads, c.runBackupConfigurationProcessor)
@@ -76,21 +105,36 @@ func (c *StashController) runBackupConfigurationProcessor(key string) error {
 				return err
 			}
 
+			// the validating webhook may not be enabled. so, don't setup backup for an invalid BackupConfiguration.
+			if err := backupConfiguration.IsValid(); err != nil {
+				_, e2 := eventer.CreateEvent(
+					c.kubeClient,
+					eventer.EventSourceBackupConfigurationController,
+					backupConfiguration,
+					core.EventTypeWarning,
+					eventer.EventReasonInvalidBackupConfiguration,
+					err.Error(),
+				)
+				return e2
+			}
+
 			// skip if BackupConfiguration paused
 			if backupConfiguration.Spec.Paused {
 				log.Infof("Skipping processing BackupConfiguration %s/%s. Reason: Backup Configuration is paused.", backupConfiguration.Namespace, backupConfiguration.Name)
-				return nil
+				return c.syncBackupConfigurationStatus(backupConfiguration, nil)
 			}
 
-			if backupConfiguration.Spec.Target != nil &&
-				backupConfiguration.Spec.Driver != api_v1beta1.VolumeSnapshotter &&
-				util.BackupModel(backupConfiguration.Spec.Target.Ref.Kind) == util.ModelSidecar {
+			if requiresSidecar(backupConfiguration) {
 				if err := c.EnsureV1beta1Sidecar(backupConfiguration); err != nil {
 					return err
 				}
 			}
 			// create a CronJob that will create BackupSession on each schedule
-			return c.EnsureCronJob(backupConfiguration)
+			cronErr := c.EnsureCronJob(backupConfiguration)
+			if err := c.syncBackupConfigurationStatus(backupConfiguration, cronErr); err != nil {
+				return err
+			}
+			return cronErr
 		}
 	}
 	return nil
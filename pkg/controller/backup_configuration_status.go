@@ -0,0 +1,251 @@
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/appscode/go/log"
+	cron "gopkg.in/robfig/cron.v2"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"stash.appscode.dev/stash/apis"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	v1beta1_util "stash.appscode.dev/stash/client/clientset/versioned/typed/stash/v1beta1/util"
+	"stash.appscode.dev/stash/pkg/eventer"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+const (
+	ConditionReasonPaused              = "Paused"
+	ConditionReasonCronJobNotCreated   = "CronJobNotCreated"
+	ConditionReasonSidecarNotInjected  = "SidecarNotInjected"
+	ConditionReasonBackupConfigured    = "BackupConfigured"
+	ConditionReasonCronJobEnsured      = "CronJobEnsured"
+	ConditionReasonFailedToEnsure      = "FailedToEnsure"
+	ConditionReasonWaitingForInjection = "WaitingForInjection"
+	ConditionReasonSidecarEnsured      = "SidecarEnsured"
+	ConditionReasonRPOExceeded         = "RPOExceeded"
+	ConditionReasonWithinRPO           = "WithinRPO"
+)
+
+// updateBackupConfigurationStatus applies the transformation to the status of a BackupConfiguration and
+// re-evaluates its "Ready" condition. The status is written only if it has been changed because any
+// update of the BackupConfiguration requeues it. It returns the previous and the new status.
+func (c *StashController) updateBackupConfigurationStatus(
+	backupConfig *api_v1beta1.BackupConfiguration,
+	transform func(in *api_v1beta1.BackupConfigurationStatus),
+) (*api_v1beta1.BackupConfigurationStatus, *api_v1beta1.BackupConfigurationStatus, error) {
+	apply := func(in *api_v1beta1.BackupConfigurationStatus) *api_v1beta1.BackupConfigurationStatus {
+		transform(in)
+		setBackupConfigurationReadyCondition(backupConfig, in)
+		return in
+	}
+
+	oldStatus := backupConfig.Status.DeepCopy()
+	newStatus := apply(backupConfig.Status.DeepCopy())
+	if reflect.DeepEqual(oldStatus, newStatus) {
+		return oldStatus, newStatus, nil
+	}
+	out, err := v1beta1_util.UpdateBackupConfigurationStatus(c.stashClient.StashV1beta1(), backupConfig, apply, apis.EnableStatusSubresource)
+	if err != nil {
+		return oldStatus, nil, err
+	}
+	return oldStatus, &out.Status, nil
+}
+
+// setBackupConfigurationReadyCondition sets "Ready" condition to "True" only if everything that is
+// necessary to take backup on schedule has been setup.
+func setBackupConfigurationReadyCondition(backupConfig *api_v1beta1.BackupConfiguration, status *api_v1beta1.BackupConfigurationStatus) {
+	ready := api_v1beta1.BackupConfigurationCondition{
+		Type:    api_v1beta1.BackupConfigurationReady,
+		Status:  core.ConditionFalse,
+		Reason:  ConditionReasonPaused,
+		Message: "BackupConfiguration has been paused",
+	}
+	switch {
+	case backupConfig.Spec.Paused:
+	case !status.IsConditionTrue(api_v1beta1.CronJobCreated):
+		ready.Reason = ConditionReasonCronJobNotCreated
+		ready.Message = "CronJob to trigger BackupSessions has not been created yet"
+	case requiresSidecar(backupConfig) && !status.IsConditionTrue(api_v1beta1.SidecarInjected):
+		ready.Reason = ConditionReasonSidecarNotInjected
+		ready.Message = "backup sidecar has not been injected into the target yet"
+	default:
+		ready.Status = core.ConditionTrue
+		ready.Reason = ConditionReasonBackupConfigured
+		ready.Message = "backup has been configured successfully"
+	}
+	status.SetCondition(ready)
+}
+
+func requiresSidecar(backupConfig *api_v1beta1.BackupConfiguration) bool {
+	return backupConfig.Spec.Target != nil &&
+		backupConfig.Spec.Driver != api_v1beta1.VolumeSnapshotter &&
+		util.BackupModel(backupConfig.Spec.Target.Ref.Kind) == util.ModelSidecar
+}
+
+// syncBackupConfigurationStatus updates the conditions, the next schedule time and the staleness of the backup
+// of a BackupConfiguration. setupErr is the error occurred while ensuring the CronJob, if any. It requeues the
+// BackupConfiguration so that the next schedule time and the staleness get re-evaluated on time.
+func (c *StashController) syncBackupConfigurationStatus(backupConfig *api_v1beta1.BackupConfiguration, setupErr error) error {
+	now := time.Now()
+	var nextSchedule *metav1.Time
+	if !backupConfig.Spec.Paused {
+		if sched, err := cron.Parse(backupConfig.Spec.Schedule); err == nil {
+			nextSchedule = &metav1.Time{Time: sched.Next(now)}
+		}
+	}
+	staleCond, staleDeadline := evaluateBackupStaleness(backupConfig, now)
+
+	oldStatus, newStatus, err := c.updateBackupConfigurationStatus(backupConfig, func(in *api_v1beta1.BackupConfigurationStatus) {
+		in.NextScheduleTime = nextSchedule
+		if staleCond != nil {
+			in.SetCondition(*staleCond)
+		} else {
+			in.RemoveCondition(api_v1beta1.BackupStale)
+		}
+
+		if !backupConfig.Spec.Paused {
+			if setupErr != nil {
+				in.SetCondition(api_v1beta1.BackupConfigurationCondition{
+					Type:    api_v1beta1.CronJobCreated,
+					Status:  core.ConditionFalse,
+					Reason:  ConditionReasonFailedToEnsure,
+					Message: setupErr.Error(),
+				})
+			} else {
+				in.SetCondition(api_v1beta1.BackupConfigurationCondition{
+					Type:    api_v1beta1.CronJobCreated,
+					Status:  core.ConditionTrue,
+					Reason:  ConditionReasonCronJobEnsured,
+					Message: "CronJob to trigger BackupSessions has been created",
+				})
+			}
+		}
+
+		if !requiresSidecar(backupConfig) {
+			in.RemoveCondition(api_v1beta1.SidecarInjected)
+		} else if in.GetCondition(api_v1beta1.SidecarInjected) == nil {
+			// the workload controller will set the condition once the sidecar has been injected
+			in.SetCondition(api_v1beta1.BackupConfigurationCondition{
+				Type:    api_v1beta1.SidecarInjected,
+				Status:  core.ConditionFalse,
+				Reason:  ConditionReasonWaitingForInjection,
+				Message: "waiting for the backup sidecar to be injected into the target",
+			})
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	// write an event when the backup becomes stale
+	if !oldStatus.IsConditionTrue(api_v1beta1.BackupStale) && newStatus.IsConditionTrue(api_v1beta1.BackupStale) {
+		cond := newStatus.GetCondition(api_v1beta1.BackupStale)
+		c.notifyBackupStale(backupConfig, cond.Message)
+		_, err = eventer.CreateEvent(
+			c.kubeClient,
+			eventer.EventSourceBackupConfigurationController,
+			backupConfig,
+			core.EventTypeWarning,
+			eventer.EventReasonBackupStale,
+			cond.Message,
+		)
+		if err != nil {
+			log.Errorf("failed to write event for BackupConfiguration %s/%s. Reason: %v", backupConfig.Namespace, backupConfig.Name, err)
+		}
+	}
+
+	// requeue to refresh the next schedule time and to detect the staleness once the RPO passes.
+	// the queue keeps only the earliest one if the key is added multiple times.
+	key, err := cache.MetaNamespaceKeyFunc(backupConfig)
+	if err != nil {
+		return err
+	}
+	if nextSchedule != nil {
+		c.bcQueue.GetQueue().AddAfter(key, nextSchedule.Sub(now))
+	}
+	if staleDeadline != nil {
+		c.bcQueue.GetQueue().AddAfter(key, staleDeadline.Sub(now))
+	}
+	return nil
+}
+
+// evaluateBackupStaleness returns "BackupStale" condition of a BackupConfiguration and the time when the backup
+// will become stale if it is not stale yet. It returns nil condition if no RPO has been specified.
+func evaluateBackupStaleness(backupConfig *api_v1beta1.BackupConfiguration, now time.Time) (*api_v1beta1.BackupConfigurationCondition, *time.Time) {
+	if backupConfig.Spec.RPO == nil {
+		return nil, nil
+	}
+	rpo := backupConfig.Spec.RPO.Duration
+
+	// if no backup has succeeded yet, measure the age from the creation of the BackupConfiguration
+	since := backupConfig.CreationTimestamp.Time
+	msg := fmt.Sprintf("no backup has succeeded since the BackupConfiguration was created at %s", since.Format(time.RFC3339))
+	if last := backupConfig.Status.LastSuccessfulSession; last != nil && last.CompletionTime != nil {
+		since = last.CompletionTime.Time
+		msg = fmt.Sprintf("newest successful backup was taken by BackupSession %s at %s", last.Name, since.Format(time.RFC3339))
+	}
+
+	deadline := since.Add(rpo)
+	if now.After(deadline) {
+		return &api_v1beta1.BackupConfigurationCondition{
+			Type:    api_v1beta1.BackupStale,
+			Status:  core.ConditionTrue,
+			Reason:  ConditionReasonRPOExceeded,
+			Message: fmt.Sprintf("%s which exceeds the RPO of %s", msg, rpo),
+		}, nil
+	}
+	return &api_v1beta1.BackupConfigurationCondition{
+		Type:    api_v1beta1.BackupStale,
+		Status:  core.ConditionFalse,
+		Reason:  ConditionReasonWithinRPO,
+		Message: fmt.Sprintf("%s which is within the RPO of %s", msg, rpo),
+	}, &deadline
+}
+
+// setSidecarInjectedCondition records whether the backup sidecar has been injected into the target workload
+func (c *StashController) setSidecarInjectedCondition(backupConfig *api_v1beta1.BackupConfiguration, injectionErr error) error {
+	cond := api_v1beta1.BackupConfigurationCondition{
+		Type:    api_v1beta1.SidecarInjected,
+		Status:  core.ConditionTrue,
+		Reason:  ConditionReasonSidecarEnsured,
+		Message: "backup sidecar has been injected into the target",
+	}
+	if injectionErr != nil {
+		cond.Status = core.ConditionFalse
+		cond.Reason = ConditionReasonFailedToEnsure
+		cond.Message = injectionErr.Error()
+	}
+	_, _, err := c.updateBackupConfigurationStatus(backupConfig, func(in *api_v1beta1.BackupConfigurationStatus) {
+		in.SetCondition(cond)
+	})
+	return err
+}
+
+// setBackupConfigurationLastSession records a completed BackupSession as the last successful or the last failed
+// session of the respective BackupConfiguration along with the time when the BackupSession has completed.
+func (c *StashController) setBackupConfigurationLastSession(backupSession *api_v1beta1.BackupSession, succeeded bool) error {
+	backupConfig, err := c.bcLister.BackupConfigurations(backupSession.Namespace).Get(backupSession.Spec.BackupConfiguration.Name)
+	if err != nil {
+		if kerr.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	ref := &api_v1beta1.BackupSessionReference{
+		Name:           backupSession.Name,
+		CompletionTime: backupSession.Status.CompletionTime,
+	}
+	_, _, err = c.updateBackupConfigurationStatus(backupConfig, func(in *api_v1beta1.BackupConfigurationStatus) {
+		if succeeded {
+			in.LastSuccessfulSession = ref
+		} else {
+			in.LastFailedSession = ref
+		}
+	})
+	return err
+}
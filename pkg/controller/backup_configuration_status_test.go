@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+)
+
+func TestEvaluateBackupStaleness(t *testing.T) {
+	created := time.Date(2019, 10, 1, 10, 0, 0, 0, time.UTC)
+	hour := metav1.Duration{Duration: time.Hour}
+	completedAt := metav1.NewTime(created.Add(2 * time.Hour))
+
+	testCases := []struct {
+		name          string
+		rpo           *metav1.Duration
+		lastSuccess   *api_v1beta1.BackupSessionReference
+		now           time.Time
+		status        core.ConditionStatus
+		reason        string
+		staleDeadline *time.Time
+	}{
+		{name: "no rpo", now: created.Add(10 * time.Hour)},
+		{
+			name:          "no backup yet within rpo",
+			rpo:           &hour,
+			now:           created.Add(30 * time.Minute),
+			status:        core.ConditionFalse,
+			reason:        ConditionReasonWithinRPO,
+			staleDeadline: timePtr(created.Add(time.Hour)),
+		},
+		{
+			name:   "no backup yet exceeding rpo",
+			rpo:    &hour,
+			now:    created.Add(2 * time.Hour),
+			status: core.ConditionTrue,
+			reason: ConditionReasonRPOExceeded,
+		},
+		{
+			name:          "recent backup within rpo",
+			rpo:           &hour,
+			lastSuccess:   &api_v1beta1.BackupSessionReference{Name: "session-1", CompletionTime: &completedAt},
+			now:           created.Add(150 * time.Minute),
+			status:        core.ConditionFalse,
+			reason:        ConditionReasonWithinRPO,
+			staleDeadline: timePtr(completedAt.Add(time.Hour)),
+		},
+		{
+			name:        "old backup exceeding rpo",
+			rpo:         &hour,
+			lastSuccess: &api_v1beta1.BackupSessionReference{Name: "session-1", CompletionTime: &completedAt},
+			now:         created.Add(4 * time.Hour),
+			status:      core.ConditionTrue,
+			reason:      ConditionReasonRPOExceeded,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bc := &api_v1beta1.BackupConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample", CreationTimestamp: metav1.NewTime(created)},
+				Spec:       api_v1beta1.BackupConfigurationSpec{RPO: tc.rpo},
+				Status:     api_v1beta1.BackupConfigurationStatus{LastSuccessfulSession: tc.lastSuccess},
+			}
+			cond, deadline := evaluateBackupStaleness(bc, tc.now)
+			if tc.rpo == nil {
+				assert.Nil(t, cond)
+				assert.Nil(t, deadline)
+				return
+			}
+			if assert.NotNil(t, cond) {
+				assert.Equal(t, api_v1beta1.BackupStale, cond.Type)
+				assert.Equal(t, tc.status, cond.Status)
+				assert.Equal(t, tc.reason, cond.Reason)
+			}
+			assert.Equal(t, tc.staleDeadline, deadline)
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -2,15 +2,19 @@ package controller
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/appscode/go/log"
 	"github.com/golang/glog"
 	batchv1 "k8s.io/api/batch/v1"
 	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/client-go/tools/reference"
 	batch_util "kmodules.xyz/client-go/batch/v1"
@@ -36,6 +40,10 @@ import (
 const (
 	BackupJobPrefix      = "stash-backup-"
 	VolumeSnapshotPrefix = "volume-snapshot-"
+
+	// replacedBackupSessionCheckInterval is the interval at which a new BackupSession that replaces the running
+	// BackupSessions is re-queued until the cancelled BackupSessions have stopped.
+	replacedBackupSessionCheckInterval = 5 * time.Second
 )
 
 func (c *StashController) NewBackupSessionWebhook() hooks.AdmissionHook {
@@ -54,8 +62,15 @@ func (c *StashController) NewBackupSessionWebhook() hooks.AdmissionHook {
 				return nil, obj.(*api_v1beta1.BackupSession).IsValid()
 			},
 			UpdateFunc: func(oldObj, newObj runtime.Object) (runtime.Object, error) {
-				// should not allow spec update
-				if !meta.Equal(oldObj.(*api_v1beta1.BackupSession).Spec, newObj.(*api_v1beta1.BackupSession).Spec) {
+				oldSpec := oldObj.(*api_v1beta1.BackupSession).Spec
+				newSpec := newObj.(*api_v1beta1.BackupSession).Spec
+				// cancellation can be requested but can't be withdrawn
+				if oldSpec.Cancel && !newSpec.Cancel {
+					return nil, fmt.Errorf("BackupSession cancellation can't be withdrawn")
+				}
+				// should not allow spec update except the cancellation request
+				oldSpec.Cancel = newSpec.Cancel
+				if !meta.Equal(oldSpec, newSpec) {
 					return nil, fmt.Errorf("BackupSession spec is immutable")
 				}
 				return nil, nil
@@ -87,11 +102,17 @@ func (c *StashController) runBackupSessionProcessor(key string) error {
 	glog.Infof("Sync/Add/Update for BackupSession %s", backupSession.GetName())
 
 	if backupSession.Status.Phase == api_v1beta1.BackupSessionFailed ||
-		backupSession.Status.Phase == api_v1beta1.BackupSessionSucceeded {
+		backupSession.Status.Phase == api_v1beta1.BackupSessionSucceeded ||
+		backupSession.Status.Phase == api_v1beta1.BackupSessionCancelled {
 		log.Infof("Skipping processing BackupSession %s/%s. Reason: phase is %q.", backupSession.Namespace, backupSession.Name, backupSession.Status.Phase)
 		return nil
 	}
 
+	// stop the backup process if cancellation has been requested
+	if backupSession.Spec.Cancel && backupSession.Status.Phase != api_v1beta1.BackupSessionSkipped {
+		return c.cancelBackupSession(backupSession)
+	}
+
 	// check whether backup session is completed or running and set it's phase accordingly
 	phase, err := c.getBackupSessionPhase(backupSession)
 
@@ -101,7 +122,7 @@ func (c *StashController) runBackupSessionProcessor(key string) error {
 		return c.setBackupSessionSucceeded(backupSession)
 	} else if phase == api_v1beta1.BackupSessionRunning {
 		log.Infof("Skipping processing BackupSession %s/%s. Reason: phase is %q.", backupSession.Namespace, backupSession.Name, backupSession.Status.Phase)
-		return nil
+		return c.checkBackupSessionDeadline(backupSession)
 	} else if phase == api_v1beta1.BackupSessionSkipped {
 		log.Infof("Skipping processing BackupSession %s/%s. Reason: previously skipped.", backupSession.Namespace, backupSession.Name)
 		return nil
@@ -123,6 +144,11 @@ func (c *StashController) runBackupSessionProcessor(key string) error {
 		return c.setBackupSessionSkipped(backupSession, "Backup Configuration is paused")
 	}
 
+	// apply concurrency policy if any previous BackupSession of this BackupConfiguration is still running
+	if start, err := c.applyConcurrencyPolicy(backupConfig, backupSession); err != nil || !start {
+		return err
+	}
+
 	if backupConfig.Spec.Target != nil && backupConfig.Spec.Driver == api_v1beta1.VolumeSnapshotter {
 		err := c.setBackupSessionRunning(backupSession)
 		if err != nil {
@@ -219,6 +245,7 @@ func (c *StashController) ensureBackupJob(backupSession *api_v1beta1.BackupSessi
 	implicitInputs := core_util.UpsertMap(repoInputs, bcInputs)
 	implicitInputs[apis.Namespace] = backupSession.Namespace
 	implicitInputs[apis.BackupSession] = backupSession.Name
+	implicitInputs[apis.SnapshotTags] = strings.Join(util.SnapshotTags(backupSession.Name, *backupConfig), ",")
 	implicitInputs[apis.StatusSubresourceEnabled] = fmt.Sprint(apis.EnableStatusSubresource)
 
 	taskResolver := resolve.TaskResolver{
@@ -246,6 +273,7 @@ func (c *StashController) ensureBackupJob(backupSession *api_v1beta1.BackupSessi
 		}
 		// backup job is created by resolving task and function. we should not delete it when it goes to completed state.
 		// user might need to know what was the final resolved job specification for debugging purpose.
+		// it will be deleted along with the BackupSession when the BackupSession exceeds the history limit.
 		in.Labels[apis.KeyDeleteJobOnCompletion] = "false"
 
 		in.Spec.Template.Spec = podSpec
@@ -260,13 +288,19 @@ func (c *StashController) ensureBackupJob(backupSession *api_v1beta1.BackupSessi
 func (c *StashController) setBackupSessionFailed(backupSession *api_v1beta1.BackupSession, jobErr error) error {
 
 	// set BackupSession phase to "Failed"
-	_, err := stash_util.UpdateBackupSessionStatus(c.stashClient.StashV1beta1(), backupSession, func(in *api_v1beta1.BackupSessionStatus) *api_v1beta1.BackupSessionStatus {
+	updatedSession, err := stash_util.UpdateBackupSessionStatus(c.stashClient.StashV1beta1(), backupSession, func(in *api_v1beta1.BackupSessionStatus) *api_v1beta1.BackupSessionStatus {
 		in.Phase = api_v1beta1.BackupSessionFailed
+		setBackupSessionCompletionTime(in)
 		return in
 	}, apis.EnableStatusSubresource)
 	if err != nil {
 		return err
 	}
+	// notify as soon as the phase has been updated so that a failure in the following steps does not suppress it
+	c.notifyBackupSession(backupSession, api_v1beta1.NotificationBackupSessionFailed, jobErr.Error())
+	if err = c.setBackupConfigurationLastSession(updatedSession, false); err != nil {
+		return err
+	}
 
 	// write failure event
 	_, err = eventer.CreateEvent(
@@ -290,6 +324,7 @@ func (c *StashController) setBackupSessionSkipped(backupSession *api_v1beta1.Bac
 	if err != nil {
 		return err
 	}
+	c.notifyBackupSession(backupSession, api_v1beta1.NotificationBackupSessionSkipped, reason)
 
 	// write skip event
 	_, err = eventer.CreateEvent(
@@ -338,8 +373,12 @@ func (c *StashController) setBackupSessionRunning(backupSession *api_v1beta1.Bac
 		eventer.EventReasonBackupSessionJobCreated,
 		fmt.Sprintf("backup job has been created succesfully for BackupSession %s/%s", backupSession.Namespace, backupSession.Name),
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	// schedule a check for the active deadline
+	return c.checkBackupSessionDeadline(backupSession)
 }
 
 func (c *StashController) setBackupSessionSucceeded(backupSession *api_v1beta1.BackupSession) error {
@@ -355,14 +394,20 @@ func (c *StashController) setBackupSessionSucceeded(backupSession *api_v1beta1.B
 	}
 
 	// update BackupSession status
-	_, err := stash_util.UpdateBackupSessionStatus(c.stashClient.StashV1beta1(), backupSession, func(in *api_v1beta1.BackupSessionStatus) *api_v1beta1.BackupSessionStatus {
+	updatedSession, err := stash_util.UpdateBackupSessionStatus(c.stashClient.StashV1beta1(), backupSession, func(in *api_v1beta1.BackupSessionStatus) *api_v1beta1.BackupSessionStatus {
 		in.Phase = api_v1beta1.BackupSessionSucceeded
 		in.SessionDuration = sessionDuration.String()
+		setBackupSessionCompletionTime(in)
 		return in
 	}, apis.EnableStatusSubresource)
 	if err != nil {
 		return err
 	}
+	message := fmt.Sprintf("backup has been completed succesfully for BackupSession %s/%s", backupSession.Namespace, backupSession.Name)
+	c.notifyBackupSession(backupSession, api_v1beta1.NotificationBackupSessionSucceeded, message)
+	if err = c.setBackupConfigurationLastSession(updatedSession, true); err != nil {
+		return err
+	}
 
 	// write event for successful backup
 	_, err = eventer.CreateEvent(
@@ -371,12 +416,150 @@ func (c *StashController) setBackupSessionSucceeded(backupSession *api_v1beta1.B
 		backupSession,
 		core.EventTypeNormal,
 		eventer.EventReasonSuccessfulBackup,
-		fmt.Sprintf("backup has been completed succesfully for BackupSession %s/%s", backupSession.Namespace, backupSession.Name),
+		message,
+	)
+
+	return err
+}
+
+// setBackupSessionCompletionTime records the time when a BackupSession has completed.
+// The time is kept unchanged if the status is updated again later.
+func setBackupSessionCompletionTime(status *api_v1beta1.BackupSessionStatus) {
+	if status.CompletionTime == nil {
+		now := metav1.Now()
+		status.CompletionTime = &now
+	}
+}
+
+// cancelBackupSession stops the backup process of a BackupSession whose cancellation has been requested.
+// For the job model, the backup job is deleted so that its pod gets terminated. The sidecar interrupts
+// the running backup by itself and records the stats of the backed up directories.
+func (c *StashController) cancelBackupSession(backupSession *api_v1beta1.BackupSession) error {
+	if err := c.deleteBackupSessionJobs(backupSession); err != nil {
+		return err
+	}
+
+	// set BackupSession phase to "Cancelled"
+	_, err := stash_util.UpdateBackupSessionStatus(c.stashClient.StashV1beta1(), backupSession, func(in *api_v1beta1.BackupSessionStatus) *api_v1beta1.BackupSessionStatus {
+		in.Phase = api_v1beta1.BackupSessionCancelled
+		return in
+	}, apis.EnableStatusSubresource)
+	if err != nil {
+		return err
+	}
+	message := fmt.Sprintf("backup has been cancelled for BackupSession %s/%s", backupSession.Namespace, backupSession.Name)
+	c.notifyBackupSession(backupSession, api_v1beta1.NotificationBackupSessionCancelled, message)
+
+	// write cancellation event
+	_, err = eventer.CreateEvent(
+		c.kubeClient,
+		eventer.EventSourceBackupSessionController,
+		backupSession,
+		core.EventTypeWarning,
+		eventer.EventReasonBackupSessionCancelled,
+		message,
 	)
 
 	return err
 }
 
+// deleteBackupSessionJobs deletes the jobs that are running backup for a BackupSession
+func (c *StashController) deleteBackupSessionJobs(backupSession *api_v1beta1.BackupSession) error {
+	for _, jobName := range []string{BackupJobPrefix + backupSession.Name, VolumeSnapshotPrefix + backupSession.Name} {
+		err := c.kubeClient.BatchV1().Jobs(backupSession.Namespace).Delete(jobName, meta.DeleteInBackground())
+		if err != nil && !kerr.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyConcurrencyPolicy applies the concurrency policy of the BackupConfiguration on a new BackupSession.
+// It returns false if the new BackupSession should not be started now.
+func (c *StashController) applyConcurrencyPolicy(backupConfig *api_v1beta1.BackupConfiguration, backupSession *api_v1beta1.BackupSession) (bool, error) {
+	if backupConfig.Spec.ConcurrencyPolicy == "" || backupConfig.Spec.ConcurrencyPolicy == api_v1beta1.AllowConcurrent {
+		return true, nil
+	}
+
+	backupSessions, err := c.backupSessionLister.BackupSessions(backupSession.Namespace).List(labels.SelectorFromSet(map[string]string{
+		util.LabelBackupConfiguration: backupConfig.Name,
+	}))
+	if err != nil {
+		return false, err
+	}
+	var running []*api_v1beta1.BackupSession
+	for _, bs := range backupSessions {
+		if bs.Name != backupSession.Name && bs.Status.Phase == api_v1beta1.BackupSessionRunning {
+			running = append(running, bs)
+		}
+	}
+	if len(running) == 0 {
+		return true, nil
+	}
+
+	switch backupConfig.Spec.ConcurrencyPolicy {
+	case api_v1beta1.ForbidConcurrent:
+		log.Infof("Skipping processing BackupSession %s/%s. Reason: previous BackupSession %s is still running.", backupSession.Namespace, backupSession.Name, running[0].Name)
+		return false, c.setBackupSessionSkipped(backupSession, fmt.Sprintf("previous BackupSession %s is still running", running[0].Name))
+	case api_v1beta1.ReplaceConcurrent:
+		// request cancellation of the running BackupSessions. they will be stopped when they are processed.
+		for _, bs := range running {
+			if bs.Spec.Cancel {
+				continue
+			}
+			log.Infof("Cancelling BackupSession %s/%s. Reason: it has been replaced by BackupSession %s.", bs.Namespace, bs.Name, backupSession.Name)
+			_, _, err := stash_util.PatchBackupSession(c.stashClient.StashV1beta1(), bs, func(in *api_v1beta1.BackupSession) *api_v1beta1.BackupSession {
+				in.Spec.Cancel = true
+				return in
+			})
+			if err != nil {
+				return false, err
+			}
+		}
+		// start the new BackupSession only after the cancelled BackupSessions have reached a terminal phase.
+		// otherwise, both of them may access the repository at the same time.
+		log.Infof("Waiting for the replaced BackupSessions to stop before starting BackupSession %s/%s.", backupSession.Namespace, backupSession.Name)
+		key, err := cache.MetaNamespaceKeyFunc(backupSession)
+		if err != nil {
+			return false, err
+		}
+		c.backupSessionQueue.GetQueue().AddAfter(key, replacedBackupSessionCheckInterval)
+		return false, nil
+	}
+	return true, nil
+}
+
+// checkBackupSessionDeadline fails a running BackupSession that has exceeded the active deadline of its BackupConfiguration.
+// If the deadline hasn't been exceeded yet, the BackupSession is re-queued to be checked again at the deadline.
+func (c *StashController) checkBackupSessionDeadline(backupSession *api_v1beta1.BackupSession) error {
+	backupConfig, err := c.bcLister.BackupConfigurations(backupSession.Namespace).Get(backupSession.Spec.BackupConfiguration.Name)
+	if err != nil {
+		if kerr.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if backupConfig.Spec.ActiveDeadlineSeconds == nil {
+		return nil
+	}
+
+	timeout := time.Duration(*backupConfig.Spec.ActiveDeadlineSeconds) * time.Second
+	if remaining := time.Until(backupSession.CreationTimestamp.Add(timeout)); remaining > 0 {
+		key, err := cache.MetaNamespaceKeyFunc(backupSession)
+		if err != nil {
+			return err
+		}
+		c.backupSessionQueue.GetQueue().AddAfter(key, remaining)
+		return nil
+	}
+
+	// stop the running backup. the sidecar stops by itself once it finds the BackupSession has failed.
+	if err := c.deleteBackupSessionJobs(backupSession); err != nil {
+		return err
+	}
+	return c.setBackupSessionFailed(backupSession, fmt.Errorf("BackupSession has exceeded the active deadline of %s", timeout))
+}
+
 func (c *StashController) getBackupSessionPhase(backupSession *api_v1beta1.BackupSession) (api_v1beta1.BackupSessionPhase, error) {
 	// BackupSession phase is empty or "Pending" then return it. controller will process accordingly
 	if backupSession.Status.TotalHosts == nil ||
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"sort"
+	"time"
+
+	"github.com/appscode/go/log"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"kmodules.xyz/client-go/meta"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+// BackupSessionGCInterval is the interval at which the old BackupSessions are garbage collected
+const BackupSessionGCInterval = 5 * time.Minute
+
+// garbageCollectBackupSessions deletes the BackupSessions that exceed the history limits of their BackupConfiguration
+func (c *StashController) garbageCollectBackupSessions() {
+	backupConfigs, err := c.bcLister.List(labels.Everything())
+	if err != nil {
+		log.Errorf("failed to list BackupConfigurations. Reason: %v", err)
+		return
+	}
+	for _, backupConfig := range backupConfigs {
+		if err := c.cleanupBackupSessionHistory(backupConfig); err != nil {
+			log.Errorf("failed to cleanup BackupSessions of BackupConfiguration %s/%s. Reason: %v", backupConfig.Namespace, backupConfig.Name, err)
+		}
+	}
+}
+
+// cleanupBackupSessionHistory keeps only the most recent completed BackupSessions of a BackupConfiguration as specified
+// by its history limits. Each phase has its own limit and the BackupSessions of a phase without limit are never deleted.
+// Running BackupSessions are never deleted and the most recent failed BackupSession is always kept for debugging.
+func (c *StashController) cleanupBackupSessionHistory(backupConfig *api_v1beta1.BackupConfiguration) error {
+	limits := map[api_v1beta1.BackupSessionPhase]*int32{
+		api_v1beta1.BackupSessionSucceeded: backupConfig.Spec.SuccessfulSessionsHistoryLimit,
+		api_v1beta1.BackupSessionFailed:    backupConfig.Spec.FailedSessionsHistoryLimit,
+		api_v1beta1.BackupSessionSkipped:   backupConfig.Spec.SkippedSessionsHistoryLimit,
+		api_v1beta1.BackupSessionCancelled: backupConfig.Spec.CancelledSessionsHistoryLimit,
+	}
+	if limits[api_v1beta1.BackupSessionSucceeded] == nil && limits[api_v1beta1.BackupSessionFailed] == nil &&
+		limits[api_v1beta1.BackupSessionSkipped] == nil && limits[api_v1beta1.BackupSessionCancelled] == nil {
+		return nil
+	}
+
+	backupSessions, err := c.backupSessionLister.BackupSessions(backupConfig.Namespace).List(labels.SelectorFromSet(map[string]string{
+		util.LabelBackupConfiguration: backupConfig.Name,
+	}))
+	if err != nil {
+		return err
+	}
+
+	sessions := make(map[api_v1beta1.BackupSessionPhase][]*api_v1beta1.BackupSession)
+	for _, bs := range backupSessions {
+		sessions[bs.Status.Phase] = append(sessions[bs.Status.Phase], bs)
+	}
+	for _, phase := range []api_v1beta1.BackupSessionPhase{
+		api_v1beta1.BackupSessionSucceeded,
+		api_v1beta1.BackupSessionFailed,
+		api_v1beta1.BackupSessionSkipped,
+		api_v1beta1.BackupSessionCancelled,
+	} {
+		if limits[phase] == nil {
+			continue
+		}
+		if err := c.deleteOldBackupSessions(sessions[phase], *limits[phase], phase == api_v1beta1.BackupSessionFailed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteOldBackupSessions deletes the BackupSessions that exceed the limit along with their Jobs
+func (c *StashController) deleteOldBackupSessions(backupSessions []*api_v1beta1.BackupSession, limit int32, keepLastFailure bool) error {
+	for _, bs := range backupSessionsExceedingLimit(backupSessions, limit, keepLastFailure) {
+		log.Infof("Deleting BackupSession %s/%s. Reason: it exceeds the history limit.", bs.Namespace, bs.Name)
+		// delete the backup Jobs first. their Pods will be removed by the garbage collector.
+		if err := c.deleteBackupSessionJobs(bs); err != nil {
+			return err
+		}
+		err := c.stashClient.StashV1beta1().BackupSessions(bs.Namespace).Delete(bs.Name, meta.DeleteInBackground())
+		if err != nil && !kerr.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// backupSessionsExceedingLimit returns all but the newest "limit" BackupSessions of the list.
+// If keepLastFailure is true, the newest BackupSession in "Failed" phase is kept even if the limit is 0.
+func backupSessionsExceedingLimit(backupSessions []*api_v1beta1.BackupSession, limit int32, keepLastFailure bool) []*api_v1beta1.BackupSession {
+	if limit < 0 {
+		limit = 0
+	}
+	if int32(len(backupSessions)) <= limit {
+		return nil
+	}
+
+	// sort from the newest to the oldest
+	sorted := append([]*api_v1beta1.BackupSession(nil), backupSessions...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[j].CreationTimestamp.Before(&sorted[i].CreationTimestamp)
+	})
+
+	var exceeding []*api_v1beta1.BackupSession
+	lastFailureKept := false
+	for i, bs := range sorted {
+		isFailure := bs.Status.Phase == api_v1beta1.BackupSessionFailed
+		if int32(i) < limit || (keepLastFailure && isFailure && !lastFailureKept) {
+			lastFailureKept = lastFailureKept || isFailure
+			continue
+		}
+		exceeding = append(exceeding, bs)
+	}
+	return exceeding
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+)
+
+func TestBackupSessionsExceedingLimit(t *testing.T) {
+	now := time.Now()
+	// newSessions returns BackupSessions with the given phases from the oldest to the newest
+	newSessions := func(phases ...api_v1beta1.BackupSessionPhase) []*api_v1beta1.BackupSession {
+		var sessions []*api_v1beta1.BackupSession
+		for i, phase := range phases {
+			sessions = append(sessions, &api_v1beta1.BackupSession{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              string(rune('a' + i)),
+					CreationTimestamp: metav1.NewTime(now.Add(time.Duration(i) * time.Minute)),
+				},
+				Status: api_v1beta1.BackupSessionStatus{Phase: phase},
+			})
+		}
+		return sessions
+	}
+	succeeded := api_v1beta1.BackupSessionSucceeded
+	failed := api_v1beta1.BackupSessionFailed
+
+	testCases := []struct {
+		name            string
+		sessions        []*api_v1beta1.BackupSession
+		limit           int32
+		keepLastFailure bool
+		deleted         []string
+	}{
+		{name: "within limit", sessions: newSessions(succeeded, succeeded), limit: 2},
+		{name: "oldest are deleted", sessions: newSessions(succeeded, succeeded, succeeded, succeeded), limit: 2, deleted: []string{"b", "a"}},
+		{name: "negative limit", sessions: newSessions(succeeded, succeeded), limit: -1, deleted: []string{"b", "a"}},
+		{name: "last failure kept", sessions: newSessions(failed, failed, failed), limit: 0, keepLastFailure: true, deleted: []string{"b", "a"}},
+		{name: "last failure not kept", sessions: newSessions(failed, failed), limit: 0, deleted: []string{"b", "a"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var deleted []string
+			for _, bs := range backupSessionsExceedingLimit(tc.sessions, tc.limit, tc.keepLastFailure) {
+				deleted = append(deleted, bs.Name)
+			}
+			assert.Equal(t, tc.deleted, deleted)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"kmodules.xyz/client-go/tools/queue"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	"stash.appscode.dev/stash/client/clientset/versioned/fake"
+	stash_listers_v1beta1 "stash.appscode.dev/stash/client/listers/stash/v1beta1"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+func TestApplyConcurrencyPolicyReplace(t *testing.T) {
+	backupConfig := &api_v1beta1.BackupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "demo"},
+		Spec:       api_v1beta1.BackupConfigurationSpec{ConcurrencyPolicy: api_v1beta1.ReplaceConcurrent},
+	}
+	newSession := func(name string, phase api_v1beta1.BackupSessionPhase) *api_v1beta1.BackupSession {
+		return &api_v1beta1.BackupSession{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "demo",
+				Labels:    map[string]string{util.LabelBackupConfiguration: backupConfig.Name},
+			},
+			Status: api_v1beta1.BackupSessionStatus{Phase: phase},
+		}
+	}
+	// the running BackupSession has already been cancelled by the new one
+	cancelled := newSession("app-1", api_v1beta1.BackupSessionRunning)
+	cancelled.Spec.Cancel = true
+	current := newSession("app-2", "")
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, indexer.Add(cancelled))
+	assert.NoError(t, indexer.Add(current))
+	c := &StashController{
+		stashClient:         fake.NewSimpleClientset(),
+		backupSessionLister: stash_listers_v1beta1.NewBackupSessionLister(indexer),
+		backupSessionQueue:  queue.New(api_v1beta1.ResourceKindBackupSession, 5, 1, nil),
+	}
+	defer c.backupSessionQueue.GetQueue().ShutDown()
+
+	// the new BackupSession keeps waiting while the cancelled one is still running
+	start, err := c.applyConcurrencyPolicy(backupConfig, current)
+	assert.NoError(t, err)
+	assert.False(t, start)
+
+	// the new BackupSession is started once the cancelled one has stopped
+	stopped := cancelled.DeepCopy()
+	stopped.Status.Phase = api_v1beta1.BackupSessionCancelled
+	assert.NoError(t, indexer.Update(stopped))
+	start, err = c.applyConcurrencyPolicy(backupConfig, current)
+	assert.NoError(t, err)
+	assert.True(t, start)
+}
+
+func TestSetBackupSessionCompletionTime(t *testing.T) {
+	status := &api_v1beta1.BackupSessionStatus{}
+	setBackupSessionCompletionTime(status)
+	if !assert.NotNil(t, status.CompletionTime) {
+		return
+	}
+
+	// the completion time is not moved when the status is updated again
+	completedAt := metav1.NewTime(status.CompletionTime.Add(-time.Hour))
+	status.CompletionTime = &completedAt
+	setBackupSessionCompletionTime(status)
+	assert.Equal(t, &completedAt, status.CompletionTime)
+}
@@ -96,6 +96,9 @@ func (c *Config) New() (*StashController, error) {
 		return nil, err
 	}
 
+	// queues created by the watchers must report their metrics
+	enableWorkqueueMetrics()
+
 	ctrl.initNamespaceWatcher()
 
 	// init workload watchers
@@ -114,11 +117,15 @@ func (c *Config) New() (*StashController, error) {
 	ctrl.initResticWatcher()
 	ctrl.initRecoveryWatcher()
 	ctrl.initRepositoryWatcher()
+	ctrl.initStorageSecretWatcher()
 
 	// init v1beta1 resources watcher
 	ctrl.initBackupConfigurationWatcher()
 	ctrl.initBackupSessionWatcher()
 	ctrl.initRestoreSessionWatcher()
+	ctrl.initNotifier()
+
+	ctrl.registerStatusCollector()
 
 	return ctrl, nil
 }
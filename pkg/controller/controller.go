@@ -8,6 +8,7 @@ import (
 	crd_api "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	crd_cs "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	apps_listers "k8s.io/client-go/listers/apps/v1"
@@ -32,6 +33,7 @@ import (
 	stashinformers "stash.appscode.dev/stash/client/informers/externalversions"
 	stash_listers "stash.appscode.dev/stash/client/listers/stash/v1alpha1"
 	stash_listers_v1beta1 "stash.appscode.dev/stash/client/listers/stash/v1beta1"
+	"stash.appscode.dev/stash/pkg/notifier"
 )
 
 type StashController struct {
@@ -68,6 +70,9 @@ type StashController struct {
 	repoInformer cache.SharedIndexInformer
 	repoLister   stash_listers.RepositoryLister
 
+	// storage Secrets are watched to start password rotation
+	secretInformer cache.SharedIndexInformer
+
 	// Deployment
 	dpQueue    *queue.Worker
 	dpInformer cache.SharedIndexInformer
@@ -122,6 +127,10 @@ type StashController struct {
 	restoreSessionInformer cache.SharedIndexInformer
 	restoreSessionLister   stash_listers_v1beta1.RestoreSessionLister
 
+	// Notifier
+	notifierLister         stash_listers_v1beta1.NotifierLister
+	notificationDispatcher *notifier.Dispatcher
+
 	// Openshift DeploymentConfiguration
 	dcQueue    *queue.Worker
 	dcInformer cache.SharedIndexInformer
@@ -141,6 +150,7 @@ func (c *StashController) ensureCustomResourceDefinitions() error {
 		api_v1beta1.BackupConfiguration{}.CustomResourceDefinition(),
 		api_v1beta1.BackupSession{}.CustomResourceDefinition(),
 		api_v1beta1.RestoreSession{}.CustomResourceDefinition(),
+		api_v1beta1.Notifier{}.CustomResourceDefinition(),
 
 		appCatalog.AppBinding{}.CustomResourceDefinition(),
 	}
@@ -233,6 +243,12 @@ func (c *StashController) RunInformers(stopCh <-chan struct{}) {
 	c.backupSessionQueue.Run(stopCh)
 	c.restoreSessionQueue.Run(stopCh)
 
+	// deliver the notifications of backup and restore outcomes
+	go c.notificationDispatcher.Run(notifier.DefaultWorkers, stopCh)
+
+	// periodically remove the BackupSessions that exceed the history limits
+	go wait.Until(c.garbageCollectBackupSessions, BackupSessionGCInterval, stopCh)
+
 	<-stopCh
 	log.Infoln("Stopping Stash controller")
 }
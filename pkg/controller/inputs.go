@@ -1,8 +1,10 @@
 package controller
 
 import (
+	"encoding/json"
 	"strconv"
 	"strings"
+	"time"
 
 	core_util "kmodules.xyz/client-go/core/v1"
 	"stash.appscode.dev/stash/apis"
@@ -14,8 +16,6 @@ import (
 func (c *StashController) inputsForBackupConfig(backupConfig api.BackupConfiguration) (map[string]string, error) {
 	// get inputs for target
 	inputs := c.inputsForBackupTarget(backupConfig.Spec.Target)
-	// append inputs for RetentionPolicy
-	inputs = core_util.UpsertMap(inputs, c.inputsForRetentionPolicy(backupConfig.Spec.RetentionPolicy))
 
 	// get host name for target
 	host, err := util.GetHostName(backupConfig.Spec.Target)
@@ -24,6 +24,9 @@ func (c *StashController) inputsForBackupConfig(backupConfig api.BackupConfigura
 	}
 	inputs[apis.Hostname] = host
 
+	// append inputs for RetentionPolicy
+	inputs = core_util.UpsertMap(inputs, c.inputsForRetentionPolicy(util.RetentionPolicyForBackupConfig(backupConfig, host)))
+
 	// always enable cache if nothing specified
 	inputs[apis.EnableCache] = strconv.FormatBool(!backupConfig.Spec.TempDir.DisableCaching)
 
@@ -44,6 +47,16 @@ func (c *StashController) inputsForRestoreSession(restoreSession api.RestoreSess
 	inputs[apis.Hostname] = restoreOptions.SourceHost
 	inputs[apis.RestoreDirectories] = strings.Join(restoreOptions.RestoreDirs, ",")
 	inputs[apis.RestoreSnapshots] = strings.Join(restoreOptions.Snapshots, ",")
+	inputs[apis.RestoreInclude] = patternsInput(restoreOptions.Include)
+	inputs[apis.RestoreExclude] = patternsInput(restoreOptions.Exclude)
+	inputs[apis.RestoreDestination] = restoreOptions.Destination
+	inputs[apis.RestoreConflictPolicy] = string(restoreOptions.ConflictPolicy)
+	if restoreOptions.SnapshotFilter != nil {
+		inputs[apis.RestoreSnapshotTags] = strings.Join(restoreOptions.SnapshotFilter.Tags, ",")
+		if restoreOptions.SnapshotFilter.Before != nil {
+			inputs[apis.RestoreSnapshotBefore] = restoreOptions.SnapshotFilter.Before.Format(time.RFC3339)
+		}
+	}
 
 	// always enable cache if nothing specified
 	inputs[apis.EnableCache] = strconv.FormatBool(!restoreSession.Spec.TempDir.DisableCaching)
@@ -75,6 +88,7 @@ func (c *StashController) inputsForRepository(repository *apiAlpha.Repository) (
 		inputs[apis.RepositoryURL] = repository.Spec.Backend.Rest.URL
 	}
 	inputs[apis.MaxConnections] = strconv.Itoa(util.GetMaxConnections(repository.Spec.Backend))
+	inputs[apis.SkipIntegrityCheck] = strconv.FormatBool(util.SkipCheckAfterBackup(*repository))
 	return
 }
 
@@ -87,6 +101,20 @@ func (c *StashController) inputsForBackupTarget(target *api.BackupTarget) map[st
 		if len(target.Directories) > 0 {
 			inputs[apis.TargetDirectories] = strings.Join(target.Directories, ",")
 		}
+		if len(target.Exclude) > 0 {
+			inputs[apis.TargetExclude] = patternsInput(target.Exclude)
+		}
+		if len(target.Include) > 0 {
+			inputs[apis.TargetInclude] = patternsInput(target.Include)
+		}
+		if len(target.ExcludeIfPresent) > 0 {
+			inputs[apis.TargetExcludeIfPresent] = patternsInput(target.ExcludeIfPresent)
+		}
+		if target.IgnoreFileName != "" {
+			inputs[apis.TargetIgnoreFileName] = target.IgnoreFileName
+		}
+		inputs[apis.TargetExcludeCaches] = strconv.FormatBool(target.ExcludeCaches)
+		inputs[apis.TargetOneFileSystem] = strconv.FormatBool(target.OneFileSystem)
 		if target.VolumeMounts != nil {
 			inputs[apis.TargetMountPath] = target.VolumeMounts[0].MountPath
 		}
@@ -131,11 +159,43 @@ func (c *StashController) inputsForRetentionPolicy(retentionPolicy apiAlpha.Rete
 	if len(retentionPolicy.KeepTags) > 0 {
 		inputs[apis.RetentionKeepTags] = strings.Join(retentionPolicy.KeepTags, ",")
 	}
+	if retentionPolicy.KeepWithin != "" {
+		inputs[apis.RetentionKeepWithin] = retentionPolicy.KeepWithin
+	}
 	if retentionPolicy.Prune {
 		inputs[apis.RetentionPrune] = "true"
 	}
 	if retentionPolicy.DryRun {
 		inputs[apis.RetentionDryRun] = "true"
 	}
+	if retentionPolicy.Filter != nil {
+		if len(retentionPolicy.Filter.GroupBy) > 0 {
+			var groupBy []string
+			for _, g := range retentionPolicy.Filter.GroupBy {
+				groupBy = append(groupBy, string(g))
+			}
+			inputs[apis.RetentionGroupBy] = strings.Join(groupBy, ",")
+		}
+		if retentionPolicy.Filter.Host != "" {
+			inputs[apis.RetentionFilterHost] = retentionPolicy.Filter.Host
+		}
+		if len(retentionPolicy.Filter.Tags) > 0 {
+			inputs[apis.RetentionFilterTags] = strings.Join(retentionPolicy.Filter.Tags, ",")
+		}
+		if len(retentionPolicy.Filter.Paths) > 0 {
+			inputs[apis.RetentionFilterPaths] = strings.Join(retentionPolicy.Filter.Paths, ",")
+		}
+	}
 	return inputs
 }
+
+// patternsInput encodes the patterns as a JSON array. The patterns may contain commas (i.e. "*.{yaml,json}"),
+// so they can't be joined with commas like the other list inputs.
+func patternsInput(patterns []string) string {
+	if len(patterns) == 0 {
+		return ""
+	}
+	// a list of strings can always be encoded
+	data, _ := json.Marshal(patterns)
+	return string(data)
+}
@@ -0,0 +1,32 @@
+package controller
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"stash.appscode.dev/stash/apis"
+	api "stash.appscode.dev/stash/apis/stash/v1beta1"
+)
+
+func TestInputsForBackupTargetPatterns(t *testing.T) {
+	target := &api.BackupTarget{
+		Ref:     api.TargetRef{Kind: apis.KindPersistentVolumeClaim, Name: "data"},
+		Include: []string{"*.{yaml,json}", "/data/config"},
+		Exclude: []string{"/data/{tmp,cache}"},
+	}
+	inputs := (&StashController{}).inputsForBackupTarget(target)
+
+	// the patterns must be decoded without being split at the commas inside them
+	for input, expected := range map[string][]string{
+		apis.TargetInclude: target.Include,
+		apis.TargetExclude: target.Exclude,
+	} {
+		var patterns []string
+		if assert.NoError(t, json.Unmarshal([]byte(inputs[input]), &patterns), input) {
+			assert.Equal(t, expected, patterns, input)
+		}
+	}
+	_, found := inputs[apis.TargetExcludeIfPresent]
+	assert.False(t, found)
+}
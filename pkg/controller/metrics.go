@@ -0,0 +1,271 @@
+package controller
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/appscode/go/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/labels"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+)
+
+var (
+	backupConfigLastSuccessDesc = prometheus.NewDesc(
+		"stash_backupconfiguration_last_successful_backup_timestamp_seconds",
+		"Time when the last successful BackupSession of the BackupConfiguration has been completed. It is 0 if no backup has succeeded yet.",
+		[]string{"namespace", "backup_configuration"}, nil,
+	)
+	backupConfigPausedDesc = prometheus.NewDesc(
+		"stash_backupconfiguration_paused",
+		"Indicates whether the BackupConfiguration has been paused",
+		[]string{"namespace", "backup_configuration"}, nil,
+	)
+	backupSessionPhaseDesc = prometheus.NewDesc(
+		"stash_backupsession_phase_count",
+		"Number of existing BackupSessions of the BackupConfiguration in each phase",
+		[]string{"namespace", "backup_configuration", "phase"}, nil,
+	)
+	backupSessionDurationDesc = prometheus.NewDesc(
+		"stash_backupsession_last_duration_seconds",
+		"Time taken by the last completed BackupSession of the BackupConfiguration",
+		[]string{"namespace", "backup_configuration", "phase"}, nil,
+	)
+	repositorySizeDesc = prometheus.NewDesc(
+		"stash_repository_size_bytes",
+		"Size of the Repository as reported in its status",
+		[]string{"namespace", "repository"}, nil,
+	)
+	repositorySnapshotCountDesc = prometheus.NewDesc(
+		"stash_repository_snapshot_count",
+		"Number of snapshots in the Repository as reported in its status",
+		[]string{"namespace", "repository"}, nil,
+	)
+	repositoryLastBackupDesc = prometheus.NewDesc(
+		"stash_repository_last_backup_timestamp_seconds",
+		"Time of the last backup into the Repository. It is 0 if there has been no backup yet.",
+		[]string{"namespace", "repository"}, nil,
+	)
+	repositoryIntegrityDesc = prometheus.NewDesc(
+		"stash_repository_integrity",
+		"Result of the last integrity check of the Repository. 1 if the repository is healthy, 0 otherwise.",
+		[]string{"namespace", "repository"}, nil,
+	)
+	repositoryPendingPruneDesc = prometheus.NewDesc(
+		"stash_repository_pending_prune_snapshots",
+		"Number of deleted snapshots whose data are waiting for the next scheduled prune",
+		[]string{"namespace", "repository"}, nil,
+	)
+	restoreSessionPhaseDesc = prometheus.NewDesc(
+		"stash_restoresession_phase",
+		"Current phase of the RestoreSession. The value is 1 for the current phase and 0 for the others.",
+		[]string{"namespace", "restore_session", "phase"}, nil,
+	)
+	restoreSessionDurationDesc = prometheus.NewDesc(
+		"stash_restoresession_duration_seconds",
+		"Time taken by the RestoreSession to complete",
+		[]string{"namespace", "restore_session"}, nil,
+	)
+)
+
+var backupSessionPhases = []api_v1beta1.BackupSessionPhase{
+	api_v1beta1.BackupSessionPending,
+	api_v1beta1.BackupSessionRunning,
+	api_v1beta1.BackupSessionSucceeded,
+	api_v1beta1.BackupSessionFailed,
+	api_v1beta1.BackupSessionSkipped,
+	api_v1beta1.BackupSessionCancelled,
+	api_v1beta1.BackupSessionUnknown,
+}
+
+var restoreSessionPhases = []api_v1beta1.RestoreSessionPhase{
+	api_v1beta1.RestoreSessionPending,
+	api_v1beta1.RestoreSessionRunning,
+	api_v1beta1.RestoreSessionSucceeded,
+	api_v1beta1.RestoreSessionFailed,
+	api_v1beta1.RestoreSessionCancelled,
+	api_v1beta1.RestoreSessionUnknown,
+}
+
+// statusCollector exposes the state of the Stash resources from the informer caches of the operator. Unlike the
+// metrics pushed by the backup and restore jobs, the series exist for every resource as long as the resource exists.
+type statusCollector struct {
+	ctrl *StashController
+}
+
+var _ prometheus.Collector = &statusCollector{}
+
+// registerStatusCollector exposes the state of the Stash resources in the /metrics endpoint of the operator.
+// It must be called after the watchers have been initialized.
+func (c *StashController) registerStatusCollector() {
+	if err := prometheus.Register(&statusCollector{ctrl: c}); err != nil {
+		log.Errorf("failed to register metrics collector. Reason: %v", err)
+	}
+}
+
+func (s *statusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- backupConfigLastSuccessDesc
+	ch <- backupConfigPausedDesc
+	ch <- backupSessionPhaseDesc
+	ch <- backupSessionDurationDesc
+	ch <- repositorySizeDesc
+	ch <- repositorySnapshotCountDesc
+	ch <- repositoryLastBackupDesc
+	ch <- repositoryIntegrityDesc
+	ch <- repositoryPendingPruneDesc
+	ch <- restoreSessionPhaseDesc
+	ch <- restoreSessionDurationDesc
+}
+
+func (s *statusCollector) Collect(ch chan<- prometheus.Metric) {
+	s.collectBackupMetrics(ch)
+	s.collectRepositoryMetrics(ch)
+	s.collectRestoreMetrics(ch)
+}
+
+func (s *statusCollector) collectBackupMetrics(ch chan<- prometheus.Metric) {
+	configs, err := s.ctrl.bcLister.List(labels.Everything())
+	if err != nil {
+		log.Errorf("failed to list BackupConfigurations for metrics. Reason: %v", err)
+		return
+	}
+	sessions, err := s.ctrl.backupSessionLister.List(labels.Everything())
+	if err != nil {
+		log.Errorf("failed to list BackupSessions for metrics. Reason: %v", err)
+		return
+	}
+
+	// group the sessions by their BackupConfiguration
+	sessionsOf := make(map[string][]*api_v1beta1.BackupSession)
+	for _, session := range sessions {
+		key := session.Namespace + "/" + session.Spec.BackupConfiguration.Name
+		sessionsOf[key] = append(sessionsOf[key], session)
+	}
+
+	for _, bc := range configs {
+		paused := 0.0
+		if bc.Spec.Paused {
+			paused = 1
+		}
+		ch <- prometheus.MustNewConstMetric(backupConfigPausedDesc, prometheus.GaugeValue, paused, bc.Namespace, bc.Name)
+
+		phaseCount := make(map[api_v1beta1.BackupSessionPhase]int)
+		var lastCompleted *api_v1beta1.BackupSession
+		for _, session := range sessionsOf[bc.Namespace+"/"+bc.Name] {
+			phase := session.Status.Phase
+			if phase == "" {
+				phase = api_v1beta1.BackupSessionPending
+			}
+			phaseCount[phase]++
+
+			switch phase {
+			case api_v1beta1.BackupSessionSucceeded, api_v1beta1.BackupSessionFailed, api_v1beta1.BackupSessionCancelled:
+				if lastCompleted == nil || lastCompleted.CreationTimestamp.Before(&session.CreationTimestamp) {
+					lastCompleted = session
+				}
+			}
+		}
+
+		// the last successful session is taken from the status as the session might have been garbage collected
+		lastSuccessTime := 0.0
+		if bc.Status.LastSuccessfulSession != nil && bc.Status.LastSuccessfulSession.CompletionTime != nil {
+			lastSuccessTime = float64(bc.Status.LastSuccessfulSession.CompletionTime.Unix())
+		}
+		ch <- prometheus.MustNewConstMetric(backupConfigLastSuccessDesc, prometheus.GaugeValue, lastSuccessTime, bc.Namespace, bc.Name)
+
+		for _, phase := range backupSessionPhases {
+			ch <- prometheus.MustNewConstMetric(backupSessionPhaseDesc, prometheus.GaugeValue, float64(phaseCount[phase]), bc.Namespace, bc.Name, string(phase))
+		}
+
+		if lastCompleted != nil {
+			if d, err := time.ParseDuration(lastCompleted.Status.SessionDuration); err == nil {
+				ch <- prometheus.MustNewConstMetric(backupSessionDurationDesc, prometheus.GaugeValue, d.Seconds(),
+					bc.Namespace, bc.Name, string(lastCompleted.Status.Phase))
+			}
+		}
+	}
+}
+
+func (s *statusCollector) collectRepositoryMetrics(ch chan<- prometheus.Metric) {
+	repos, err := s.ctrl.repoLister.List(labels.Everything())
+	if err != nil {
+		log.Errorf("failed to list Repositories for metrics. Reason: %v", err)
+		return
+	}
+	for _, repo := range repos {
+		if size, ok := parseRepositorySize(repo.Status.Size); ok {
+			ch <- prometheus.MustNewConstMetric(repositorySizeDesc, prometheus.GaugeValue, size, repo.Namespace, repo.Name)
+		}
+		ch <- prometheus.MustNewConstMetric(repositorySnapshotCountDesc, prometheus.GaugeValue, float64(repo.Status.SnapshotCount), repo.Namespace, repo.Name)
+
+		lastBackup := 0.0
+		if repo.Status.LastBackupTime != nil {
+			lastBackup = float64(repo.Status.LastBackupTime.Unix())
+		}
+		ch <- prometheus.MustNewConstMetric(repositoryLastBackupDesc, prometheus.GaugeValue, lastBackup, repo.Namespace, repo.Name)
+
+		if repo.Status.Integrity != nil {
+			integrity := 0.0
+			if *repo.Status.Integrity {
+				integrity = 1
+			}
+			ch <- prometheus.MustNewConstMetric(repositoryIntegrityDesc, prometheus.GaugeValue, integrity, repo.Namespace, repo.Name)
+		}
+
+		pending := 0.0
+		if repo.Status.PendingPrune != nil {
+			pending = float64(repo.Status.PendingPrune.SnapshotsDeleted)
+		}
+		ch <- prometheus.MustNewConstMetric(repositoryPendingPruneDesc, prometheus.GaugeValue, pending, repo.Namespace, repo.Name)
+	}
+}
+
+func (s *statusCollector) collectRestoreMetrics(ch chan<- prometheus.Metric) {
+	sessions, err := s.ctrl.restoreSessionLister.List(labels.Everything())
+	if err != nil {
+		log.Errorf("failed to list RestoreSessions for metrics. Reason: %v", err)
+		return
+	}
+	for _, session := range sessions {
+		current := session.Status.Phase
+		if current == "" {
+			current = api_v1beta1.RestoreSessionPending
+		}
+		for _, phase := range restoreSessionPhases {
+			value := 0.0
+			if phase == current {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(restoreSessionPhaseDesc, prometheus.GaugeValue, value, session.Namespace, session.Name, string(phase))
+		}
+		if d, err := time.ParseDuration(session.Status.SessionDuration); err == nil {
+			ch <- prometheus.MustNewConstMetric(restoreSessionDurationDesc, prometheus.GaugeValue, d.Seconds(), session.Namespace, session.Name)
+		}
+	}
+}
+
+var byteUnits = map[string]float64{
+	"B":   1,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+}
+
+// parseRepositorySize converts the size of a Repository written in its status (i.e. "10.500 MiB") into bytes
+func parseRepositorySize(size string) (float64, bool) {
+	parts := strings.Fields(size)
+	if len(parts) != 2 {
+		return 0, false
+	}
+	unit, found := byteUnits[parts[1]]
+	if !found {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return 0, false
+	}
+	return value * unit, true
+}
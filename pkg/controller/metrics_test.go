@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRepositorySize(t *testing.T) {
+	testCases := []struct {
+		name  string
+		size  string
+		bytes float64
+		valid bool
+	}{
+		{name: "bytes", size: "512 B", bytes: 512, valid: true},
+		{name: "fractional MiB", size: "10.500 MiB", bytes: 10.5 * (1 << 20), valid: true},
+		{name: "GiB", size: "2 GiB", bytes: 2 * (1 << 30), valid: true},
+		{name: "empty", size: "", valid: false},
+		{name: "unknown unit", size: "10 MB", valid: false},
+		{name: "missing unit", size: "1024", valid: false},
+		{name: "invalid number", size: "ten MiB", valid: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bytes, valid := parseRepositorySize(tc.size)
+			assert.Equal(t, tc.valid, valid)
+			assert.Equal(t, tc.bytes, bytes)
+		})
+	}
+}
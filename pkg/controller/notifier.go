@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/appscode/go/log"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	api "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	"stash.appscode.dev/stash/pkg/notifier"
+)
+
+// initNotifier sets up the delivery of the notifications. It must be called after the Repository watcher has been initialized.
+func (c *StashController) initNotifier() {
+	c.notifierLister = c.stashInformerFactory.Stash().V1beta1().Notifiers().Lister()
+	c.notificationDispatcher = notifier.NewDispatcher(c.kubeClient, c.notifierLister)
+
+	// the Repository reconciler does not know the previous state. so, detect the failed checks here.
+	c.repoInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.notifyRepositoryCheckFailure(oldObj.(*api.Repository), newObj.(*api.Repository))
+		},
+	})
+}
+
+func (c *StashController) notifyBackupSession(backupSession *api_v1beta1.BackupSession, event api_v1beta1.NotificationEvent, message string) {
+	backupConfig, err := c.bcLister.BackupConfigurations(backupSession.Namespace).Get(backupSession.Spec.BackupConfiguration.Name)
+	if err != nil {
+		log.Errorf("failed to get BackupConfiguration of BackupSession %s/%s to send notification. Reason: %v", backupSession.Namespace, backupSession.Name, err)
+		return
+	}
+	c.notificationDispatcher.Notify(backupSession.Namespace, backupConfig.Spec.Notifiers, notifier.Notification{
+		Event:               event,
+		Kind:                api_v1beta1.ResourceKindBackupSession,
+		Namespace:           backupSession.Namespace,
+		Name:                backupSession.Name,
+		BackupConfiguration: backupConfig.Name,
+		Repository:          backupConfig.Spec.Repository.Name,
+		Message:             message,
+	})
+}
+
+func (c *StashController) notifyBackupStale(backupConfig *api_v1beta1.BackupConfiguration, message string) {
+	c.notificationDispatcher.Notify(backupConfig.Namespace, backupConfig.Spec.Notifiers, notifier.Notification{
+		Event:               api_v1beta1.NotificationBackupStale,
+		Kind:                api_v1beta1.ResourceKindBackupConfiguration,
+		Namespace:           backupConfig.Namespace,
+		Name:                backupConfig.Name,
+		BackupConfiguration: backupConfig.Name,
+		Repository:          backupConfig.Spec.Repository.Name,
+		Message:             message,
+	})
+}
+
+func (c *StashController) notifyRestoreSession(restoreSession *api_v1beta1.RestoreSession, event api_v1beta1.NotificationEvent, message string) {
+	c.notificationDispatcher.Notify(restoreSession.Namespace, restoreSession.Spec.Notifiers, notifier.Notification{
+		Event:      event,
+		Kind:       api_v1beta1.ResourceKindRestoreSession,
+		Namespace:  restoreSession.Namespace,
+		Name:       restoreSession.Name,
+		Repository: restoreSession.Spec.Repository.Name,
+		Message:    message,
+	})
+}
+
+// notifyRepositoryCheckFailure notifies the Notifiers of the BackupConfigurations using the Repository
+// when a new check of the Repository has failed.
+func (c *StashController) notifyRepositoryCheckFailure(oldRepo, newRepo *api.Repository) {
+	check := newRepo.Status.LastCheck
+	if check == nil || check.Phase != api.MaintenanceTaskFailed {
+		return
+	}
+	if last := oldRepo.Status.LastCheck; last != nil && last.Phase == check.Phase && last.CompletionTime.Equal(check.CompletionTime) {
+		return
+	}
+
+	backupConfigs, err := c.bcLister.BackupConfigurations(newRepo.Namespace).List(labels.Everything())
+	if err != nil {
+		log.Errorf("failed to list BackupConfigurations to notify failed check of Repository %s/%s. Reason: %v", newRepo.Namespace, newRepo.Name, err)
+		return
+	}
+	var refs []core.LocalObjectReference
+	for _, bc := range backupConfigs {
+		if bc.Spec.Repository.Name == newRepo.Name {
+			refs = append(refs, bc.Spec.Notifiers...)
+		}
+	}
+	if len(refs) == 0 {
+		return
+	}
+	c.notificationDispatcher.Notify(newRepo.Namespace, refs, notifier.Notification{
+		Event:      api_v1beta1.NotificationRepositoryCheckFailed,
+		Kind:       api.ResourceKindRepository,
+		Namespace:  newRepo.Namespace,
+		Name:       newRepo.Name,
+		Repository: newRepo.Name,
+		Message:    fmt.Sprintf("check of Repository %s/%s has failed. Reason: %s", newRepo.Namespace, newRepo.Name, check.Error),
+	})
+}
@@ -34,6 +34,7 @@ const (
 	VolumeSnapshotClusterRole        = "stash-volumesnapshot-job"
 	VolumeSnapshotRestoreClusterRole = "stash-volumesnapshot-restore-job"
 	CronJobClusterRole               = "stash-cron-job"
+	RepositoryMaintenanceClusterRole = "stash-repository-maintenance-job"
 	KindRole                         = "Role"
 	KindClusterRole                  = "ClusterRole"
 	StorageClassClusterRole          = "stash-storageclass"
@@ -58,6 +59,10 @@ func (c *StashController) getStorageClassClusterRoleBindingName(name string) str
 	return name + "-" + StorageClassClusterRole
 }
 
+func (c *StashController) getRepositoryMaintenanceRoleBindingName(name string) string {
+	return name + "-" + RepositoryMaintenanceClusterRole
+}
+
 func (c *StashController) ensureCronJobRBAC(resource *core.ObjectReference, sa string, psps []string, labels map[string]string) error {
 	// ensure CronJob cluster role
 	err := c.ensureCronJobClusterRole(psps, labels)
@@ -804,3 +809,71 @@ func (c *StashController) ensureStorageClassClusterRoleBinding(resource *core.Ob
 	})
 	return err
 }
+
+func (c *StashController) ensureRepositoryMaintenanceRBAC(resource *core.ObjectReference, sa string, labels map[string]string) error {
+	// ensure repository maintenance cluster role
+	err := c.ensureRepositoryMaintenanceClusterRole(labels)
+	if err != nil {
+		return err
+	}
+
+	// ensure RoleBinding
+	return c.ensureRepositoryMaintenanceRoleBinding(resource, sa, labels)
+}
+
+func (c *StashController) ensureRepositoryMaintenanceClusterRole(labels map[string]string) error {
+	meta := metav1.ObjectMeta{
+		Name:   RepositoryMaintenanceClusterRole,
+		Labels: labels,
+	}
+	_, _, err := rbac_util.CreateOrPatchClusterRole(c.kubeClient, meta, func(in *rbac.ClusterRole) *rbac.ClusterRole {
+		in.Rules = []rbac.PolicyRule{
+			{
+				APIGroups: []string{api_v1alpha1.SchemeGroupVersion.Group},
+				Resources: []string{api_v1alpha1.ResourcePluralRepository, api_v1alpha1.ResourcePluralRepository + "/status"},
+				Verbs:     []string{"get", "update", "patch"},
+			},
+			{
+				APIGroups: []string{core.GroupName},
+				Resources: []string{"events"},
+				Verbs:     []string{"create"},
+			},
+			{
+				APIGroups:     []string{policy.GroupName},
+				Resources:     []string{"podsecuritypolicies"},
+				Verbs:         []string{"use"},
+				ResourceNames: []string{DefaultBackupJobPSPName},
+			},
+		}
+		return in
+	})
+	return err
+}
+
+func (c *StashController) ensureRepositoryMaintenanceRoleBinding(resource *core.ObjectReference, sa string, labels map[string]string) error {
+	meta := metav1.ObjectMeta{
+		Name:      c.getRepositoryMaintenanceRoleBindingName(resource.Name),
+		Namespace: resource.Namespace,
+		Labels:    labels,
+	}
+
+	// ensure role binding
+	_, _, err := rbac_util.CreateOrPatchRoleBinding(c.kubeClient, meta, func(in *rbac.RoleBinding) *rbac.RoleBinding {
+		core_util.EnsureOwnerReference(&in.ObjectMeta, resource)
+
+		in.RoleRef = rbac.RoleRef{
+			APIGroup: rbac.GroupName,
+			Kind:     KindClusterRole,
+			Name:     RepositoryMaintenanceClusterRole,
+		}
+		in.Subjects = []rbac.Subject{
+			{
+				Kind:      rbac.ServiceAccountKind,
+				Name:      sa,
+				Namespace: resource.Namespace,
+			},
+		}
+		return in
+	})
+	return err
+}
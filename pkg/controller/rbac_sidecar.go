@@ -94,6 +94,16 @@ func (c *StashController) ensureSidecarClusterRole() error {
 				Resources: []string{"configmaps"},
 				Verbs:     []string{"create", "update", "get"},
 			},
+			{
+				APIGroups: []string{core.GroupName},
+				Resources: []string{"pods"},
+				Verbs:     []string{"get"},
+			},
+			{
+				APIGroups: []string{core.GroupName},
+				Resources: []string{"pods/exec"},
+				Verbs:     []string{"create"},
+			},
 			{
 				APIGroups: []string{core.GroupName},
 				Resources: []string{"events"},
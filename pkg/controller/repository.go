@@ -79,7 +79,19 @@ func (c *StashController) runRepositoryReconciler(key string) error {
 				in.ObjectMeta = core_util.AddFinalizer(in.ObjectMeta, util.RepositoryFinalizer)
 				return in
 			})
-			return err
+			if err != nil {
+				return err
+			}
+			// ignore invalid repository objects (eg: created by xray).
+			if repo.IsValid() != nil {
+				return nil
+			}
+			// rotate the password if a new password has been added in the storage secret
+			if err = c.ensurePasswordRotationJob(repo); err != nil {
+				return err
+			}
+			// create CronJobs for the scheduled maintenance tasks
+			return c.ensureRepositoryMaintenanceCronJobs(repo)
 		}
 	}
 	return nil
@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"fmt"
+
+	batch_v1beta1 "k8s.io/api/batch/v1beta1"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/reference"
+	batch_util "kmodules.xyz/client-go/batch/v1beta1"
+	core_util "kmodules.xyz/client-go/core/v1"
+	meta_util "kmodules.xyz/client-go/meta"
+	"stash.appscode.dev/stash/apis"
+	api "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	stash_scheme "stash.appscode.dev/stash/client/clientset/versioned/scheme"
+	"stash.appscode.dev/stash/pkg/docker"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+func getRepositoryMaintenanceCronJobName(repoName, task string) string {
+	return util.MaintenanceCronJobPrefix + task + "-" + repoName
+}
+
+// ensureRepositoryMaintenanceCronJobs creates a CronJob for each maintenance task scheduled for the Repository
+// and removes the CronJobs of the tasks that are no longer scheduled.
+func (c *StashController) ensureRepositoryMaintenanceCronJobs(repository *api.Repository) error {
+	schedules := map[string]string{}
+	if repository.Spec.Maintenance != nil {
+		if repository.Spec.Maintenance.Check != nil {
+			schedules[api.MaintenanceTaskCheck] = repository.Spec.Maintenance.Check.Schedule
+		}
+		if repository.Spec.Maintenance.Prune != nil {
+			schedules[api.MaintenanceTaskPrune] = repository.Spec.Maintenance.Prune.Schedule
+		}
+	}
+
+	for _, task := range []string{api.MaintenanceTaskCheck, api.MaintenanceTaskPrune} {
+		if _, scheduled := schedules[task]; scheduled {
+			continue
+		}
+		err := c.kubeClient.BatchV1beta1().CronJobs(repository.Namespace).Delete(getRepositoryMaintenanceCronJobName(repository.Name, task), meta_util.DeleteInBackground())
+		if err != nil && !kerr.IsNotFound(err) {
+			return err
+		}
+	}
+	if len(schedules) == 0 {
+		return nil
+	}
+
+	ref, err := reference.GetReference(stash_scheme.Scheme, repository)
+	if err != nil {
+		return err
+	}
+	labels := map[string]string{
+		util.LabelApp:            util.AppLabelStash,
+		util.AnnotationOperation: util.OperationRepositoryMaintenance,
+	}
+
+	// create a ServiceAccount for the maintenance jobs and grant it the permissions to update the Repository status
+	serviceAccountName := util.MaintenanceCronJobPrefix + repository.Name
+	_, _, err = core_util.CreateOrPatchServiceAccount(c.kubeClient, metav1.ObjectMeta{Name: serviceAccountName, Namespace: repository.Namespace}, func(in *core.ServiceAccount) *core.ServiceAccount {
+		core_util.EnsureOwnerReference(&in.ObjectMeta, ref)
+		return in
+	})
+	if err != nil {
+		return err
+	}
+	if err = c.ensureRepositoryMaintenanceRBAC(ref, serviceAccountName, labels); err != nil {
+		return err
+	}
+
+	image := docker.Docker{
+		Registry: c.DockerRegistry,
+		Image:    docker.ImageStash,
+		Tag:      c.StashImageTag,
+	}
+	for task, schedule := range schedules {
+		meta := metav1.ObjectMeta{
+			Name:      getRepositoryMaintenanceCronJobName(repository.Name, task),
+			Namespace: repository.Namespace,
+			Labels:    labels,
+		}
+		_, _, err = batch_util.CreateOrPatchCronJob(c.kubeClient, meta, func(in *batch_v1beta1.CronJob) *batch_v1beta1.CronJob {
+			// set repository as cron-job owner
+			core_util.EnsureOwnerReference(&in.ObjectMeta, ref)
+
+			in.Spec.Schedule = schedule
+			// maintenance tasks lock the repository exclusively. so, don't run them concurrently.
+			in.Spec.ConcurrencyPolicy = batch_v1beta1.ForbidConcurrent
+			in.Spec.JobTemplate.Labels = core_util.UpsertMap(in.Spec.JobTemplate.Labels, labels)
+			// ensure that job gets deleted on completion
+			in.Spec.JobTemplate.Labels[apis.KeyDeleteJobOnCompletion] = "true"
+
+			in.Spec.JobTemplate.Spec.Template.Spec.Containers = core_util.UpsertContainer(
+				in.Spec.JobTemplate.Spec.Template.Spec.Containers,
+				core.Container{
+					Name:            util.StashContainer,
+					ImagePullPolicy: core.PullIfNotPresent,
+					Image:           image.ToContainerImage(),
+					Args: []string{
+						"maintain-repository",
+						fmt.Sprintf("--repository=%s", repository.Name),
+						fmt.Sprintf("--namespace=%s", repository.Namespace),
+						fmt.Sprintf("--task=%s", task),
+						fmt.Sprintf("--secret-dir=%s", util.StashSecretMountDir),
+						fmt.Sprintf("--scratch-dir=%s", util.TmpDirMountPath),
+					},
+					VolumeMounts: []core.VolumeMount{
+						{
+							Name:      util.StashSecretVolume,
+							MountPath: util.StashSecretMountDir,
+						},
+						{
+							Name:      util.ScratchDirVolumeName,
+							MountPath: util.TmpDirMountPath,
+						},
+					},
+				})
+			in.Spec.JobTemplate.Spec.Template.Spec.Volumes = util.UpsertSecretVolume(in.Spec.JobTemplate.Spec.Template.Spec.Volumes, repository.Spec.Backend.StorageSecretName)
+			in.Spec.JobTemplate.Spec.Template.Spec.Volumes = util.UpsertScratchVolume(in.Spec.JobTemplate.Spec.Template.Spec.Volumes)
+			// mount local backend into the job
+			if repository.Spec.Backend.Local != nil {
+				in.Spec.JobTemplate.Spec.Template.Spec = util.AttachLocalBackend(in.Spec.JobTemplate.Spec.Template.Spec, *repository.Spec.Backend.Local)
+			}
+			in.Spec.JobTemplate.Spec.Template.Spec.RestartPolicy = core.RestartPolicyNever
+			in.Spec.JobTemplate.Spec.Template.Spec.ServiceAccountName = serviceAccountName
+			return in
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
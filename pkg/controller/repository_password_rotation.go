@@ -0,0 +1,296 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/appscode/go/log"
+	batchv1 "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	rbac "k8s.io/api/rbac/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/reference"
+	batch_util "kmodules.xyz/client-go/batch/v1"
+	core_util "kmodules.xyz/client-go/core/v1"
+	rbac_util "kmodules.xyz/client-go/rbac/v1"
+	"kmodules.xyz/client-go/tools/queue"
+	"stash.appscode.dev/stash/apis"
+	api "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	stash_scheme "stash.appscode.dev/stash/client/clientset/versioned/scheme"
+	stash_util "stash.appscode.dev/stash/client/clientset/versioned/typed/stash/v1alpha1/util"
+	"stash.appscode.dev/stash/pkg/docker"
+	"stash.appscode.dev/stash/pkg/eventer"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+func getPasswordRotationJobName(repoName string) string {
+	return util.PasswordRotationJobPrefix + repoName
+}
+
+// passwordRotationBackoffLimit is the number of retries of the password rotation Job.
+// The rotation resumes from the last completed step. So, it is safe to retry.
+const passwordRotationBackoffLimit int32 = 3
+
+// getNewPasswordKey returns the key of the storage secret that holds the new password of the Repository
+func getNewPasswordKey(repository *api.Repository) string {
+	if repository.Spec.PasswordRotation != nil && repository.Spec.PasswordRotation.NewPasswordKey != "" {
+		return repository.Spec.PasswordRotation.NewPasswordKey
+	}
+	return api.DefaultNewPasswordKey
+}
+
+// initStorageSecretWatcher resyncs the Repositories using a storage secret when a new password is added to it,
+// so that password rotation starts without waiting for the periodic resync. It must be called after the
+// Repository watcher has been initialized.
+func (c *StashController) initStorageSecretWatcher() {
+	c.secretInformer = c.kubeInformerFactory.Core().V1().Secrets().Informer()
+	c.secretInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueRepositoriesForNewPassword(obj.(*core.Secret))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.enqueueRepositoriesForNewPassword(newObj.(*core.Secret))
+		},
+	})
+}
+
+func (c *StashController) enqueueRepositoriesForNewPassword(secret *core.Secret) {
+	repositories, err := c.repoLister.Repositories(secret.Namespace).List(labels.Everything())
+	if err != nil {
+		log.Errorf("failed to list Repositories using Secret %s/%s. Reason: %v", secret.Namespace, secret.Name, err)
+		return
+	}
+	for _, repo := range repositories {
+		if repo.Spec.Backend.StorageSecretName != secret.Name {
+			continue
+		}
+		if _, found := secret.Data[getNewPasswordKey(repo)]; found {
+			queue.Enqueue(c.repoQueue.GetQueue(), repo)
+		}
+	}
+}
+
+// ensurePasswordRotationJob creates a Job to rotate the password of the Repository when the new password
+// has been added in the storage secret or a previous rotation is incomplete. The Job resumes an incomplete
+// rotation from its last completed step. A Job that has failed all its retries is kept so that the rotation
+// is not retried until the user deletes it.
+func (c *StashController) ensurePasswordRotationJob(repository *api.Repository) error {
+	newPasswordKey := getNewPasswordKey(repository)
+	var oldKeyRemovalDelay *metav1.Duration
+	if repository.Spec.PasswordRotation != nil && repository.Spec.PasswordRotation.OldKeyRemovalDelay != nil {
+		oldKeyRemovalDelay = repository.Spec.PasswordRotation.OldKeyRemovalDelay
+	}
+
+	secret, err := c.kubeClient.CoreV1().Secrets(repository.Namespace).Get(repository.Spec.Backend.StorageSecretName, metav1.GetOptions{})
+	if err != nil {
+		if kerr.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	// the new password is moved out of its key once the secret has been updated. so, an incomplete rotation
+	// must be resumed even if the new password key is absent.
+	if _, found := secret.Data[newPasswordKey]; !found && !api.PasswordRotationIncomplete(repository.Status.PasswordRotation) {
+		return nil
+	}
+
+	jobName := getPasswordRotationJobName(repository.Name)
+	_, err = c.kubeClient.BatchV1().Jobs(repository.Namespace).Get(jobName, metav1.GetOptions{})
+	if err == nil {
+		// rotation is running or has failed
+		return nil
+	}
+	if !kerr.IsNotFound(err) {
+		return err
+	}
+
+	// the secret will be updated with the new password. so, the other Repositories using the same secret
+	// would not be able to open their repositories anymore.
+	repositories, err := c.repoLister.Repositories(repository.Namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, r := range repositories {
+		if r.Name != repository.Name && r.Spec.Backend.StorageSecretName == repository.Spec.Backend.StorageSecretName {
+			return c.refusePasswordRotation(repository, fmt.Sprintf("storage secret %s is also used by Repository %s", secret.Name, r.Name))
+		}
+	}
+
+	ref, err := reference.GetReference(stash_scheme.Scheme, repository)
+	if err != nil {
+		return err
+	}
+	labels := map[string]string{
+		util.LabelApp:            util.AppLabelStash,
+		util.AnnotationOperation: util.OperationPasswordRotation,
+	}
+
+	// create a ServiceAccount for the job and grant it the permissions to update the storage secret and the Repository status
+	serviceAccountName := getPasswordRotationJobName(repository.Name)
+	_, _, err = core_util.CreateOrPatchServiceAccount(c.kubeClient, metav1.ObjectMeta{Name: serviceAccountName, Namespace: repository.Namespace}, func(in *core.ServiceAccount) *core.ServiceAccount {
+		core_util.EnsureOwnerReference(&in.ObjectMeta, ref)
+		return in
+	})
+	if err != nil {
+		return err
+	}
+	if err = c.ensurePasswordRotationRBAC(ref, repository.Spec.Backend.StorageSecretName, serviceAccountName, labels); err != nil {
+		return err
+	}
+
+	image := docker.Docker{
+		Registry: c.DockerRegistry,
+		Image:    docker.ImageStash,
+		Tag:      c.StashImageTag,
+	}
+	args := []string{
+		"rotate-password",
+		fmt.Sprintf("--repository=%s", repository.Name),
+		fmt.Sprintf("--namespace=%s", repository.Namespace),
+		fmt.Sprintf("--new-password-key=%s", newPasswordKey),
+		fmt.Sprintf("--secret-dir=%s", util.StashSecretMountDir),
+		fmt.Sprintf("--scratch-dir=%s", util.TmpDirMountPath),
+	}
+	if oldKeyRemovalDelay != nil {
+		args = append(args, fmt.Sprintf("--old-key-removal-delay=%s", oldKeyRemovalDelay.Duration))
+	}
+	jobMeta := metav1.ObjectMeta{
+		Name:      jobName,
+		Namespace: repository.Namespace,
+		Labels:    labels,
+	}
+	_, _, err = batch_util.CreateOrPatchJob(c.kubeClient, jobMeta, func(in *batchv1.Job) *batchv1.Job {
+		// set repository as job owner
+		core_util.EnsureOwnerReference(&in.ObjectMeta, ref)
+		// ensure that job gets deleted on completion
+		in.Labels[apis.KeyDeleteJobOnCompletion] = "true"
+		// the rotation resumes from the last completed step on retry
+		backoffLimit := passwordRotationBackoffLimit
+		in.Spec.BackoffLimit = &backoffLimit
+
+		in.Spec.Template.Spec.Containers = core_util.UpsertContainer(
+			in.Spec.Template.Spec.Containers,
+			core.Container{
+				Name:            util.StashContainer,
+				ImagePullPolicy: core.PullIfNotPresent,
+				Image:           image.ToContainerImage(),
+				Args:            args,
+				VolumeMounts: []core.VolumeMount{
+					{
+						Name:      util.StashSecretVolume,
+						MountPath: util.StashSecretMountDir,
+					},
+					{
+						Name:      util.ScratchDirVolumeName,
+						MountPath: util.TmpDirMountPath,
+					},
+				},
+			})
+		in.Spec.Template.Spec.Volumes = util.UpsertSecretVolume(in.Spec.Template.Spec.Volumes, repository.Spec.Backend.StorageSecretName)
+		in.Spec.Template.Spec.Volumes = util.UpsertScratchVolume(in.Spec.Template.Spec.Volumes)
+		// mount local backend into the job
+		if repository.Spec.Backend.Local != nil {
+			in.Spec.Template.Spec = util.AttachLocalBackend(in.Spec.Template.Spec, *repository.Spec.Backend.Local)
+		}
+		in.Spec.Template.Spec.RestartPolicy = core.RestartPolicyNever
+		in.Spec.Template.Spec.ServiceAccountName = serviceAccountName
+		return in
+	})
+	return err
+}
+
+// refusePasswordRotation marks the password rotation of the Repository as failed without starting it
+func (c *StashController) refusePasswordRotation(repository *api.Repository, reason string) error {
+	if cur := repository.Status.PasswordRotation; cur != nil && cur.Phase == api.PasswordRotationFailed && cur.Error == reason {
+		return nil
+	}
+	_, err := stash_util.UpdateRepositoryStatus(c.stashClient.StashV1alpha1(), repository, func(in *api.RepositoryStatus) *api.RepositoryStatus {
+		now := metav1.Now()
+		in.PasswordRotation = &api.PasswordRotationStatus{
+			Phase:          api.PasswordRotationFailed,
+			CompletionTime: &now,
+			Error:          reason,
+		}
+		return in
+	}, apis.EnableStatusSubresource)
+	if err != nil {
+		return err
+	}
+	_, err = eventer.CreateEvent(
+		c.kubeClient,
+		eventer.EventSourceRepositoryController,
+		repository,
+		core.EventTypeWarning,
+		eventer.EventReasonPasswordRotationFailed,
+		fmt.Sprintf("Refused to rotate password. Reason: %s", reason),
+	)
+	return err
+}
+
+func (c *StashController) getPasswordRotationRoleName(name string) string {
+	return getPasswordRotationJobName(name)
+}
+
+// ensurePasswordRotationRBAC grants the password rotation job the permission to update only the storage secret of the Repository
+func (c *StashController) ensurePasswordRotationRBAC(resource *core.ObjectReference, secretName, sa string, labels map[string]string) error {
+	meta := metav1.ObjectMeta{
+		Name:      c.getPasswordRotationRoleName(resource.Name),
+		Namespace: resource.Namespace,
+		Labels:    labels,
+	}
+	_, _, err := rbac_util.CreateOrPatchRole(c.kubeClient, meta, func(in *rbac.Role) *rbac.Role {
+		core_util.EnsureOwnerReference(&in.ObjectMeta, resource)
+
+		in.Rules = []rbac.PolicyRule{
+			{
+				APIGroups:     []string{core.GroupName},
+				Resources:     []string{"secrets"},
+				ResourceNames: []string{secretName},
+				Verbs:         []string{"get", "update"},
+			},
+			{
+				APIGroups:     []string{api.SchemeGroupVersion.Group},
+				Resources:     []string{api.ResourcePluralRepository, api.ResourcePluralRepository + "/status"},
+				ResourceNames: []string{resource.Name},
+				Verbs:         []string{"get", "update", "patch"},
+			},
+			{
+				APIGroups: []string{core.GroupName},
+				Resources: []string{"events"},
+				Verbs:     []string{"create"},
+			},
+			{
+				APIGroups:     []string{policy.GroupName},
+				Resources:     []string{"podsecuritypolicies"},
+				Verbs:         []string{"use"},
+				ResourceNames: []string{DefaultBackupJobPSPName},
+			},
+		}
+		return in
+	})
+	if err != nil {
+		return err
+	}
+
+	_, _, err = rbac_util.CreateOrPatchRoleBinding(c.kubeClient, meta, func(in *rbac.RoleBinding) *rbac.RoleBinding {
+		core_util.EnsureOwnerReference(&in.ObjectMeta, resource)
+
+		in.RoleRef = rbac.RoleRef{
+			APIGroup: rbac.GroupName,
+			Kind:     KindRole,
+			Name:     meta.Name,
+		}
+		in.Subjects = []rbac.Subject{
+			{
+				Kind:      rbac.ServiceAccountKind,
+				Name:      sa,
+				Namespace: resource.Namespace,
+			},
+		}
+		return in
+	})
+	return err
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/golang/glog"
 	batchv1 "k8s.io/api/batch/v1"
 	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -54,8 +55,15 @@ func (c *StashController) NewRestoreSessionWebhook() hooks.AdmissionHook {
 				return nil, obj.(*api_v1beta1.RestoreSession).IsValid()
 			},
 			UpdateFunc: func(oldObj, newObj runtime.Object) (runtime.Object, error) {
+				oldSpec := oldObj.(*api_v1beta1.RestoreSession).Spec
+				newSpec := newObj.(*api_v1beta1.RestoreSession).Spec
+				// cancellation can be requested but can't be withdrawn
+				if oldSpec.Cancel && !newSpec.Cancel {
+					return nil, fmt.Errorf("RestoreSession cancellation can't be withdrawn")
+				}
 				// TODO: should not allow spec update ???
-				if !meta.Equal(oldObj.(*api_v1beta1.RestoreSession).Spec, newObj.(*api_v1beta1.RestoreSession).Spec) {
+				oldSpec.Cancel = newSpec.Cancel
+				if !meta.Equal(oldSpec, newSpec) {
 					return nil, fmt.Errorf("RestoreSession spec is immutable")
 				}
 				return nil, nil
@@ -126,10 +134,16 @@ func (c *StashController) runRestoreSessionProcessor(key string) error {
 			}
 
 			if restoreSession.Status.Phase == api_v1beta1.RestoreSessionFailed ||
-				restoreSession.Status.Phase == api_v1beta1.RestoreSessionSucceeded {
+				restoreSession.Status.Phase == api_v1beta1.RestoreSessionSucceeded ||
+				restoreSession.Status.Phase == api_v1beta1.RestoreSessionCancelled {
 				log.Infof("Skipping processing RestoreSession %s/%s. Reason: phase is %q.", restoreSession.Namespace, restoreSession.Name, restoreSession.Status.Phase)
 				return nil
 			}
+
+			// stop the restore process if cancellation has been requested
+			if restoreSession.Spec.Cancel {
+				return c.cancelRestoreSession(restoreSession)
+			}
 			// check whether restore session is completed or running and set it's phase accordingly
 			phase, err := c.getRestoreSessionPhase(restoreSession)
 
@@ -311,6 +325,7 @@ func (c *StashController) setRestoreSessionFailed(restoreSession *api_v1beta1.Re
 	if err != nil {
 		return err
 	}
+	c.notifyRestoreSession(restoreSession, api_v1beta1.NotificationRestoreSessionFailed, jobErr.Error())
 
 	// write failure event
 	_, err = eventer.CreateEvent(
@@ -325,6 +340,41 @@ func (c *StashController) setRestoreSessionFailed(restoreSession *api_v1beta1.Re
 	return err
 }
 
+// cancelRestoreSession stops the restore process of a RestoreSession whose cancellation has been requested.
+// For the job model, the restore job is deleted so that its pod gets terminated. The init-container
+// interrupts the running restore by itself and lets the workload start.
+func (c *StashController) cancelRestoreSession(restoreSession *api_v1beta1.RestoreSession) error {
+	for _, jobName := range []string{RestoreJobPrefix + restoreSession.Name, VolumeSnapshotPrefix + restoreSession.Name} {
+		err := c.kubeClient.BatchV1().Jobs(restoreSession.Namespace).Delete(jobName, meta.DeleteInBackground())
+		if err != nil && !kerr.IsNotFound(err) {
+			return err
+		}
+	}
+
+	// set RestoreSession phase to "Cancelled"
+	_, err := v1beta1_util.UpdateRestoreSessionStatus(c.stashClient.StashV1beta1(), restoreSession, func(in *api_v1beta1.RestoreSessionStatus) *api_v1beta1.RestoreSessionStatus {
+		in.Phase = api_v1beta1.RestoreSessionCancelled
+		return in
+	}, apis.EnableStatusSubresource)
+	if err != nil {
+		return err
+	}
+	message := fmt.Sprintf("restore has been cancelled for RestoreSession %s/%s", restoreSession.Namespace, restoreSession.Name)
+	c.notifyRestoreSession(restoreSession, api_v1beta1.NotificationRestoreSessionCancelled, message)
+
+	// write cancellation event
+	_, err = eventer.CreateEvent(
+		c.kubeClient,
+		eventer.EventSourceRestoreSessionController,
+		restoreSession,
+		core.EventTypeWarning,
+		eventer.EventReasonRestoreSessionCancelled,
+		message,
+	)
+
+	return err
+}
+
 func (c *StashController) setRestoreSessionUnknown(restoreSession *api_v1beta1.RestoreSession, jobErr error) error {
 
 	// set RestoreSession phase to "Unknown"
@@ -400,6 +450,8 @@ func (c *StashController) setRestoreSessionSucceeded(restoreSession *api_v1beta1
 	if err != nil {
 		return err
 	}
+	message := fmt.Sprintf("restore has been completed succesfully for RestoreSession %s/%s", restoreSession.Namespace, restoreSession.Name)
+	c.notifyRestoreSession(restoreSession, api_v1beta1.NotificationRestoreSessionSucceeded, message)
 
 	// write job creation success event
 	_, err = eventer.CreateEvent(
@@ -408,7 +460,7 @@ func (c *StashController) setRestoreSessionSucceeded(restoreSession *api_v1beta1
 		restoreSession,
 		core.EventTypeNormal,
 		eventer.EventReasonRestoreSessionSucceeded,
-		fmt.Sprintf("restore has been completed succesfully for RestoreSession %s/%s", restoreSession.Namespace, restoreSession.Name),
+		message,
 	)
 
 	return err
@@ -35,6 +35,7 @@ const (
 	EventReasonFailedSetup                   = "SetupFailed"
 	EventReasonAdmissionWebhookNotActivated  = "AdmissionWebhookNotActivated"
 	EventReasonInvalidBackupConfiguration    = "InvalidBackupConfiguration"
+	EventReasonBackupStale                   = "BackupStale"
 
 	EventReasonInvalidBackupSession    = "InvalidBackupSession"
 	EventReasonBackupSessionSucceeded  = "BackupSessionSucceeded"
@@ -43,27 +44,50 @@ const (
 	EventReasonBackupSessionJobCreated = "BackupSessionJobCreated"
 	EventReasonHostBackupSucceded      = "SuccessfulHostBackup"
 	EventReasonHostBackupFailed        = "FailedHostBackup"
+	EventReasonBackupSessionCancelled  = "BackupSessionCancelled"
+	EventReasonHostBackupCancelled     = "CancelledHostBackup"
+
+	// Repository events
+	EventReasonRepositoryMaintenanceSucceeded = "SuccessfulRepositoryMaintenance"
+	EventReasonRepositoryMaintenanceFailed    = "FailedRepositoryMaintenance"
+	EventReasonPasswordRotationSucceeded      = "SuccessfulPasswordRotation"
+	EventReasonPasswordRotationFailed         = "FailedPasswordRotation"
+	EventReasonSnapshotDownloaded             = "SnapshotDownloaded"
+	EventReasonSnapshotDeleted                = "SnapshotDeleted"
+	EventReasonSnapshotTagsUpdated            = "SnapshotTagsUpdated"
 
 	EventReasonInvalidRestoreSession   = "InvalidRestoreSession"
 	EventReasonRestoreSessionSucceeded = "RestoreSessionSucceeded"
 	EventReasonRestoreSessionFailed    = "RestoreSessionFailedToExecute"
 	EventReasonRestorePhaseUnknown     = "RestoreSession Phase Unknown"
 	EventReasonRestoreJobCreated       = "RestoreJobCreated"
+	EventReasonRestoreSessionCancelled = "RestoreSessionCancelled"
 
 	// RestoreSession events
 	EventReasonRestoreFailed        = "FailedRestoreSession"
 	EventReasonRestoreSucceded      = "SuccessfulRestoreSession"
 	EventReasonHostRestoreSucceeded = "SuccessfulHostRestore"
 	EventReasonHostRestoreFailed    = "FailedHostRestore"
+	EventReasonHostRestoreCancelled = "CancelledHostRestore"
+
+	// Notifier events
+	EventReasonNotificationFailed  = "NotificationFailed"
+	EventReasonNotificationDropped = "NotificationDropped"
 
 	// Event Sources
-	EventSourceBackupSessionController  = "BackupSession Controller"
-	EventSourceRestoreSessionController = "RestoreSession Controller"
-	EventSourceBackupSidecar            = "Backup Sidecar"
-	EventSourceRestoreInitContainer     = "Restore Init-Container"
-	EventSourceBackupTriggeringCronJob  = "Backup Triggering CronJob"
-	EventSourcePostBackupStatusUpdater  = "Post Backup Status Updater"
-	EventSourcePostRestoreStatusUpdater = "Post Restore Status Updater"
+	EventSourceBackupSessionController       = "BackupSession Controller"
+	EventSourceBackupConfigurationController = "BackupConfiguration Controller"
+	EventSourceRestoreSessionController      = "RestoreSession Controller"
+	EventSourceRepositoryController          = "Repository Controller"
+	EventSourceBackupSidecar                 = "Backup Sidecar"
+	EventSourceRestoreInitContainer          = "Restore Init-Container"
+	EventSourceBackupTriggeringCronJob       = "Backup Triggering CronJob"
+	EventSourcePostBackupStatusUpdater       = "Post Backup Status Updater"
+	EventSourcePostRestoreStatusUpdater      = "Post Restore Status Updater"
+	EventSourceRepositoryMaintenanceJob      = "Repository Maintenance Job"
+	EventSourcePasswordRotationJob           = "Password Rotation Job"
+	EventSourceSnapshotAPI                   = "Snapshot API"
+	EventSourceNotifier                      = "Notifier"
 
 	// Event Reasons
 	EventReasonBackupSkipped = "Backup Skipped"
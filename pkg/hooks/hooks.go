@@ -0,0 +1,219 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/appscode/go/log"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+const (
+	DefaultHookTimeout = 30 * time.Second
+	DefaultHTTPHost    = "localhost"
+)
+
+// HookExecutor executes the backup/restore hooks and keeps track of their results.
+type HookExecutor struct {
+	Config     *rest.Config
+	KubeClient kubernetes.Interface
+	// Namespace and PodName identify the pod where exec hooks are executed.
+	// If PodName is empty, exec hooks are executed locally inside the stash container.
+	Namespace string
+	PodName   string
+
+	// Stats holds the results of the hooks that have been executed so far
+	Stats []api_v1beta1.HookStats
+}
+
+// Execute runs the hook and records its result. It returns an error only if the hook has failed
+// and its failure policy does not allow to continue.
+func (e *HookExecutor) Execute(name string, hook *api_v1beta1.HookSpec) error {
+	if hook == nil {
+		return nil
+	}
+	failurePolicy := hook.FailurePolicy
+	if failurePolicy == "" {
+		failurePolicy = api_v1beta1.HookFailurePolicyAbort
+	}
+
+	log.Infof("Executing %s hook", name)
+	startTime := time.Now()
+	err := e.execute(hook)
+
+	stats := api_v1beta1.HookStats{
+		Name:          name,
+		Phase:         api_v1beta1.HookSucceeded,
+		FailurePolicy: failurePolicy,
+		Duration:      time.Since(startTime).Round(time.Millisecond).String(),
+	}
+	if err != nil {
+		stats.Phase = api_v1beta1.HookFailed
+		stats.Error = err.Error()
+	}
+	e.Stats = append(e.Stats, stats)
+
+	if err != nil {
+		if failurePolicy == api_v1beta1.HookFailurePolicyContinue {
+			log.Warningf("%s hook failed. Reason: %v. Continuing as failure policy is %q.", name, err, failurePolicy)
+			return nil
+		}
+		return fmt.Errorf("%s hook failed. Reason: %v", name, err)
+	}
+	log.Infof("%s hook has been executed successfully", name)
+	return nil
+}
+
+func (e *HookExecutor) execute(hook *api_v1beta1.HookSpec) error {
+	timeout := DefaultHookTimeout
+	if hook.TimeoutSeconds != nil {
+		timeout = time.Duration(*hook.TimeoutSeconds) * time.Second
+	}
+
+	switch {
+	case hook.Exec != nil && hook.HTTP != nil:
+		return fmt.Errorf("both exec and http are specified")
+	case hook.Exec != nil:
+		if len(hook.Exec.Command) == 0 {
+			return fmt.Errorf("no command specified for exec hook")
+		}
+		if e.PodName == "" {
+			return e.executeLocally(hook.Exec, timeout)
+		}
+		return e.executeInPod(hook.Exec, timeout)
+	case hook.HTTP != nil:
+		return e.executeHTTP(hook.HTTP, timeout)
+	default:
+		return fmt.Errorf("neither exec nor http is specified")
+	}
+}
+
+func (e *HookExecutor) executeLocally(hook *api_v1beta1.ExecHook, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, hook.Command[0], hook.Command[1:]...).CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %v", timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("%v, output: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (e *HookExecutor) executeInPod(hook *api_v1beta1.ExecHook, timeout time.Duration) error {
+	pod, err := e.KubeClient.CoreV1().Pods(e.Namespace).Get(e.PodName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	container := hook.Container
+	if container == "" {
+		container, err = defaultContainer(pod)
+		if err != nil {
+			return err
+		}
+	}
+
+	var (
+		execOut bytes.Buffer
+		execErr bytes.Buffer
+	)
+	req := e.KubeClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("exec")
+	req.VersionedParams(&core.PodExecOptions{
+		Container: container,
+		Command:   hook.Command,
+		Stdout:    true,
+		Stderr:    true,
+	}, metav1.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(e.Config, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to init executor: %v", err)
+	}
+
+	// remotecommand does not support cancellation. so, stop waiting for the command after timeout.
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- executor.Stream(remotecommand.StreamOptions{
+			Stdout: &execOut,
+			Stderr: &execErr,
+		})
+	}()
+	select {
+	case err = <-errCh:
+		if err != nil {
+			return fmt.Errorf("failed to execute command in container %q: %v, reason: %s", container, err, execErr.String())
+		}
+	case <-time.After(timeout):
+		return fmt.Errorf("timed out after %v", timeout)
+	}
+	log.Infof("Output of hook command: %s", execOut.String())
+	return nil
+}
+
+func (e *HookExecutor) executeHTTP(hook *api_v1beta1.HTTPHook, timeout time.Duration) error {
+	method := strings.ToUpper(hook.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+	if method != http.MethodGet && method != http.MethodPost {
+		return fmt.Errorf("unsupported http method %q", hook.Method)
+	}
+	scheme := strings.ToLower(string(hook.Scheme))
+	if scheme == "" {
+		scheme = "http"
+	}
+	host := hook.Host
+	if host == "" {
+		host = DefaultHTTPHost
+	}
+	url := fmt.Sprintf("%s://%s:%d/%s", scheme, host, hook.Port, strings.TrimPrefix(hook.Path, "/"))
+
+	req, err := http.NewRequest(method, url, strings.NewReader(hook.Body))
+	if err != nil {
+		return err
+	}
+	for _, h := range hook.HTTPHeaders {
+		req.Header.Add(h.Name, h.Value)
+	}
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("%s %s returned status %d, response: %s", method, url, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// defaultContainer returns the first container of the pod other than stash sidecar
+func defaultContainer(pod *core.Pod) (string, error) {
+	for _, c := range pod.Spec.Containers {
+		if c.Name != util.StashContainer {
+			return c.Name, nil
+		}
+	}
+	return "", fmt.Errorf("no application container found in pod %s/%s", pod.Namespace, pod.Name)
+}
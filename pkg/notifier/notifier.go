@@ -0,0 +1,309 @@
+package notifier
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/appscode/go/log"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	stash_listers_v1beta1 "stash.appscode.dev/stash/client/listers/stash/v1beta1"
+	"stash.appscode.dev/stash/pkg/eventer"
+)
+
+const (
+	// DefaultQueueSize is the number of notifications that can wait for delivery.
+	// New notifications are dropped while the queue is full.
+	DefaultQueueSize = 1000
+	// DefaultWorkers is the number of notifications delivered concurrently
+	DefaultWorkers = 5
+)
+
+// Notification describes an outcome of backup or restore. Its fields are available in the body template of a Notifier.
+type Notification struct {
+	Event               api_v1beta1.NotificationEvent `json:"event"`
+	Kind                string                        `json:"kind"`
+	Namespace           string                        `json:"namespace"`
+	Name                string                        `json:"name"`
+	BackupConfiguration string                        `json:"backupConfiguration,omitempty"`
+	Repository          string                        `json:"repository,omitempty"`
+	Message             string                        `json:"message,omitempty"`
+	Time                time.Time                     `json:"time"`
+}
+
+type delivery struct {
+	notifier     *api_v1beta1.Notifier
+	notification Notification
+	body         []byte
+}
+
+// Dispatcher delivers the notifications to the webhooks of the Notifiers. The notifications are delivered
+// asynchronously so that an unresponsive webhook does not block the controllers.
+type Dispatcher struct {
+	kubeClient     kubernetes.Interface
+	notifierLister stash_listers_v1beta1.NotifierLister
+	queue          chan delivery
+
+	lock     sync.Mutex
+	limiters map[string]*rateLimiter
+}
+
+func NewDispatcher(kubeClient kubernetes.Interface, notifierLister stash_listers_v1beta1.NotifierLister) *Dispatcher {
+	return &Dispatcher{
+		kubeClient:     kubeClient,
+		notifierLister: notifierLister,
+		queue:          make(chan delivery, DefaultQueueSize),
+		limiters:       make(map[string]*rateLimiter),
+	}
+}
+
+// Run starts the workers that deliver the notifications. It blocks until stopCh is closed.
+func (d *Dispatcher) Run(workers int, stopCh <-chan struct{}) {
+	for i := 0; i < workers; i++ {
+		go func() {
+			for {
+				select {
+				case item := <-d.queue:
+					d.deliver(item, stopCh)
+				case <-stopCh:
+					return
+				}
+			}
+		}()
+	}
+	<-stopCh
+}
+
+// Notify sends the notification to the referred Notifiers that subscribe to its event.
+// It does not wait for the delivery. Failures are reported as events of the Notifier.
+func (d *Dispatcher) Notify(namespace string, refs []core.LocalObjectReference, n Notification) {
+	if n.Time.IsZero() {
+		n.Time = time.Now()
+	}
+	notified := make(map[string]bool)
+	for _, ref := range refs {
+		if notified[ref.Name] {
+			continue
+		}
+		notified[ref.Name] = true
+
+		notifier, err := d.notifierLister.Notifiers(namespace).Get(ref.Name)
+		if err != nil {
+			log.Warningf("failed to get Notifier %s/%s for %s event of %s %s/%s. Reason: %v",
+				namespace, ref.Name, n.Event, n.Kind, n.Namespace, n.Name, err)
+			continue
+		}
+		if !notifier.Subscribes(n.Event) {
+			continue
+		}
+		if err = notifier.IsValid(); err != nil {
+			d.writeEvent(notifier, eventer.EventReasonNotificationFailed, err.Error())
+			continue
+		}
+		if !d.allow(notifier) {
+			d.writeEvent(notifier, eventer.EventReasonNotificationDropped,
+				fmt.Sprintf("%s notification of %s %s/%s has been dropped due to rate limit", n.Event, n.Kind, n.Namespace, n.Name))
+			continue
+		}
+		body, err := renderBody(notifier, n)
+		if err != nil {
+			d.writeEvent(notifier, eventer.EventReasonNotificationFailed,
+				fmt.Sprintf("failed to render %s notification of %s %s/%s. Reason: %v", n.Event, n.Kind, n.Namespace, n.Name, err))
+			continue
+		}
+
+		select {
+		case d.queue <- delivery{notifier: notifier.DeepCopy(), notification: n, body: body}:
+		default:
+			d.writeEvent(notifier, eventer.EventReasonNotificationDropped,
+				fmt.Sprintf("%s notification of %s %s/%s has been dropped as too many notifications are waiting for delivery", n.Event, n.Kind, n.Namespace, n.Name))
+		}
+	}
+}
+
+func renderBody(notifier *api_v1beta1.Notifier, n Notification) ([]byte, error) {
+	tpl, err := notifier.Spec.Webhook.BodyTemplate()
+	if err != nil {
+		return nil, err
+	}
+	if tpl == nil {
+		return json.Marshal(n)
+	}
+	var buf bytes.Buffer
+	if err = tpl.Execute(&buf, n); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// deliver sends the notification to the webhook. A failed request is retried with exponential backoff
+// unless the webhook has rejected the notification.
+func (d *Dispatcher) deliver(item delivery, stopCh <-chan struct{}) {
+	backoff := item.notifier.Backoff()
+	maxRetries := item.notifier.MaxRetries()
+	for attempt := 0; ; attempt++ {
+		retriable, err := d.send(item)
+		if err == nil {
+			return
+		}
+		if !retriable || attempt >= maxRetries {
+			n := item.notification
+			d.writeEvent(item.notifier, eventer.EventReasonNotificationFailed,
+				fmt.Sprintf("failed to send %s notification of %s %s/%s after %d attempt(s). Reason: %v", n.Event, n.Kind, n.Namespace, n.Name, attempt+1, err))
+			return
+		}
+		select {
+		case <-time.After(backoff):
+		case <-stopCh:
+			return
+		}
+		backoff *= 2
+	}
+}
+
+// send makes a single request to the webhook. It returns whether the request should be retried in case of failure.
+func (d *Dispatcher) send(item delivery) (bool, error) {
+	notifier := item.notifier
+	webhook := notifier.Spec.Webhook
+
+	target := webhook.URL
+	if webhook.URLFrom != nil {
+		secret, retriable, err := d.getSecret(notifier.Namespace, webhook.URLFrom.Name)
+		if err != nil {
+			return retriable, err
+		}
+		data, found := secret.Data[webhook.URLFrom.Key]
+		if !found {
+			return false, fmt.Errorf("key %q not found in Secret %s/%s", webhook.URLFrom.Key, notifier.Namespace, webhook.URLFrom.Name)
+		}
+		target = strings.TrimSpace(string(data))
+	}
+
+	method := strings.ToUpper(webhook.Method)
+	if method == "" {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequest(method, target, bytes.NewReader(item.body))
+	if err != nil {
+		return false, hideURL(err)
+	}
+	contentType := webhook.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range webhook.Headers {
+		req.Header.Set(k, v)
+	}
+	if webhook.HeadersFrom != nil {
+		secret, retriable, err := d.getSecret(notifier.Namespace, webhook.HeadersFrom.Name)
+		if err != nil {
+			return retriable, err
+		}
+		for k, v := range secret.Data {
+			req.Header.Set(k, strings.TrimSpace(string(v)))
+		}
+	}
+
+	timeout := api_v1beta1.DefaultNotificationTimeout
+	if webhook.Timeout != nil {
+		timeout = webhook.Timeout.Duration
+	}
+	client := &http.Client{Timeout: timeout}
+	if webhook.InsecureSkipTLSVerify {
+		client.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, hideURL(err)
+	}
+	defer resp.Body.Close()
+
+	// the response is not included in the error as the events of the Notifier must not expose
+	// the responses of arbitrary endpoints that the operator can reach.
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		// the webhook won't accept the same notification later unless it is overloaded or failing
+		retriable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return retriable, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return false, nil
+}
+
+// getSecret returns a Secret referred by a Notifier. Only the Secrets labeled for Notifiers are returned so that
+// a Notifier can't be used to read the other Secrets of the namespace. It also returns whether the failure is temporary.
+func (d *Dispatcher) getSecret(namespace, name string) (*core.Secret, bool, error) {
+	secret, err := d.kubeClient.CoreV1().Secrets(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, !kerr.IsNotFound(err), err
+	}
+	if secret.Labels[api_v1beta1.LabelNotifierSecret] != "true" {
+		return nil, false, fmt.Errorf("secret %s/%s can't be used by a Notifier as it does not have label %s=true", namespace, name, api_v1beta1.LabelNotifierSecret)
+	}
+	return secret, false, nil
+}
+
+// hideURL removes the url from the error. The url may contain credentials. So, it must not be exposed in the events.
+func hideURL(err error) error {
+	if urlErr, ok := err.(*url.Error); ok {
+		return fmt.Errorf("%s request failed. Reason: %v", urlErr.Op, urlErr.Err)
+	}
+	return err
+}
+
+func (d *Dispatcher) writeEvent(notifier *api_v1beta1.Notifier, reason, message string) {
+	log.Warningf("Notifier %s/%s: %s", notifier.Namespace, notifier.Name, message)
+	_, err := eventer.CreateEvent(d.kubeClient, eventer.EventSourceNotifier, notifier, core.EventTypeWarning, reason, message)
+	if err != nil {
+		log.Errorf("failed to write event for Notifier %s/%s. Reason: %v", notifier.Namespace, notifier.Name, err)
+	}
+}
+
+// rateLimiter allows at most limit notifications in any period. It remembers the time of the recent notifications.
+type rateLimiter struct {
+	spec api_v1beta1.NotificationRateLimit
+	sent []time.Time
+}
+
+func (d *Dispatcher) allow(notifier *api_v1beta1.Notifier) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	key := notifier.Namespace + "/" + notifier.Name
+	if notifier.Spec.RateLimit == nil {
+		delete(d.limiters, key)
+		return true
+	}
+	l, found := d.limiters[key]
+	if !found || l.spec != *notifier.Spec.RateLimit {
+		// start over if the limit has been changed
+		l = &rateLimiter{spec: *notifier.Spec.RateLimit}
+		d.limiters[key] = l
+	}
+
+	now := time.Now()
+	recent := l.sent[:0]
+	for _, t := range l.sent {
+		if now.Sub(t) < l.spec.Period.Duration {
+			recent = append(recent, t)
+		}
+	}
+	l.sent = recent
+	if int32(len(l.sent)) >= l.spec.Limit {
+		return false
+	}
+	l.sent = append(l.sent, now)
+	return true
+}
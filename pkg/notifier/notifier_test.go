@@ -0,0 +1,97 @@
+package notifier
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+)
+
+func newTestNotifier(rateLimit *api_v1beta1.NotificationRateLimit) *api_v1beta1.Notifier {
+	return &api_v1beta1.Notifier{
+		ObjectMeta: metav1.ObjectMeta{Name: "slack", Namespace: "demo"},
+		Spec:       api_v1beta1.NotifierSpec{RateLimit: rateLimit},
+	}
+}
+
+func TestDispatcherAllow(t *testing.T) {
+	limit := func(n int32, period time.Duration) *api_v1beta1.NotificationRateLimit {
+		return &api_v1beta1.NotificationRateLimit{Limit: n, Period: metav1.Duration{Duration: period}}
+	}
+	testCases := []struct {
+		name    string
+		limits  []*api_v1beta1.NotificationRateLimit
+		allowed []bool
+	}{
+		{name: "no rate limit", limits: []*api_v1beta1.NotificationRateLimit{nil, nil, nil}, allowed: []bool{true, true, true}},
+		{name: "limit reached", limits: []*api_v1beta1.NotificationRateLimit{limit(2, time.Hour), limit(2, time.Hour), limit(2, time.Hour)}, allowed: []bool{true, true, false}},
+		{name: "limit changed", limits: []*api_v1beta1.NotificationRateLimit{limit(1, time.Hour), limit(1, time.Hour), limit(2, time.Hour)}, allowed: []bool{true, false, true}},
+		{name: "period elapsed", limits: []*api_v1beta1.NotificationRateLimit{limit(1, time.Nanosecond), limit(1, time.Nanosecond)}, allowed: []bool{true, true}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewDispatcher(fake.NewSimpleClientset(), nil)
+			var allowed []bool
+			for _, l := range tc.limits {
+				allowed = append(allowed, d.allow(newTestNotifier(l)))
+				time.Sleep(time.Millisecond)
+			}
+			assert.Equal(t, tc.allowed, allowed)
+		})
+	}
+}
+
+func TestDispatcherGetSecret(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(
+		&core.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "labeled", Namespace: "demo", Labels: map[string]string{api_v1beta1.LabelNotifierSecret: "true"}},
+		},
+		&core.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "unlabeled", Namespace: "demo"},
+		},
+	)
+	d := NewDispatcher(kubeClient, nil)
+
+	testCases := []struct {
+		name      string
+		secret    string
+		valid     bool
+		retriable bool
+	}{
+		{name: "labeled secret", secret: "labeled", valid: true},
+		{name: "unlabeled secret", secret: "unlabeled", valid: false},
+		{name: "missing secret", secret: "missing", valid: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			secret, retriable, err := d.getSecret("demo", tc.secret)
+			assert.Equal(t, tc.valid, err == nil)
+			assert.Equal(t, tc.valid, secret != nil)
+			assert.Equal(t, tc.retriable, retriable)
+		})
+	}
+}
+
+func TestDispatcherSendHidesResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("internal response"))
+	}))
+	defer server.Close()
+
+	notifier := newTestNotifier(nil)
+	notifier.Spec.Webhook = api_v1beta1.WebhookNotifier{URL: server.URL}
+	d := NewDispatcher(fake.NewSimpleClientset(), nil)
+	retriable, err := d.send(delivery{notifier: notifier, body: []byte("{}")})
+	assert.True(t, retriable)
+	if assert.Error(t, err) {
+		assert.False(t, strings.Contains(err.Error(), "internal response"))
+	}
+}
@@ -0,0 +1,225 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	authorization "k8s.io/api/authorization/v1"
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/authentication/user"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"stash.appscode.dev/stash/apis/repositories"
+	repov1alpha1 "stash.appscode.dev/stash/apis/repositories/v1alpha1"
+	stash "stash.appscode.dev/stash/apis/stash/v1alpha1"
+)
+
+const (
+	// authorizationCacheTTL is the time during which the decision of a SubjectAccessReview is reused for the same user.
+	// It is kept short so that the changes of the RBAC rules take effect almost immediately.
+	authorizationCacheTTL = 10 * time.Second
+	// maxCachedAuthorizations is the maximum number of cached decisions. The expired ones are removed when it is exceeded.
+	maxCachedAuthorizations = 10000
+	// maxConcurrentAuthorization is the maximum number of repositories that are authorized at a time
+	maxConcurrentAuthorization = 5
+)
+
+// Verbs checked against the Repository of a snapshot. Reading a snapshot requires the permission to get the Repository.
+// Deleting or modifying a snapshot modifies the restic repository. So, it requires the permission to update the Repository.
+const (
+	repositoryVerbRead  = "get"
+	repositoryVerbWrite = "update"
+)
+
+// authorize checks whether the requesting user is allowed to access the Repository with the verb and to read its
+// storage secret. The snapshots are read using the operator's credentials. So, without this check anyone allowed to
+// access snapshots would be able to read the data of any Repository of the namespace.
+func (r *REST) authorize(ctx context.Context, repository *stash.Repository, verb string) error {
+	u, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return apierrors.NewForbidden(repositories.Resource(repov1alpha1.ResourcePluralSnapshot), "", fmt.Errorf("no user found in the request"))
+	}
+
+	checks := []authorization.ResourceAttributes{
+		{
+			Namespace: repository.Namespace,
+			Verb:      verb,
+			Group:     stash.SchemeGroupVersion.Group,
+			Resource:  stash.ResourcePluralRepository,
+			Name:      repository.Name,
+		},
+		{
+			Namespace: repository.Namespace,
+			Verb:      "get",
+			Group:     core.GroupName,
+			Resource:  "secrets",
+			Name:      repository.Spec.Backend.StorageSecretName,
+		},
+	}
+	for i := range checks {
+		allowed, reason, err := r.subjectAccessReview(u, &checks[i])
+		if err != nil {
+			return apierrors.NewInternalError(err)
+		}
+		if !allowed {
+			msg := fmt.Sprintf("user %q cannot %s %s %q", u.GetName(), checks[i].Verb, checks[i].Resource, checks[i].Name)
+			if reason != "" {
+				msg += ": " + reason
+			}
+			return apierrors.NewForbidden(stash.Resource(stash.ResourceSingularRepository), repository.Name, fmt.Errorf("%s", msg))
+		}
+	}
+	return nil
+}
+
+// subjectAccessReview checks whether the user is allowed to access the resource. The decision is cached for
+// authorizationCacheTTL as listing snapshots authorizes every Repository of the namespace on each request.
+func (r *REST) subjectAccessReview(u user.Info, attr *authorization.ResourceAttributes) (bool, string, error) {
+	key := authorizationKey(u, attr)
+	if decision, found := r.authzCache.get(key); found {
+		return decision.allowed, decision.reason, nil
+	}
+	allowed, reason, err := r.createSubjectAccessReview(u, attr)
+	if err != nil {
+		return false, "", err
+	}
+	r.authzCache.set(key, authorizationDecision{allowed: allowed, reason: reason})
+	return allowed, reason, nil
+}
+
+func (r *REST) createSubjectAccessReview(u user.Info, attr *authorization.ResourceAttributes) (bool, string, error) {
+	extra := make(map[string]authorization.ExtraValue)
+	for k, v := range u.GetExtra() {
+		extra[k] = authorization.ExtraValue(v)
+	}
+	review, err := r.kubeClient.AuthorizationV1().SubjectAccessReviews().Create(&authorization.SubjectAccessReview{
+		Spec: authorization.SubjectAccessReviewSpec{
+			ResourceAttributes: attr,
+			User:               u.GetName(),
+			Groups:             u.GetGroups(),
+			UID:                u.GetUID(),
+			Extra:              extra,
+		},
+	})
+	if err != nil {
+		return false, "", err
+	}
+	return review.Status.Allowed, review.Status.Reason, nil
+}
+
+// authorizedRepositories returns the repositories the requesting user is allowed to access with the verb.
+// It fails if the user is not allowed to access any of the repositories.
+// The repositories are authorized by at most maxConcurrentAuthorization workers.
+func (r *REST) authorizedRepositories(ctx context.Context, repos []stash.Repository, verb string) ([]stash.Repository, error) {
+	errs := make([]error, len(repos))
+
+	workers := maxConcurrentAuthorization
+	if len(repos) < workers {
+		workers = len(repos)
+	}
+	queue := make(chan int, len(repos))
+	for i := range repos {
+		queue <- i
+	}
+	close(queue)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				errs[i] = r.authorize(ctx, &repos[i], verb)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var allowed []stash.Repository
+	var lastErr error
+	for i, err := range errs {
+		if err != nil {
+			if !apierrors.IsForbidden(err) {
+				return nil, err
+			}
+			lastErr = err
+			continue
+		}
+		allowed = append(allowed, repos[i])
+	}
+	if len(allowed) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return allowed, nil
+}
+
+type authorizationDecision struct {
+	allowed bool
+	reason  string
+	expiry  time.Time
+}
+
+// authorizationCache caches the decisions of the SubjectAccessReviews for a short time
+type authorizationCache struct {
+	lock      sync.RWMutex
+	ttl       time.Duration
+	decisions map[string]authorizationDecision
+}
+
+func newAuthorizationCache(ttl time.Duration) *authorizationCache {
+	return &authorizationCache{
+		ttl:       ttl,
+		decisions: make(map[string]authorizationDecision),
+	}
+}
+
+// authorizationKey identifies the decision of a SubjectAccessReview for the user and the resource
+func authorizationKey(u user.Info, attr *authorization.ResourceAttributes) string {
+	groups := append([]string(nil), u.GetGroups()...)
+	sort.Strings(groups)
+	extra := make([]string, 0, len(u.GetExtra()))
+	for k, v := range u.GetExtra() {
+		extra = append(extra, k+"="+strings.Join(v, ","))
+	}
+	sort.Strings(extra)
+	return strings.Join([]string{
+		u.GetName(), u.GetUID(), strings.Join(groups, ","), strings.Join(extra, ";"),
+		attr.Namespace, attr.Verb, attr.Group, attr.Resource, attr.Name,
+	}, "/")
+}
+
+func (c *authorizationCache) get(key string) (authorizationDecision, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	decision, found := c.decisions[key]
+	if !found || time.Now().After(decision.expiry) {
+		return authorizationDecision{}, false
+	}
+	return decision, true
+}
+
+func (c *authorizationCache) set(key string, decision authorizationDecision) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := time.Now()
+	if len(c.decisions) >= maxCachedAuthorizations {
+		for k, d := range c.decisions {
+			if now.After(d.expiry) {
+				delete(c.decisions, k)
+			}
+		}
+	}
+	decision.expiry = now.Add(c.ttl)
+	c.decisions[key] = decision
+}
+
+func requestingUser(ctx context.Context) string {
+	if u, ok := apirequest.UserFrom(ctx); ok {
+		return u.GetName()
+	}
+	return "unknown"
+}
@@ -0,0 +1,121 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	authorization "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/kubernetes/fake"
+	core_testing "k8s.io/client-go/testing"
+	store "kmodules.xyz/objectstore-api/api/v1"
+	stash "stash.appscode.dev/stash/apis/stash/v1alpha1"
+)
+
+// newAuthorizingREST returns a REST whose SubjectAccessReviews deny the access to the resources named with
+// "private" prefix. The number of SubjectAccessReviews created is counted in reviews.
+func newAuthorizingREST(reviews *int32) *REST {
+	kubeClient := fake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action core_testing.Action) (bool, runtime.Object, error) {
+		atomic.AddInt32(reviews, 1)
+		review := action.(core_testing.CreateAction).GetObject().(*authorization.SubjectAccessReview)
+		review.Status.Allowed = !strings.HasPrefix(review.Spec.ResourceAttributes.Name, "private")
+		return true, review, nil
+	})
+	return &REST{
+		kubeClient: kubeClient,
+		authzCache: newAuthorizationCache(time.Minute),
+	}
+}
+
+func newRepositories(names ...string) []stash.Repository {
+	repos := make([]stash.Repository, 0, len(names))
+	for _, name := range names {
+		repos = append(repos, stash.Repository{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "demo"},
+			Spec: stash.RepositorySpec{
+				Backend: store.Backend{StorageSecretName: name + "-secret"},
+			},
+		})
+	}
+	return repos
+}
+
+func TestAuthorizedRepositories(t *testing.T) {
+	ctx := apirequest.WithUser(context.Background(), &user.DefaultInfo{Name: "alice", Groups: []string{"dev"}})
+
+	testCases := []struct {
+		name      string
+		repos     []string
+		allowed   []string
+		forbidden bool
+	}{
+		{name: "all allowed", repos: []string{"repo-1", "repo-2", "repo-3"}, allowed: []string{"repo-1", "repo-2", "repo-3"}},
+		{name: "some allowed", repos: []string{"private-1", "repo-1", "private-2", "repo-2", "repo-3", "repo-4", "repo-5"}, allowed: []string{"repo-1", "repo-2", "repo-3", "repo-4", "repo-5"}},
+		{name: "none allowed", repos: []string{"private-1", "private-2"}, forbidden: true},
+		{name: "no repository", repos: []string{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var reviews int32
+			r := newAuthorizingREST(&reviews)
+			repos, err := r.authorizedRepositories(ctx, newRepositories(tc.repos...), repositoryVerbRead)
+			if tc.forbidden {
+				assert.True(t, apierrors.IsForbidden(err), fmt.Sprintf("error: %v", err))
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			var allowed []string
+			for _, repo := range repos {
+				allowed = append(allowed, repo.Name)
+			}
+			assert.Equal(t, tc.allowed, allowed)
+		})
+	}
+}
+
+func TestAuthorizationCache(t *testing.T) {
+	var reviews int32
+	r := newAuthorizingREST(&reviews)
+	repos := newRepositories("repo-1", "repo-2")
+
+	alice := apirequest.WithUser(context.Background(), &user.DefaultInfo{Name: "alice"})
+	_, err := r.authorizedRepositories(alice, repos, repositoryVerbRead)
+	assert.NoError(t, err)
+	// the repository and its secret are checked for each repository
+	assert.Equal(t, int32(4), atomic.LoadInt32(&reviews))
+
+	// the decisions are reused for the same user and verb
+	_, err = r.authorizedRepositories(alice, repos, repositoryVerbRead)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&reviews))
+
+	// the decisions are not shared with another verb or user
+	_, err = r.authorizedRepositories(alice, repos, repositoryVerbWrite)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(6), atomic.LoadInt32(&reviews))
+	bob := apirequest.WithUser(context.Background(), &user.DefaultInfo{Name: "bob"})
+	_, err = r.authorizedRepositories(bob, repos, repositoryVerbRead)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(10), atomic.LoadInt32(&reviews))
+
+	// the decisions are reviewed again once expired
+	r.authzCache.ttl = 0
+	r.authzCache.decisions = make(map[string]authorizationDecision)
+	_, err = r.authorizedRepositories(alice, repos, repositoryVerbRead)
+	assert.NoError(t, err)
+	_, err = r.authorizedRepositories(alice, repos, repositoryVerbRead)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(18), atomic.LoadInt32(&reviews))
+}
@@ -0,0 +1,204 @@
+package snapshot
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/appscode/go/log"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"stash.appscode.dev/stash/apis/repositories"
+	stash "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	stashinformers "stash.appscode.dev/stash/client/informers/externalversions"
+	v1beta1_listers "stash.appscode.dev/stash/client/listers/stash/v1beta1"
+)
+
+const (
+	// DefaultSnapshotCacheTTL is the time after which the cached snapshots of a repository are listed again.
+	// The snapshots taken by a BackupSession are visible earlier as the cache is invalidated when it completes.
+	DefaultSnapshotCacheTTL = 5 * time.Minute
+	// cacheInvalidatorResyncPeriod is the resync period of the BackupSession informer used to invalidate the cache
+	cacheInvalidatorResyncPeriod = 10 * time.Minute
+	// maxCachedSnapshotStats is the maximum number of snapshots whose stats are cached.
+	// The stats cached first are evicted when the limit is exceeded.
+	maxCachedSnapshotStats = 10000
+)
+
+type snapshotCacheEntry struct {
+	snapshots []repositories.Snapshot
+	// generation of the Repository when the snapshots have been listed. the backend may have been changed if it differs.
+	generation int64
+	expiry     time.Time
+}
+
+type snapshotStats struct {
+	size      uint64
+	fileCount int64
+}
+
+type snapshotStatsEntry struct {
+	uid  types.UID
+	stat snapshotStats
+}
+
+// snapshotCache caches the snapshots of each repository so that listing snapshots does not run restic every time.
+// It also caches the size and the number of files of each snapshot which never change during the lifetime of a snapshot.
+type snapshotCache struct {
+	lock    sync.RWMutex
+	ttl     time.Duration
+	entries map[string]snapshotCacheEntry
+	// stats holds the elements of statsOrder which keeps the snapshotStatsEntry in the order they have been cached
+	stats         map[types.UID]*list.Element
+	statsOrder    *list.List
+	maxStatsCount int
+}
+
+func newSnapshotCache(ttl time.Duration) *snapshotCache {
+	return &snapshotCache{
+		ttl:           ttl,
+		entries:       make(map[string]snapshotCacheEntry),
+		stats:         make(map[types.UID]*list.Element),
+		statsOrder:    list.New(),
+		maxStatsCount: maxCachedSnapshotStats,
+	}
+}
+
+func cacheKey(namespace, repoName string) string {
+	return namespace + "/" + repoName
+}
+
+// get returns a copy of the cached snapshots of the repository. It returns false if the entry is missing or stale.
+func (c *snapshotCache) get(repository *stash.Repository) ([]repositories.Snapshot, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	entry, found := c.entries[cacheKey(repository.Namespace, repository.Name)]
+	if !found || entry.generation != repository.Generation || time.Now().After(entry.expiry) {
+		return nil, false
+	}
+	snapshots := make([]repositories.Snapshot, len(entry.snapshots))
+	for i := range entry.snapshots {
+		entry.snapshots[i].DeepCopyInto(&snapshots[i])
+	}
+	return snapshots, true
+}
+
+func (c *snapshotCache) set(repository *stash.Repository, snapshots []repositories.Snapshot) {
+	entry := snapshotCacheEntry{
+		snapshots:  make([]repositories.Snapshot, len(snapshots)),
+		generation: repository.Generation,
+		expiry:     time.Now().Add(c.ttl),
+	}
+	for i := range snapshots {
+		snapshots[i].DeepCopyInto(&entry.snapshots[i])
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries[cacheKey(repository.Namespace, repository.Name)] = entry
+}
+
+// invalidate removes the cached snapshots of a repository
+func (c *snapshotCache) invalidate(namespace, repoName string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.entries, cacheKey(namespace, repoName))
+}
+
+// invalidateNamespace removes the cached snapshots of all repositories of a namespace
+func (c *snapshotCache) invalidateNamespace(namespace string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for key := range c.entries {
+		if strings.HasPrefix(key, namespace+"/") {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *snapshotCache) getStats(uid types.UID) (snapshotStats, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	elem, found := c.stats[uid]
+	if !found {
+		return snapshotStats{}, false
+	}
+	return elem.Value.(snapshotStatsEntry).stat, true
+}
+
+// setStats caches the stats of a snapshot. The oldest entry is evicted if the cache is full.
+func (c *snapshotCache) setStats(uid types.UID, stat snapshotStats) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if elem, found := c.stats[uid]; found {
+		elem.Value = snapshotStatsEntry{uid: uid, stat: stat}
+		return
+	}
+	c.stats[uid] = c.statsOrder.PushBack(snapshotStatsEntry{uid: uid, stat: stat})
+	for c.statsOrder.Len() > c.maxStatsCount {
+		oldest := c.statsOrder.Front()
+		c.statsOrder.Remove(oldest)
+		delete(c.stats, oldest.Value.(snapshotStatsEntry).uid)
+	}
+}
+
+func (c *snapshotCache) deleteStats(uid types.UID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if elem, found := c.stats[uid]; found {
+		c.statsOrder.Remove(elem)
+		delete(c.stats, uid)
+	}
+}
+
+// RunCacheInvalidator watches the BackupSessions and invalidates the cached snapshots of a Repository when a
+// BackupSession that backs up into it completes. It blocks until stopCh is closed.
+func (r *REST) RunCacheInvalidator(stopCh <-chan struct{}) {
+	factory := stashinformers.NewSharedInformerFactory(r.stashClient, cacheInvalidatorResyncPeriod)
+	bcLister := factory.Stash().V1beta1().BackupConfigurations().Lister()
+	factory.Stash().V1beta1().BackupSessions().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldSession, ok := oldObj.(*api_v1beta1.BackupSession)
+			if !ok {
+				return
+			}
+			newSession, ok := newObj.(*api_v1beta1.BackupSession)
+			if !ok {
+				return
+			}
+			if oldSession.Status.Phase != newSession.Status.Phase && isBackupSessionCompleted(newSession) {
+				r.invalidateCacheForBackupSession(bcLister, newSession)
+			}
+		},
+	})
+	factory.Start(stopCh)
+	for t, synced := range factory.WaitForCacheSync(stopCh) {
+		if !synced {
+			log.Errorf("failed to sync informer cache for %v", t)
+		}
+	}
+	<-stopCh
+}
+
+func isBackupSessionCompleted(session *api_v1beta1.BackupSession) bool {
+	switch session.Status.Phase {
+	// a failed or cancelled session may have taken the snapshots of some hosts
+	case api_v1beta1.BackupSessionSucceeded, api_v1beta1.BackupSessionFailed, api_v1beta1.BackupSessionCancelled:
+		return true
+	}
+	return false
+}
+
+// invalidateCacheForBackupSession invalidates the cached snapshots of the Repository of the BackupConfiguration of
+// the BackupSession. All repositories of the namespace are invalidated if the BackupConfiguration is not found.
+func (r *REST) invalidateCacheForBackupSession(bcLister v1beta1_listers.BackupConfigurationLister, session *api_v1beta1.BackupSession) {
+	bc, err := bcLister.BackupConfigurations(session.Namespace).Get(session.Spec.BackupConfiguration.Name)
+	if err != nil {
+		r.cache.invalidateNamespace(session.Namespace)
+		return
+	}
+	r.cache.invalidate(session.Namespace, bc.Spec.Repository.Name)
+}
@@ -0,0 +1,40 @@
+package snapshot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestSnapshotStatsCache(t *testing.T) {
+	c := newSnapshotCache(time.Minute)
+	c.maxStatsCount = 2
+
+	c.setStats("a", snapshotStats{size: 1})
+	c.setStats("b", snapshotStats{size: 2})
+	// updating an entry doesn't evict any entry
+	c.setStats("a", snapshotStats{size: 10})
+	// the oldest entry is evicted when the cache is full
+	c.setStats("c", snapshotStats{size: 3})
+	c.deleteStats("b")
+
+	testCases := []struct {
+		uid   types.UID
+		found bool
+		size  uint64
+	}{
+		{uid: "a", found: false},
+		{uid: "b", found: false},
+		{uid: "c", found: true, size: 3},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.uid), func(t *testing.T) {
+			stat, found := c.getStats(tc.uid)
+			assert.Equal(t, tc.found, found)
+			assert.Equal(t, tc.size, stat.size)
+		})
+	}
+	assert.Equal(t, 1, c.statsOrder.Len())
+}
@@ -0,0 +1,120 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"stash.appscode.dev/stash/apis/repositories"
+	repov1alpha1 "stash.appscode.dev/stash/apis/repositories/v1alpha1"
+	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+// DiffREST serves "diff" subresource of Snapshot. It shows the changes between the snapshot
+// and an older snapshot of the same repository.
+type DiffREST struct {
+	snapshot *REST
+}
+
+var _ rest.GetterWithOptions = &DiffREST{}
+var _ rest.GroupVersionKindProvider = &DiffREST{}
+
+func NewDiffREST(snapshot *REST) *DiffREST {
+	return &DiffREST{
+		snapshot: snapshot,
+	}
+}
+
+func (r *DiffREST) New() runtime.Object {
+	return &repositories.SnapshotDiff{}
+}
+
+func (r *DiffREST) GroupVersionKind(containingGV schema.GroupVersion) schema.GroupVersionKind {
+	return repov1alpha1.SchemeGroupVersion.WithKind(repov1alpha1.ResourceKindSnapshotDiff)
+}
+
+func (r *DiffREST) NewGetOptions() (runtime.Object, bool, string) {
+	return &repositories.SnapshotDiffOptions{}, false, ""
+}
+
+func (r *DiffREST) Get(ctx context.Context, name string, options runtime.Object) (runtime.Object, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing namespace")
+	}
+	opts, ok := options.(*repositories.SnapshotDiffOptions)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid options object: %#v", options))
+	}
+	if opts.Against == "" {
+		return nil, apierrors.NewBadRequest("name of the snapshot to compare against is not provided")
+	}
+
+	repo, snapshotId, err := r.snapshot.getRepositoryForSnapshot(ctx, ns, name, repositoryVerbRead)
+	if err != nil {
+		return nil, err
+	}
+	againstRepoName, againstSnapshotId, err := util.GetRepoNameAndSnapshotID(opts.Against)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	if againstRepoName != repo.Name {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("snapshot %s does not belong to Repository %s", opts.Against, repo.Name))
+	}
+
+	diff, found, err := r.snapshot.diffSnapshots(repo, againstSnapshotId, snapshotId)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if !found {
+		return nil, apierrors.NewNotFound(repositories.Resource(repov1alpha1.ResourceSingularSnapshot), fmt.Sprintf("%s or %s", opts.Against, name))
+	}
+	return toSnapshotDiff(opts.Against, name, diff), nil
+}
+
+var diffChanges = map[string]repositories.SnapshotDiffChange{
+	restic.DiffAdded:           repositories.SnapshotDiffAdded,
+	restic.DiffRemoved:         repositories.SnapshotDiffRemoved,
+	restic.DiffModified:        repositories.SnapshotDiffModified,
+	restic.DiffTypeChanged:     repositories.SnapshotDiffTypeChanged,
+	restic.DiffMetadataChanged: repositories.SnapshotDiffMetadataChanged,
+}
+
+func toSnapshotDiff(from, to string, diff *restic.SnapshotDiff) *repositories.SnapshotDiff {
+	out := &repositories.SnapshotDiff{
+		From: from,
+		To:   to,
+		Stats: repositories.SnapshotDiffStats{
+			FilesAdded:       diff.Stats.FilesAdded,
+			FilesRemoved:     diff.Stats.FilesRemoved,
+			FilesChanged:     diff.Stats.FilesChanged,
+			DirsAdded:        diff.Stats.DirsAdded,
+			DirsRemoved:      diff.Stats.DirsRemoved,
+			OthersAdded:      diff.Stats.OthersAdded,
+			OthersRemoved:    diff.Stats.OthersRemoved,
+			DataBlobsAdded:   diff.Stats.DataBlobsAdded,
+			DataBlobsRemoved: diff.Stats.DataBlobsRemoved,
+			TreeBlobsAdded:   diff.Stats.TreeBlobsAdded,
+			TreeBlobsRemoved: diff.Stats.TreeBlobsRemoved,
+			BytesAdded:       diff.Stats.BytesAdded,
+			BytesRemoved:     diff.Stats.BytesRemoved,
+		},
+	}
+	for _, entry := range diff.Entries {
+		change, ok := diffChanges[entry.Modifier]
+		if !ok {
+			// unknown modifier from a newer restic version
+			change = repositories.SnapshotDiffChange(entry.Modifier)
+		}
+		out.Entries = append(out.Entries, repositories.SnapshotDiffEntry{
+			Path:   entry.Path,
+			Change: change,
+		})
+	}
+	return out
+}
@@ -0,0 +1,259 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"stash.appscode.dev/stash/apis/repositories"
+	repov1alpha1 "stash.appscode.dev/stash/apis/repositories/v1alpha1"
+	"stash.appscode.dev/stash/pkg/eventer"
+	"stash.appscode.dev/stash/pkg/restic"
+)
+
+const (
+	mimeTypeOctetStream = "application/octet-stream"
+	mimeTypeTar         = "application/x-tar"
+
+	// maxDirDownloadSize is the maximum total size of the files of a directory that can be downloaded.
+	// The directory is restored in a temporary directory of the api server before it is archived.
+	maxDirDownloadSize = 1 << 30
+)
+
+// DownloadREST serves "download" subresource of Snapshot. It streams a file of a snapshot as is directly
+// from the backend. A directory is restored once in a temporary directory and streamed as a tar archive.
+type DownloadREST struct {
+	snapshot *REST
+}
+
+var _ rest.GetterWithOptions = &DownloadREST{}
+var _ rest.GroupVersionKindProvider = &DownloadREST{}
+var _ rest.StorageMetadata = &DownloadREST{}
+
+func NewDownloadREST(snapshot *REST) *DownloadREST {
+	return &DownloadREST{
+		snapshot: snapshot,
+	}
+}
+
+func (r *DownloadREST) New() runtime.Object {
+	return &repositories.Snapshot{}
+}
+
+func (r *DownloadREST) GroupVersionKind(containingGV schema.GroupVersion) schema.GroupVersionKind {
+	return repov1alpha1.SchemeGroupVersion.WithKind(repov1alpha1.ResourceKindSnapshot)
+}
+
+func (r *DownloadREST) ProducesMIMETypes(verb string) []string {
+	return []string{mimeTypeOctetStream, mimeTypeTar}
+}
+
+func (r *DownloadREST) ProducesObject(verb string) interface{} {
+	return ""
+}
+
+func (r *DownloadREST) NewGetOptions() (runtime.Object, bool, string) {
+	return &repositories.SnapshotDownloadOptions{}, false, ""
+}
+
+func (r *DownloadREST) Get(ctx context.Context, name string, options runtime.Object) (runtime.Object, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing namespace")
+	}
+	opts, ok := options.(*repositories.SnapshotDownloadOptions)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid options object: %#v", options))
+	}
+	if !path.IsAbs(opts.Path) {
+		return nil, apierrors.NewBadRequest("path must be an absolute path inside the snapshot")
+	}
+	filePath := path.Clean(opts.Path)
+
+	repo, snapshotId, err := r.snapshot.getRepositoryForSnapshot(ctx, ns, name, repositoryVerbRead)
+	if err != nil {
+		return nil, err
+	}
+
+	// find out whether the path is a file or a directory
+	nodes, found, err := r.snapshot.getSnapshotFiles(repo, snapshotId)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if !found {
+		return nil, apierrors.NewNotFound(repositories.Resource(repov1alpha1.ResourceSingularSnapshot), name)
+	}
+	var node *restic.Node
+	for i := range nodes {
+		if nodes[i].Path == filePath {
+			node = &nodes[i]
+			break
+		}
+	}
+	if node == nil {
+		return nil, apierrors.NewNotFound(repositories.Resource("files"), filePath)
+	}
+
+	r.snapshot.writeAuditEvent(repo, eventer.EventReasonSnapshotDownloaded,
+		fmt.Sprintf("%s has downloaded %q from snapshot %s", requestingUser(ctx), filePath, name))
+
+	streamer := &downloadStreamer{
+		contentType: mimeTypeOctetStream,
+	}
+	if node.Type == "dir" {
+		if err = checkDirSize(nodes, filePath, maxDirDownloadSize); err != nil {
+			return nil, apierrors.NewBadRequest(err.Error())
+		}
+		streamer.contentType = mimeTypeTar
+		streamer.stream = func(w *restic.ResticWrapper, tempDir string, out io.Writer) error {
+			target := filepath.Join(tempDir, "download")
+			if err := w.RestoreDir(snapshotId, filePath, target); err != nil {
+				return err
+			}
+			return streamDir(target, filePath, out)
+		}
+	} else {
+		streamer.stream = func(w *restic.ResticWrapper, tempDir string, out io.Writer) error {
+			return w.StreamFile(snapshotId, filePath, out)
+		}
+	}
+	streamer.newResticWrapper = func(tempDir string) (*restic.ResticWrapper, error) {
+		return r.snapshot.newResticWrapper(repo, tempDir)
+	}
+	return streamer, nil
+}
+
+// downloadStreamer streams the data produced by restic to the client. It implements rest.ResourceStreamer
+// so that the api server writes the stream in the response instead of encoding an object.
+type downloadStreamer struct {
+	contentType      string
+	newResticWrapper func(tempDir string) (*restic.ResticWrapper, error)
+	stream           func(w *restic.ResticWrapper, tempDir string, out io.Writer) error
+}
+
+var _ rest.ResourceStreamer = &downloadStreamer{}
+
+func (s *downloadStreamer) GetObjectKind() schema.ObjectKind {
+	return schema.EmptyObjectKind
+}
+
+func (s *downloadStreamer) DeepCopyObject() runtime.Object {
+	panic("downloadStreamer does not implement DeepCopyObject")
+}
+
+// InputStream starts restic in background and returns the reading end of a pipe where the data is written.
+// Closing the stream stops restic.
+func (s *downloadStreamer) InputStream(ctx context.Context, apiVersion, acceptHeader string) (io.ReadCloser, bool, string, error) {
+	tempDir, err := ioutil.TempDir("", "stash")
+	if err != nil {
+		return nil, false, "", err
+	}
+	w, err := s.newResticWrapper(tempDir)
+	if err != nil {
+		os.RemoveAll(tempDir)
+		return nil, false, "", err
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		// cleanup whole tempDir dir at the end
+		defer os.RemoveAll(tempDir)
+		writer.CloseWithError(s.stream(w, tempDir, writer))
+	}()
+	return &downloadReader{PipeReader: reader, w: w}, true, s.contentType, nil
+}
+
+type downloadReader struct {
+	*io.PipeReader
+	w *restic.ResticWrapper
+}
+
+func (r *downloadReader) Close() error {
+	r.w.Cancel()
+	return r.PipeReader.Close()
+}
+
+// checkDirSize returns an error if the total size of the files of the directory exceeds the limit
+func checkDirSize(nodes []restic.Node, dir string, limit uint64) error {
+	var size uint64
+	for _, node := range nodes {
+		if node.Type == "file" && inDir(node.Path, dir) {
+			size += node.Size
+		}
+	}
+	if size > limit {
+		return fmt.Errorf("directory %q is too large to download. Total size of its files is %d bytes but at most %d bytes are allowed. "+
+			"Download the files individually or restore the snapshot using a RestoreSession", dir, size, limit)
+	}
+	return nil
+}
+
+func inDir(file, dir string) bool {
+	return file == dir || strings.HasPrefix(file, strings.TrimSuffix(dir, "/")+"/")
+}
+
+// streamDir writes the directory of the snapshot that has been restored inside target into out as a tar archive.
+// The names inside the archive are relative to the parent of the directory.
+func streamDir(target, dir string, out io.Writer) error {
+	root := filepath.Join(target, filepath.FromSlash(dir))
+	base := filepath.Dir(root)
+	if dir == "/" {
+		base = root
+	}
+	tw := tar.NewWriter(out)
+	err := filepath.Walk(root, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(base, file)
+		if err != nil {
+			return err
+		}
+		if name == "." {
+			// root of the snapshot
+			return nil
+		}
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(name)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err = tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
@@ -0,0 +1,111 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"stash.appscode.dev/stash/pkg/restic"
+)
+
+func TestStreamDir(t *testing.T) {
+	// target mimics the directory where the snapshot has been restored
+	target, err := ioutil.TempDir("", "stash-download")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(target)
+	contents := map[string]string{
+		"data/a.txt":     "content",
+		"data/sub/b.txt": "sub content",
+		"database/c.txt": "database txt",
+	}
+	for file, content := range contents {
+		file = filepath.Join(target, file)
+		if !assert.NoError(t, os.MkdirAll(filepath.Dir(file), 0755)) {
+			return
+		}
+		if !assert.NoError(t, ioutil.WriteFile(file, []byte(content), 0644)) {
+			return
+		}
+	}
+	if !assert.NoError(t, os.Symlink("a.txt", filepath.Join(target, "data", "link"))) {
+		return
+	}
+
+	testCases := []struct {
+		name    string
+		dir     string
+		entries []string
+	}{
+		{name: "sub directory", dir: "/data/sub", entries: []string{"sub/", "sub/b.txt"}},
+		{name: "directory with a symlink", dir: "/data", entries: []string{"data/", "data/a.txt", "data/link", "data/sub/", "data/sub/b.txt"}},
+		{name: "snapshot root", dir: "/", entries: []string{"data/", "data/a.txt", "data/link", "data/sub/", "data/sub/b.txt", "database/", "database/c.txt"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := bytes.NewBuffer(nil)
+			if !assert.NoError(t, streamDir(target, tc.dir, buf)) {
+				return
+			}
+			var entries []string
+			tr := tar.NewReader(buf)
+			for {
+				header, err := tr.Next()
+				if err == io.EOF {
+					break
+				}
+				if !assert.NoError(t, err) {
+					return
+				}
+				data, err := ioutil.ReadAll(tr)
+				assert.NoError(t, err)
+				assert.Equal(t, header.Size, int64(len(data)), fmt.Sprintf("size of %s", header.Name))
+				entries = append(entries, header.Name)
+			}
+			assert.Equal(t, tc.entries, entries)
+		})
+	}
+}
+
+func TestStreamDirNotRestored(t *testing.T) {
+	target, err := ioutil.TempDir("", "stash-download")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(target)
+	assert.Error(t, streamDir(target, "/data", ioutil.Discard))
+}
+
+func TestCheckDirSize(t *testing.T) {
+	nodes := []restic.Node{
+		{Path: "/data", Type: "dir"},
+		{Path: "/data/a.txt", Type: "file", Size: 60},
+		{Path: "/data/sub", Type: "dir"},
+		{Path: "/data/sub/b.txt", Type: "file", Size: 30},
+		{Path: "/database", Type: "dir"},
+		{Path: "/database/c.txt", Type: "file", Size: 50},
+	}
+	testCases := []struct {
+		name    string
+		dir     string
+		invalid bool
+	}{
+		{name: "sub directory", dir: "/data/sub"},
+		{name: "directory with a similar sibling", dir: "/data"},
+		{name: "snapshot root", dir: "/", invalid: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := checkDirSize(nodes, tc.dir, 100); (err != nil) != tc.invalid {
+				t.Errorf("expected invalid: %v, got error: %v", tc.invalid, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"path"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"stash.appscode.dev/stash/apis/repositories"
+	repov1alpha1 "stash.appscode.dev/stash/apis/repositories/v1alpha1"
+	"stash.appscode.dev/stash/pkg/restic"
+)
+
+// FilesREST serves "files" subresource of Snapshot. It lists the files of a snapshot directly
+// from the backend. So, no pod is required to browse a snapshot.
+type FilesREST struct {
+	snapshot *REST
+}
+
+var _ rest.GetterWithOptions = &FilesREST{}
+var _ rest.GroupVersionKindProvider = &FilesREST{}
+
+func NewFilesREST(snapshot *REST) *FilesREST {
+	return &FilesREST{
+		snapshot: snapshot,
+	}
+}
+
+func (r *FilesREST) New() runtime.Object {
+	return &repositories.SnapshotFileList{}
+}
+
+func (r *FilesREST) GroupVersionKind(containingGV schema.GroupVersion) schema.GroupVersionKind {
+	return repov1alpha1.SchemeGroupVersion.WithKind(repov1alpha1.ResourceKindSnapshotFileList)
+}
+
+func (r *FilesREST) NewGetOptions() (runtime.Object, bool, string) {
+	return &repositories.SnapshotFilesOptions{}, false, ""
+}
+
+func (r *FilesREST) Get(ctx context.Context, name string, options runtime.Object) (runtime.Object, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing namespace")
+	}
+	opts, ok := options.(*repositories.SnapshotFilesOptions)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid options object: %#v", options))
+	}
+	if opts.Limit < 0 {
+		return nil, apierrors.NewBadRequest("limit must not be negative")
+	}
+
+	repo, snapshotId, err := r.snapshot.getRepositoryForSnapshot(ctx, ns, name, repositoryVerbRead)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, found, err := r.snapshot.getSnapshotFiles(repo, snapshotId)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if !found {
+		return nil, apierrors.NewNotFound(repositories.Resource(repov1alpha1.ResourceSingularSnapshot), name)
+	}
+
+	return paginateFiles(filterFilesByPath(nodes, opts.Path), opts.Limit, opts.Continue)
+}
+
+// filterFilesByPath returns the nodes that are inside the directory. The directory itself is not included.
+func filterFilesByPath(nodes []restic.Node, dir string) []restic.Node {
+	dir = path.Clean("/" + dir)
+	if dir == "/" {
+		return nodes
+	}
+	var filtered []restic.Node
+	for _, node := range nodes {
+		if strings.HasPrefix(node.Path, dir+"/") {
+			filtered = append(filtered, node)
+		}
+	}
+	return filtered
+}
+
+// paginateFiles returns at most limit files starting after the file encoded in the continue token.
+// The token is the path of the last file of the previous page. The order of the files in a snapshot
+// never changes. So, the token remains valid as long as the snapshot exists.
+func paginateFiles(nodes []restic.Node, limit int64, continueToken string) (*repositories.SnapshotFileList, error) {
+	start := 0
+	if continueToken != "" {
+		last, err := base64.RawURLEncoding.DecodeString(continueToken)
+		if err != nil {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid continue token. Reason: %v", err))
+		}
+		start = -1
+		for i := range nodes {
+			if nodes[i].Path == string(last) {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			return nil, apierrors.NewBadRequest("invalid continue token. File not found in the snapshot.")
+		}
+	}
+
+	end := len(nodes)
+	if limit > 0 && int64(end-start) > limit {
+		end = start + int(limit)
+	}
+
+	list := &repositories.SnapshotFileList{
+		Items: make([]repositories.SnapshotFile, 0, end-start),
+	}
+	for _, node := range nodes[start:end] {
+		file := repositories.SnapshotFile{
+			Name: node.Name,
+			Path: node.Path,
+			Type: node.Type,
+			Size: node.Size,
+			Mode: node.Mode.String(),
+			UID:  node.UID,
+			Gid:  node.Gid,
+		}
+		file.ModTime.Time = node.ModTime
+		list.Items = append(list.Items, file)
+	}
+	if end < len(nodes) {
+		list.Continue = base64.RawURLEncoding.EncodeToString([]byte(nodes[end-1].Path))
+	}
+	return list, nil
+}
@@ -0,0 +1,178 @@
+package snapshot
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/selection"
+	"stash.appscode.dev/stash/apis/repositories"
+	repov1alpha1 "stash.appscode.dev/stash/apis/repositories/v1alpha1"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+// snapshotFieldFilter selects snapshots by the fields of their status and by the time they have been taken
+type snapshotFieldFilter struct {
+	requirements  fields.Requirements
+	createdAfter  *time.Time
+	createdBefore *time.Time
+}
+
+// newSnapshotFieldFilter validates the field selector and converts it into a filter.
+// Time range fields support only "=" operator.
+func newSnapshotFieldFilter(selector fields.Selector) (*snapshotFieldFilter, error) {
+	filter := &snapshotFieldFilter{}
+	if selector == nil || selector.Empty() {
+		return filter, nil
+	}
+	for _, req := range selector.Requirements() {
+		switch req.Field {
+		case repov1alpha1.SnapshotFieldCreatedAfter, repov1alpha1.SnapshotFieldCreatedBefore:
+			if req.Operator == selection.NotEquals {
+				return nil, apierrors.NewBadRequest(fmt.Sprintf("field %s does not support operator %s", req.Field, req.Operator))
+			}
+			t, err := time.Parse(time.RFC3339, req.Value)
+			if err != nil {
+				return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid value of field %s. Reason: %v", req.Field, err))
+			}
+			if req.Field == repov1alpha1.SnapshotFieldCreatedAfter {
+				filter.createdAfter = &t
+			} else {
+				filter.createdBefore = &t
+			}
+		case "metadata.name",
+			"metadata.namespace",
+			repov1alpha1.SnapshotFieldHostname,
+			repov1alpha1.SnapshotFieldPaths,
+			repov1alpha1.SnapshotFieldTags,
+			repov1alpha1.SnapshotFieldBackupSession,
+			repov1alpha1.SnapshotFieldBackupConfiguration:
+			filter.requirements = append(filter.requirements, req)
+		default:
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("field label not supported: %s", req.Field))
+		}
+	}
+	return filter, nil
+}
+
+// matches returns true if the snapshot satisfies all requirements of the filter. A list field (i.e. paths, tags)
+// matches if any of its items matches the value.
+func (f *snapshotFieldFilter) matches(snapshot repositories.Snapshot) bool {
+	created := snapshot.CreationTimestamp.Time
+	if f.createdAfter != nil && !created.After(*f.createdAfter) {
+		return false
+	}
+	if f.createdBefore != nil && !created.Before(*f.createdBefore) {
+		return false
+	}
+	for _, req := range f.requirements {
+		var values []string
+		switch req.Field {
+		case "metadata.name":
+			values = []string{snapshot.Name}
+		case "metadata.namespace":
+			values = []string{snapshot.Namespace}
+		case repov1alpha1.SnapshotFieldHostname:
+			values = []string{snapshot.Status.Hostname}
+		case repov1alpha1.SnapshotFieldPaths:
+			values = snapshot.Status.Paths
+		case repov1alpha1.SnapshotFieldTags:
+			values = snapshot.Status.Tags
+		case repov1alpha1.SnapshotFieldBackupSession:
+			values = []string{snapshot.Status.BackupSession}
+		case repov1alpha1.SnapshotFieldBackupConfiguration:
+			values = []string{snapshot.Status.BackupConfiguration}
+		}
+		found := false
+		for _, v := range values {
+			if v == req.Value {
+				found = true
+				break
+			}
+		}
+		if found == (req.Operator == selection.NotEquals) {
+			return false
+		}
+	}
+	return true
+}
+
+// snapshotContinueToken identifies the last snapshot of a page. It holds the sort key of the snapshot
+// instead of its name so that the token remains valid even if that snapshot has been deleted.
+type snapshotContinueToken struct {
+	Repository string    `json:"repository"`
+	Time       time.Time `json:"time"`
+	Name       string    `json:"name"`
+}
+
+// repositoryOfSnapshot returns the name of the Repository of a snapshot from the name of the snapshot
+func repositoryOfSnapshot(snapshot repositories.Snapshot) string {
+	repoName, _, _ := util.GetRepoNameAndSnapshotID(snapshot.Name)
+	return repoName
+}
+
+func tokenForSnapshot(snapshot repositories.Snapshot) snapshotContinueToken {
+	return snapshotContinueToken{
+		Repository: repositoryOfSnapshot(snapshot),
+		Time:       snapshot.CreationTimestamp.Time,
+		Name:       snapshot.Name,
+	}
+}
+
+// less orders the snapshots by repository, then by the time they have been taken
+func (t snapshotContinueToken) less(o snapshotContinueToken) bool {
+	if t.Repository != o.Repository {
+		return t.Repository < o.Repository
+	}
+	if !t.Time.Equal(o.Time) {
+		return t.Time.Before(o.Time)
+	}
+	return t.Name < o.Name
+}
+
+func sortSnapshots(snapshots []repositories.Snapshot) {
+	sort.Slice(snapshots, func(i, j int) bool {
+		return tokenForSnapshot(snapshots[i]).less(tokenForSnapshot(snapshots[j]))
+	})
+}
+
+// paginateSnapshots returns at most limit snapshots starting after the snapshot encoded in the continue token.
+// The snapshots must be sorted by sortSnapshots.
+func paginateSnapshots(snapshots []repositories.Snapshot, limit int64, continueToken string) (*repositories.SnapshotList, error) {
+	start := 0
+	if continueToken != "" {
+		data, err := base64.RawURLEncoding.DecodeString(continueToken)
+		if err != nil {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid continue token. Reason: %v", err))
+		}
+		var last snapshotContinueToken
+		if err = json.Unmarshal(data, &last); err != nil {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid continue token. Reason: %v", err))
+		}
+		start = sort.Search(len(snapshots), func(i int) bool {
+			return last.less(tokenForSnapshot(snapshots[i]))
+		})
+	}
+
+	end := len(snapshots)
+	if limit > 0 && int64(end-start) > limit {
+		end = start + int(limit)
+	}
+
+	list := &repositories.SnapshotList{
+		Items: make([]repositories.Snapshot, 0, end-start),
+	}
+	list.Items = append(list.Items, snapshots[start:end]...)
+	if end < len(snapshots) {
+		data, err := json.Marshal(tokenForSnapshot(snapshots[end-1]))
+		if err != nil {
+			return nil, err
+		}
+		list.Continue = base64.RawURLEncoding.EncodeToString(data)
+	}
+	return list, nil
+}
@@ -0,0 +1,127 @@
+package snapshot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"stash.appscode.dev/stash/apis/repositories"
+)
+
+func newTestSnapshot(name string, created time.Time, hostname string, tags ...string) repositories.Snapshot {
+	snapshot := repositories.Snapshot{}
+	snapshot.Name = name
+	snapshot.Namespace = "demo"
+	snapshot.CreationTimestamp = metav1.NewTime(created)
+	snapshot.Status.Hostname = hostname
+	snapshot.Status.Tags = tags
+	return snapshot
+}
+
+func TestSnapshotFieldFilter(t *testing.T) {
+	now := time.Date(2019, 10, 1, 10, 0, 0, 0, time.UTC)
+	snapshot := newTestSnapshot("repo-1234abcd", now, "host-0", "app=demo", "env=prod")
+
+	testCases := []struct {
+		name     string
+		selector string
+		invalid  bool
+		matches  bool
+	}{
+		{name: "empty selector", selector: "", matches: true},
+		{name: "hostname", selector: "status.hostname=host-0", matches: true},
+		{name: "hostname mismatch", selector: "status.hostname=host-1", matches: false},
+		{name: "hostname not equal", selector: "status.hostname!=host-1", matches: true},
+		{name: "any tag matches", selector: `status.tags=env\=prod`, matches: true},
+		{name: "tag not present", selector: `status.tags!=env\=prod`, matches: false},
+		{name: "created after", selector: "createdAfter=2019-10-01T09:00:00Z", matches: true},
+		{name: "created before", selector: "createdBefore=2019-10-01T09:00:00Z", matches: false},
+		{name: "time range", selector: "createdAfter=2019-10-01T09:00:00Z,createdBefore=2019-10-01T11:00:00Z", matches: true},
+		{name: "invalid time", selector: "createdAfter=yesterday", invalid: true},
+		{name: "unsupported operator for time", selector: "createdAfter!=2019-10-01T09:00:00Z", invalid: true},
+		{name: "unsupported field", selector: "status.tree=abc", invalid: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			selector, err := fields.ParseSelector(tc.selector)
+			if !assert.NoError(t, err) {
+				return
+			}
+			filter, err := newSnapshotFieldFilter(selector)
+			if tc.invalid {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.matches, filter.matches(snapshot))
+			}
+		})
+	}
+}
+
+func TestPaginateSnapshots(t *testing.T) {
+	now := time.Date(2019, 10, 1, 10, 0, 0, 0, time.UTC)
+	snapshots := []repositories.Snapshot{
+		newTestSnapshot("b-00000003", now, "host-0"),
+		newTestSnapshot("a-00000002", now.Add(time.Hour), "host-0"),
+		newTestSnapshot("a-00000001", now, "host-0"),
+		newTestSnapshot("b-00000004", now.Add(time.Hour), "host-0"),
+	}
+	sortSnapshots(snapshots)
+
+	testCases := []struct {
+		name  string
+		limit int64
+		pages [][]string
+	}{
+		{name: "no limit", limit: 0, pages: [][]string{{"a-00000001", "a-00000002", "b-00000003", "b-00000004"}}},
+		{name: "limit larger than the list", limit: 10, pages: [][]string{{"a-00000001", "a-00000002", "b-00000003", "b-00000004"}}},
+		{name: "exact pages", limit: 2, pages: [][]string{{"a-00000001", "a-00000002"}, {"b-00000003", "b-00000004"}}},
+		{name: "partial last page", limit: 3, pages: [][]string{{"a-00000001", "a-00000002", "b-00000003"}, {"b-00000004"}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var pages [][]string
+			continueToken := ""
+			for {
+				list, err := paginateSnapshots(snapshots, tc.limit, continueToken)
+				if !assert.NoError(t, err) {
+					return
+				}
+				var names []string
+				for _, s := range list.Items {
+					names = append(names, s.Name)
+				}
+				pages = append(pages, names)
+				if list.Continue == "" {
+					break
+				}
+				continueToken = list.Continue
+			}
+			assert.Equal(t, tc.pages, pages)
+		})
+	}
+}
+
+func TestPaginateSnapshotsDeletedLastItem(t *testing.T) {
+	now := time.Date(2019, 10, 1, 10, 0, 0, 0, time.UTC)
+	snapshots := []repositories.Snapshot{
+		newTestSnapshot("a-00000001", now, "host-0"),
+		newTestSnapshot("a-00000002", now.Add(time.Hour), "host-0"),
+		newTestSnapshot("a-00000003", now.Add(2*time.Hour), "host-0"),
+	}
+	list, err := paginateSnapshots(snapshots, 2, "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	// the last snapshot of the page has been deleted before requesting the next page
+	list, err = paginateSnapshots(append(snapshots[:1:1], snapshots[2]), 2, list.Continue)
+	if assert.NoError(t, err) && assert.Len(t, list.Items, 1) {
+		assert.Equal(t, "a-00000003", list.Items[0].Name)
+	}
+
+	_, err = paginateSnapshots(snapshots, 2, "invalid token")
+	assert.Error(t, err)
+}
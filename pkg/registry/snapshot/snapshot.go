@@ -2,7 +2,11 @@ package snapshot
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"sync"
 
+	"github.com/appscode/go/log"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
@@ -10,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	apirequest "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/rest"
 	"k8s.io/client-go/kubernetes"
@@ -19,19 +24,31 @@ import (
 	repov1alpha1 "stash.appscode.dev/stash/apis/repositories/v1alpha1"
 	stash "stash.appscode.dev/stash/apis/stash/v1alpha1"
 	"stash.appscode.dev/stash/client/clientset/versioned"
+	"stash.appscode.dev/stash/pkg/eventer"
 	"stash.appscode.dev/stash/pkg/util"
 )
 
+// maxConcurrentRepositoryListing is the maximum number of repositories whose snapshots are listed at a time
+const maxConcurrentRepositoryListing = 5
+
+// maxConcurrentStatsComputation is the maximum number of snapshots of a repository whose stats are computed at a time
+const maxConcurrentStatsComputation = 5
+
 type REST struct {
 	stashClient versioned.Interface
 	kubeClient  kubernetes.Interface
 	config      *restconfig.Config
+	cache       *snapshotCache
+	authzCache  *authorizationCache
 }
 
 var _ rest.Scoper = &REST{}
 var _ rest.Getter = &REST{}
 var _ rest.Lister = &REST{}
 var _ rest.GracefulDeleter = &REST{}
+var _ rest.CollectionDeleter = &REST{}
+var _ rest.Updater = &REST{}
+var _ rest.Patcher = &REST{}
 var _ rest.GroupVersionKindProvider = &REST{}
 var _ rest.CategoriesProvider = &REST{}
 
@@ -40,6 +57,8 @@ func NewREST(config *restconfig.Config) *REST {
 		stashClient: versioned.NewForConfigOrDie(config),
 		kubeClient:  kubernetes.NewForConfigOrDie(config),
 		config:      config,
+		cache:       newSnapshotCache(DefaultSnapshotCacheTTL),
+		authzCache:  newAuthorizationCache(authorizationCacheTTL),
 	}
 }
 
@@ -78,6 +97,9 @@ func (r *REST) Get(ctx context.Context, name string, options *metav1.GetOptions)
 			return nil, apierrors.NewInternalError(err)
 		}
 	}
+	if err = r.authorize(ctx, repo, repositoryVerbRead); err != nil {
+		return nil, err
+	}
 
 	snapshots, err := r.GetVersionedSnapshots(repo, []string{snapshotId}, false)
 	if err != nil {
@@ -87,10 +109,10 @@ func (r *REST) Get(ctx context.Context, name string, options *metav1.GetOptions)
 		return nil, apierrors.NewNotFound(repositories.Resource(repov1alpha1.ResourceSingularSnapshot), name)
 	}
 
-	// TODO: return &snapshots[0], nil
-	snapshot := &repositories.Snapshot{}
-	snapshot = &snapshots[0]
-	return snapshot, nil
+	if err = r.setSnapshotStats(repo, snapshots[:1]); err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	return &snapshots[0], nil
 }
 
 func (r *REST) NewList() runtime.Object {
@@ -103,13 +125,55 @@ func (r *REST) List(ctx context.Context, options *metainternalversion.ListOption
 		return nil, apierrors.NewBadRequest("missing namespace")
 	}
 
+	selectedRepos, snapshots, warnings, err := r.selectSnapshots(ctx, ns, options, repositoryVerbRead)
+	if err != nil {
+		return nil, err
+	}
+
+	sortSnapshots(snapshots)
+	snapshotList, err := paginateSnapshots(snapshots, options.Limit, options.Continue)
+	if err != nil {
+		return nil, err
+	}
+	snapshotList.Warnings = warnings
+
+	// compute the stats only for the snapshots of this page. the snapshots of a repository are adjacent in the page.
+	repoByName := make(map[string]*stash.Repository)
+	for i := range selectedRepos {
+		repoByName[selectedRepos[i].Name] = &selectedRepos[i]
+	}
+	items := snapshotList.Items
+	for start, end := 0, 0; start < len(items); start = end {
+		repoName := repositoryOfSnapshot(items[start])
+		for end = start + 1; end < len(items) && repositoryOfSnapshot(items[end]) == repoName; end++ {
+		}
+		if err = r.setSnapshotStats(repoByName[repoName], items[start:end]); err != nil {
+			log.Errorf("failed to read stats of the snapshots of Repository %s/%s. Reason: %v", ns, repoName, err)
+			snapshotList.Warnings = append(snapshotList.Warnings, repositories.SnapshotListWarning{
+				Repository: repoName,
+				Message:    fmt.Sprintf("failed to read stats of the snapshots. Reason: %v", err),
+			})
+		}
+	}
+	return snapshotList, nil
+}
+
+// selectSnapshots returns the snapshots of the namespace that match the label and field selectors along with the
+// repositories they have been searched in. Only the repositories that the requesting user is allowed to access
+// with the verb are searched. A repository that can't be listed does not fail the whole selection. It is reported
+// as a warning instead.
+func (r *REST) selectSnapshots(ctx context.Context, ns string, options *metainternalversion.ListOptions, verb string) ([]stash.Repository, []repositories.Snapshot, []repositories.SnapshotListWarning, error) {
 	repos, err := r.stashClient.StashV1alpha1().Repositories(ns).List(metav1.ListOptions{})
 	if err != nil {
-		return nil, apierrors.NewInternalError(err)
+		return nil, nil, nil, apierrors.NewInternalError(err)
 	}
 
+	// labels derived from snapshot tags are not present in the Repository. so, split the selector into
+	// a selector for the repositories and a selector for the snapshots.
+	repoSelector, snapshotSelector := splitLabelSelector(options.LabelSelector)
+
 	var selectedRepos []stash.Repository
-	if options.LabelSelector != nil {
+	if repoSelector != nil {
 		for _, r := range repos.Items {
 			repoLabels := map[string]string{
 				"repository": r.Name,
@@ -117,28 +181,122 @@ func (r *REST) List(ctx context.Context, options *metainternalversion.ListOption
 			if r.Labels != nil {
 				repoLabels = core_util.UpsertMap(repoLabels, r.Labels)
 			}
-			if options.LabelSelector.Matches(labels.Set(repoLabels)) {
+			if repoSelector.Matches(labels.Set(repoLabels)) {
 				selectedRepos = append(selectedRepos, r)
 			}
 		}
 	} else {
 		selectedRepos = repos.Items
 	}
+	selectedRepos, err = r.authorizedRepositories(ctx, selectedRepos, verb)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	fieldFilter, err := newSnapshotFieldFilter(options.FieldSelector)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	results, warnings := r.listSnapshotsOfRepositories(selectedRepos)
+	if len(selectedRepos) > 0 && len(warnings) == len(selectedRepos) {
+		return nil, nil, nil, apierrors.NewInternalError(fmt.Errorf("failed to list snapshots of any repository. Reason: %s", warnings[0].Message))
+	}
+	snapshots := make([]repositories.Snapshot, 0)
+	for _, repoSnapshots := range results {
+		for _, snapshot := range repoSnapshots {
+			if (snapshotSelector == nil || snapshotSelector.Matches(labels.Set(snapshot.Labels))) && fieldFilter.matches(snapshot) {
+				snapshots = append(snapshots, snapshot)
+			}
+		}
+	}
+
+	return selectedRepos, snapshots, warnings, nil
+}
+
+// listSnapshotsOfRepositories lists the snapshots of the repositories concurrently using at most
+// maxConcurrentRepositoryListing workers. The snapshots are served from the cache whenever possible.
+// The snapshots of the i-th repository are returned at index i. A warning is returned for each
+// repository whose snapshots could not be listed.
+func (r *REST) listSnapshotsOfRepositories(repos []stash.Repository) ([][]repositories.Snapshot, []repositories.SnapshotListWarning) {
+	results := make([][]repositories.Snapshot, len(repos))
+	errs := make([]error, len(repos))
+
+	workers := maxConcurrentRepositoryListing
+	if len(repos) < workers {
+		workers = len(repos)
+	}
+	queue := make(chan int, len(repos))
+	for i := range repos {
+		queue <- i
+	}
+	close(queue)
 
-	snapshotList := &repositories.SnapshotList{
-		Items: make([]repositories.Snapshot, 0),
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				results[i], errs[i] = r.getCachedSnapshots(&repos[i])
+			}
+		}()
 	}
-	for _, repo := range selectedRepos {
-		snapshots, err := r.GetVersionedSnapshots(&repo, nil, false)
+	wg.Wait()
+
+	var warnings []repositories.SnapshotListWarning
+	for i, err := range errs {
 		if err != nil {
-			return nil, apierrors.NewInternalError(err)
+			log.Errorf("failed to list snapshots of Repository %s/%s. Reason: %v", repos[i].Namespace, repos[i].Name, err)
+			warnings = append(warnings, repositories.SnapshotListWarning{
+				Repository: repos[i].Name,
+				Message:    err.Error(),
+			})
 		}
-		snapshotList.Items = append(snapshotList.Items, snapshots...)
 	}
+	return results, warnings
+}
 
-	// k8s.io/apimachinery/pkg/apis/meta/v1/unstructured/unstructured_list.go
-	// unstructured.UnstructuredList{}
-	return snapshotList, nil
+// getCachedSnapshots returns all snapshots of the repository from the cache. The snapshots are listed
+// from the backend and cached if the cache does not have them.
+func (r *REST) getCachedSnapshots(repository *stash.Repository) ([]repositories.Snapshot, error) {
+	if snapshots, found := r.cache.get(repository); found {
+		return snapshots, nil
+	}
+	snapshots, err := r.GetVersionedSnapshots(repository, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.set(repository, snapshots)
+	return snapshots, nil
+}
+
+// splitLabelSelector splits the selector into a selector for the repositories and a selector for the labels
+// derived from snapshot tags. A nil selector is returned if there is no requirement for it.
+func splitLabelSelector(selector labels.Selector) (labels.Selector, labels.Selector) {
+	if selector == nil {
+		return nil, nil
+	}
+	requirements, selectable := selector.Requirements()
+	if !selectable {
+		return selector, nil
+	}
+
+	var repoSelector, snapshotSelector labels.Selector
+	for _, req := range requirements {
+		if util.IsSnapshotTagLabel(req.Key()) {
+			if snapshotSelector == nil {
+				snapshotSelector = labels.NewSelector()
+			}
+			snapshotSelector = snapshotSelector.Add(req)
+		} else {
+			if repoSelector == nil {
+				repoSelector = labels.NewSelector()
+			}
+			repoSelector = repoSelector.Add(req)
+		}
+	}
+	return repoSelector, snapshotSelector
 }
 
 func (r *REST) Delete(ctx context.Context, name string, options *metav1.DeleteOptions) (runtime.Object, bool, error) {
@@ -158,6 +316,9 @@ func (r *REST) Delete(ctx context.Context, name string, options *metav1.DeleteOp
 			return nil, false, apierrors.NewInternalError(err)
 		}
 	}
+	if err = r.authorize(ctx, repo, repositoryVerbWrite); err != nil {
+		return nil, false, err
+	}
 
 	// first, check if the snapshot exist
 	snapshots, err := r.GetVersionedSnapshots(repo, []string{snapshotId}, false)
@@ -166,10 +327,130 @@ func (r *REST) Delete(ctx context.Context, name string, options *metav1.DeleteOp
 	} else if len(snapshots) == 0 {
 		return nil, false, apierrors.NewNotFound(repositories.Resource(repov1alpha1.ResourceSingularSnapshot), name)
 	}
+	if isUnderLegalHold(snapshots[0].Status.Tags) {
+		return nil, false, apierrors.NewForbidden(repositories.Resource(repov1alpha1.ResourcePluralSnapshot), name,
+			fmt.Errorf("snapshot is under legal hold. Remove %q tag to delete it", stash.SnapshotTagLegalHold))
+	}
 	// delete snapshot
 	if err = r.ForgetVersionedSnapshots(repo, []string{snapshotId}, false); err != nil {
 		return nil, false, apierrors.NewInternalError(err)
 	}
+	r.cache.deleteStats(snapshots[0].UID)
+	r.writeAuditEvent(repo, eventer.EventReasonSnapshotDeleted, fmt.Sprintf("%s has deleted snapshot %s", requestingUser(ctx), name))
 
 	return nil, true, nil
 }
+
+// DeleteCollection deletes all snapshots that match the label and field selectors. The snapshots of a repository
+// are deleted with a single restic command. If a prune has been scheduled for the repository, their data are removed
+// by the next scheduled prune instead of pruning the repository now. Nothing is deleted if any of the matching
+// snapshots is under legal hold or if the snapshots of any repository could not be listed.
+func (r *REST) DeleteCollection(ctx context.Context, options *metav1.DeleteOptions, listOptions *metainternalversion.ListOptions) (runtime.Object, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing namespace")
+	}
+
+	// never delete based on a stale list
+	r.cache.invalidateNamespace(ns)
+	selectedRepos, snapshots, warnings, err := r.selectSnapshots(ctx, ns, listOptions, repositoryVerbWrite)
+	if err != nil {
+		return nil, err
+	}
+	if len(warnings) > 0 {
+		return nil, apierrors.NewInternalError(fmt.Errorf("failed to list snapshots of Repository %s. Reason: %s. Use a label selector to exclude it",
+			warnings[0].Repository, warnings[0].Message))
+	}
+
+	snapshotIDs := make(map[string][]string)
+	for _, snapshot := range snapshots {
+		if isUnderLegalHold(snapshot.Status.Tags) {
+			return nil, apierrors.NewForbidden(repositories.Resource(repov1alpha1.ResourcePluralSnapshot), snapshot.Name,
+				fmt.Errorf("snapshot is under legal hold. Remove %q tag to delete it", stash.SnapshotTagLegalHold))
+		}
+		repoName := repositoryOfSnapshot(snapshot)
+		snapshotIDs[repoName] = append(snapshotIDs[repoName], string(snapshot.UID))
+	}
+
+	deleted := &repositories.SnapshotList{
+		Items: make([]repositories.Snapshot, 0, len(snapshots)),
+	}
+	for i := range selectedRepos {
+		ids := snapshotIDs[selectedRepos[i].Name]
+		if len(ids) == 0 {
+			continue
+		}
+		if err = r.ForgetVersionedSnapshots(&selectedRepos[i], ids, false); err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		var names []string
+		for _, snapshot := range snapshots {
+			if repositoryOfSnapshot(snapshot) == selectedRepos[i].Name {
+				r.cache.deleteStats(snapshot.UID)
+				deleted.Items = append(deleted.Items, snapshot)
+				names = append(names, snapshot.Name)
+			}
+		}
+		r.writeAuditEvent(&selectedRepos[i], eventer.EventReasonSnapshotDeleted,
+			fmt.Sprintf("%s has deleted %d snapshots: %s", requestingUser(ctx), len(names), strings.Join(names, ", ")))
+	}
+	return deleted, nil
+}
+
+// Update updates the tags of a snapshot. Other fields of a snapshot are immutable and their changes are ignored.
+// restic replaces the snapshot with a new one when the tags are changed. So, the updated snapshot has a new name.
+func (r *REST) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, false, apierrors.NewBadRequest("missing namespace")
+	}
+	repo, snapshotId, err := r.getRepositoryForSnapshot(ctx, ns, name, repositoryVerbWrite)
+	if err != nil {
+		return nil, false, err
+	}
+
+	snapshots, err := r.GetVersionedSnapshots(repo, []string{snapshotId}, false)
+	if err != nil {
+		return nil, false, apierrors.NewInternalError(err)
+	} else if len(snapshots) == 0 {
+		return nil, false, apierrors.NewNotFound(repositories.Resource(repov1alpha1.ResourceSingularSnapshot), name)
+	}
+	old := &snapshots[0]
+
+	obj, err := objInfo.UpdatedObject(ctx, old)
+	if err != nil {
+		return nil, false, err
+	}
+	updated, ok := obj.(*repositories.Snapshot)
+	if !ok {
+		return nil, false, apierrors.NewBadRequest(fmt.Sprintf("not a Snapshot: %#v", obj))
+	}
+	if updateValidation != nil {
+		if err := updateValidation(updated, old); err != nil {
+			return nil, false, err
+		}
+	}
+	for _, tag := range updated.Status.Tags {
+		if tag == "" || strings.Contains(tag, ",") {
+			return nil, false, apierrors.NewBadRequest(fmt.Sprintf("invalid tag %q. A tag must be non-empty and can't contain comma", tag))
+		}
+	}
+
+	add, remove := diffTags(old.Status.Tags, updated.Status.Tags)
+	result, err := r.updateSnapshotTags(repo, snapshotId, add, remove)
+	if err != nil {
+		return nil, false, apierrors.NewInternalError(err)
+	}
+	if len(add) > 0 || len(remove) > 0 {
+		r.writeAuditEvent(repo, eventer.EventReasonSnapshotTagsUpdated, fmt.Sprintf("%s has updated the tags of snapshot %s (added: %v, removed: %v). New snapshot: %s",
+			requestingUser(ctx), name, add, remove, result.Name))
+	}
+	return result, false, nil
+}
+
+// diffTags returns the tags that have to be added and removed to change the old tags into the new tags
+func diffTags(oldTags, newTags []string) ([]string, []string) {
+	oldSet := sets.NewString(oldTags...)
+	newSet := sets.NewString(newTags...)
+	return newSet.Difference(oldSet).List(), oldSet.Difference(newSet).List()
+}
@@ -0,0 +1,30 @@
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffTags(t *testing.T) {
+	testCases := []struct {
+		name    string
+		oldTags []string
+		newTags []string
+		add     []string
+		remove  []string
+	}{
+		{name: "no change", oldTags: []string{"a", "b"}, newTags: []string{"b", "a"}, add: []string{}, remove: []string{}},
+		{name: "add tags", oldTags: []string{"a"}, newTags: []string{"a", "c", "b"}, add: []string{"b", "c"}, remove: []string{}},
+		{name: "remove tags", oldTags: []string{"a", "legal-hold"}, newTags: []string{"a"}, add: []string{}, remove: []string{"legal-hold"}},
+		{name: "replace tags", oldTags: []string{"a"}, newTags: []string{"b"}, add: []string{"b"}, remove: []string{"a"}},
+		{name: "no old tags", newTags: []string{"a", "a"}, add: []string{"a"}, remove: []string{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			add, remove := diffTags(tc.oldTags, tc.newTags)
+			assert.Equal(t, tc.add, add)
+			assert.Equal(t, tc.remove, remove)
+		})
+	}
+}
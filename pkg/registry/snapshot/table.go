@@ -0,0 +1,95 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"stash.appscode.dev/stash/apis/repositories"
+)
+
+var _ rest.TableConvertor = &REST{}
+
+var snapshotColumns = []metav1beta1.TableColumnDefinition{
+	{Name: "Name", Type: "string", Format: "name", Description: "Name of the snapshot"},
+	{Name: "Repository", Type: "string", Description: "Name of the Repository of the snapshot"},
+	{Name: "Hostname", Type: "string", Description: "Host whose data has been backed up in the snapshot"},
+	{Name: "Paths", Type: "string", Description: "Paths that have been backed up in the snapshot"},
+	{Name: "Size", Type: "string", Description: "Total size of the files of the snapshot"},
+	{Name: "Files", Type: "integer", Description: "Number of files of the snapshot", Priority: 1},
+	{Name: "BackupSession", Type: "string", Description: "BackupSession that has taken the snapshot", Priority: 1},
+	{Name: "Tags", Type: "string", Description: "Tags of the snapshot", Priority: 1},
+	{Name: "Age", Type: "string", Description: "Time elapsed since the snapshot has been taken"},
+}
+
+// ConvertToTable prints the snapshots with their host, paths, size and age in "kubectl get snapshots"
+func (r *REST) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1beta1.Table, error) {
+	table := &metav1beta1.Table{
+		ColumnDefinitions: snapshotColumns,
+	}
+
+	var snapshots []repositories.Snapshot
+	switch obj := object.(type) {
+	case *repositories.SnapshotList:
+		snapshots = obj.Items
+		table.Continue = obj.Continue
+	case *repositories.Snapshot:
+		snapshots = []repositories.Snapshot{*obj}
+	default:
+		return nil, fmt.Errorf("unexpected object of type %T", object)
+	}
+
+	for i := range snapshots {
+		s := &snapshots[i]
+		size := "<unknown>"
+		if s.Status.Size > 0 {
+			size = resource.NewQuantity(int64(s.Status.Size), resource.BinarySI).String()
+		}
+		table.Rows = append(table.Rows, metav1beta1.TableRow{
+			Cells: []interface{}{
+				s.Name,
+				repositoryOfSnapshot(*s),
+				s.Status.Hostname,
+				strings.Join(s.Status.Paths, ","),
+				size,
+				s.Status.FileCount,
+				s.Status.BackupSession,
+				strings.Join(s.Status.Tags, ","),
+				translateTimestampSince(s.CreationTimestamp),
+			},
+			Object: runtime.RawExtension{Object: s},
+		})
+	}
+	if m, err := meta.ListAccessor(object); err == nil {
+		table.ResourceVersion = m.GetResourceVersion()
+		table.SelfLink = m.GetSelfLink()
+	}
+	return table, nil
+}
+
+// translateTimestampSince returns the elapsed time since timestamp in short human readable form (i.e. 5m, 3h, 2d)
+func translateTimestampSince(timestamp metav1.Time) string {
+	if timestamp.IsZero() {
+		return "<unknown>"
+	}
+	d := time.Since(timestamp.Time)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	default:
+		return fmt.Sprintf("%dy", int(d.Hours()/24/365))
+	}
+}
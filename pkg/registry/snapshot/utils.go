@@ -3,23 +3,31 @@ package snapshot
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/appscode/go/log"
 	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/remotecommand"
 	core_util "kmodules.xyz/client-go/core/v1"
+	"stash.appscode.dev/stash/apis"
 	"stash.appscode.dev/stash/apis/repositories"
 	stash "stash.appscode.dev/stash/apis/stash/v1alpha1"
-	"stash.appscode.dev/stash/pkg/cli"
+	stash_util "stash.appscode.dev/stash/client/clientset/versioned/typed/stash/v1alpha1/util"
+	"stash.appscode.dev/stash/pkg/eventer"
 	"stash.appscode.dev/stash/pkg/restic"
 	"stash.appscode.dev/stash/pkg/util"
 )
@@ -30,99 +38,6 @@ const (
 	secretDirName  = "secret"
 )
 
-func (r *REST) getSnapshots(repository *stash.Repository, snapshotIDs []string) ([]repositories.Snapshot, error) {
-	backend := repository.Spec.Backend.DeepCopy()
-
-	info, err := util.ExtractDataFromRepositoryLabel(repository.Labels)
-	if err != nil {
-		return nil, err
-	}
-
-	workload := &stash.LocalTypedReference{
-		Kind: info.WorkloadKind,
-		Name: info.WorkloadName,
-	}
-	hostName, smartPrefix, err := workload.HostnamePrefix(info.PodName, info.NodeName)
-
-	secret, err := r.kubeClient.CoreV1().Secrets(repository.Namespace).Get(backend.StorageSecretName, metav1.GetOptions{})
-	if err != nil {
-		return nil, err
-	}
-
-	backend = util.FixBackendPrefix(backend, smartPrefix)
-
-	cli := cli.New("/tmp", false, hostName)
-	if _, err = cli.SetupEnv(*backend, secret, smartPrefix); err != nil {
-		return nil, err
-	}
-
-	results, err := cli.ListSnapshots(snapshotIDs)
-	if err != nil {
-		return nil, err
-	}
-
-	snapshots := make([]repositories.Snapshot, 0)
-	snapshot := &repositories.Snapshot{}
-	for _, result := range results {
-		snapshot.Namespace = repository.Namespace
-		snapshot.Name = repository.Name + "-" + result.ID[0:util.SnapshotIDLength] // snapshotName = repositoryName-first8CharacterOfSnapshotId
-		snapshot.UID = types.UID(result.ID)
-
-		snapshot.Labels = map[string]string{
-			"repository": repository.Name,
-		}
-		if repository.Labels != nil {
-			snapshot.Labels = core_util.UpsertMap(snapshot.Labels, repository.Labels)
-		}
-
-		snapshot.CreationTimestamp.Time = result.Time
-		snapshot.Status.UID = result.UID
-		snapshot.Status.Gid = result.Gid
-		snapshot.Status.Hostname = result.Hostname
-		snapshot.Status.Paths = result.Paths
-		snapshot.Status.Tree = result.Tree
-		snapshot.Status.Username = result.Username
-		snapshot.Status.Tags = result.Tags
-
-		snapshots = append(snapshots, *snapshot)
-	}
-	return snapshots, nil
-}
-
-func (r *REST) forgetSnapshots(repository *stash.Repository, snapshotIDs []string) error {
-	backend := repository.Spec.Backend.DeepCopy()
-
-	info, err := util.ExtractDataFromRepositoryLabel(repository.Labels)
-	if err != nil {
-		return err
-	}
-
-	workload := &stash.LocalTypedReference{
-		Kind: info.WorkloadKind,
-		Name: info.WorkloadName,
-	}
-	hostName, smartPrefix, err := workload.HostnamePrefix(info.PodName, info.NodeName)
-
-	secret, err := r.kubeClient.CoreV1().Secrets(repository.Namespace).Get(backend.StorageSecretName, metav1.GetOptions{})
-	if err != nil {
-		return err
-	}
-
-	backend = util.FixBackendPrefix(backend, smartPrefix)
-
-	cli := cli.New("/tmp", false, hostName)
-	if _, err = cli.SetupEnv(*backend, secret, smartPrefix); err != nil {
-		return err
-	}
-
-	err = cli.DeleteSnapshots(snapshotIDs)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
 func (r *REST) getSnapshotsFromSidecar(repository *stash.Repository, snapshotIDs []string) ([]repositories.Snapshot, error) {
 	response, err := r.execOnSidecar(repository, "snapshots", snapshotIDs)
 	if err != nil {
@@ -138,8 +53,14 @@ func (r *REST) getSnapshotsFromSidecar(repository *stash.Repository, snapshotIDs
 	return snapshots, nil
 }
 
-func (r *REST) forgetSnapshotsFromSidecar(repository *stash.Repository, snapshotIDs []string) error {
-	_, err := r.execOnSidecar(repository, "forget", snapshotIDs)
+// forgetSnapshotsFromSidecar deletes the snapshots of a local backend using the sidecar. If prune is false,
+// only the snapshots are removed and their data are left in the repository.
+func (r *REST) forgetSnapshotsFromSidecar(repository *stash.Repository, snapshotIDs []string, prune bool) error {
+	args := snapshotIDs
+	if !prune {
+		args = append([]string{"--prune=false"}, snapshotIDs...)
+	}
+	_, err := r.execOnSidecar(repository, "forget", args)
 	return err
 }
 
@@ -246,13 +167,16 @@ func (r *REST) execCommandOnPod(pod *core.Pod, command []string) ([]byte, error)
 	return execOut.Bytes(), nil
 }
 
-func (r *REST) getV1Beta1Snapshots(repository *stash.Repository, snapshotIDs []string) ([]repositories.Snapshot, error) {
-	tempDir, err := ioutil.TempDir("", "stash")
-	if err != nil {
-		return nil, err
+// newResticWrapper writes the storage secret of the repository in a directory inside tempDir and
+// configures a restic wrapper from the backend of the repository. It works for the repositories created
+// by Restic as well as BackupConfiguration.
+func (r *REST) newResticWrapper(repository *stash.Repository, tempDir string) (*restic.ResticWrapper, error) {
+	if isV1Alpha1Repository(*repository) {
+		var err error
+		if repository, err = normalizeV1Alpha1Repository(repository); err != nil {
+			return nil, err
+		}
 	}
-	// cleanup whole tempDir dir at the end
-	defer os.RemoveAll(tempDir)
 
 	scratchDir := filepath.Join(tempDir, scratchDirName)
 	secretDir := filepath.Join(tempDir, secretDirName)
@@ -285,7 +209,55 @@ func (r *REST) getV1Beta1Snapshots(repository *stash.Repository, snapshotIDs []s
 		return nil, fmt.Errorf("setup option for repository failed, reason: %s", err)
 	}
 	// init restic wrapper
-	resticWrapper, err := restic.NewResticWrapper(setupOpt)
+	return restic.NewResticWrapper(setupOpt)
+}
+
+// normalizeV1Alpha1Repository returns a copy of a repository created by Restic whose backend points to the same
+// location as the one used by the sidecar. The prefix of such repository is the one returned by cli.SetupEnv. So,
+// it may include the bucket and the smart prefix of the workload. They are removed using the workload information
+// of the repository labels and the smart prefix is appended again in the way "cli.SetupEnv" does.
+func normalizeV1Alpha1Repository(repository *stash.Repository) (*stash.Repository, error) {
+	info, err := util.ExtractDataFromRepositoryLabel(repository.Labels)
+	if err != nil {
+		return nil, err
+	}
+	workload := &stash.LocalTypedReference{
+		Kind: info.WorkloadKind,
+		Name: info.WorkloadName,
+	}
+	_, smartPrefix, err := workload.HostnamePrefix(info.PodName, info.NodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	out := repository.DeepCopy()
+	backend := util.FixBackendPrefix(&out.Spec.Backend, smartPrefix)
+	if backend.Local != nil {
+		backend.Local.SubPath = smartPrefix
+	} else if backend.S3 != nil {
+		backend.S3.Prefix = path.Join(backend.S3.Prefix, smartPrefix)
+	} else if backend.GCS != nil {
+		backend.GCS.Prefix = path.Join(backend.GCS.Prefix, smartPrefix)
+	} else if backend.Azure != nil {
+		backend.Azure.Prefix = path.Join(backend.Azure.Prefix, smartPrefix)
+	} else if backend.Swift != nil {
+		backend.Swift.Prefix = path.Join(backend.Swift.Prefix, smartPrefix)
+	} else if backend.B2 != nil {
+		backend.B2.Prefix = path.Join(backend.B2.Prefix, smartPrefix)
+	}
+	return out, nil
+}
+
+// getSnapshots lists the snapshots of all hosts of the repository
+func (r *REST) getSnapshots(repository *stash.Repository, snapshotIDs []string) ([]repositories.Snapshot, error) {
+	tempDir, err := ioutil.TempDir("", "stash")
+	if err != nil {
+		return nil, err
+	}
+	// cleanup whole tempDir dir at the end
+	defer os.RemoveAll(tempDir)
+
+	resticWrapper, err := r.newResticWrapper(repository, tempDir)
 	if err != nil {
 		return nil, err
 	}
@@ -302,34 +274,90 @@ func (r *REST) getV1Beta1Snapshots(repository *stash.Repository, snapshotIDs []s
 	}
 
 	snapshots := make([]repositories.Snapshot, 0)
-	snapshot := &repositories.Snapshot{}
 	for _, result := range results {
-		snapshot.Namespace = repository.Namespace
-		snapshot.Name = repository.Name + "-" + result.ID[0:util.SnapshotIDLength] // snapshotName = repositoryName-first8CharacterOfSnapshotId
-		snapshot.UID = types.UID(result.ID)
+		snapshots = append(snapshots, toSnapshot(repository, result))
+	}
+	return snapshots, nil
+}
 
-		snapshot.Labels = map[string]string{
-			"repository": repository.Name,
-		}
-		if repository.Labels != nil {
-			snapshot.Labels = core_util.UpsertMap(snapshot.Labels, repository.Labels)
+func toSnapshot(repository *stash.Repository, result restic.Snapshot) repositories.Snapshot {
+	snapshot := repositories.Snapshot{}
+	snapshot.Namespace = repository.Namespace
+	snapshot.Name = repository.Name + "-" + result.ID[0:util.SnapshotIDLength] // snapshotName = repositoryName-first8CharacterOfSnapshotId
+	snapshot.UID = types.UID(result.ID)
+
+	snapshot.Labels = map[string]string{
+		"repository": repository.Name,
+	}
+	if repository.Labels != nil {
+		snapshot.Labels = core_util.UpsertMap(snapshot.Labels, repository.Labels)
+	}
+	// add labels from the tags added by Stash so that snapshots can be selected by BackupSession, BackupConfiguration etc.
+	tagLabels := util.SnapshotLabelsFromTags(result.Tags)
+	snapshot.Labels = core_util.UpsertMap(snapshot.Labels, tagLabels)
+
+	snapshot.CreationTimestamp.Time = result.Time
+	snapshot.Status.UID = result.UID
+	snapshot.Status.Gid = result.Gid
+	snapshot.Status.Hostname = result.Hostname
+	snapshot.Status.Paths = result.Paths
+	snapshot.Status.Tree = result.Tree
+	snapshot.Status.Username = result.Username
+	snapshot.Status.Tags = result.Tags
+	snapshot.Status.BackupSession = tagLabels[util.SnapshotLabelKey(util.TagBackupSession)]
+	snapshot.Status.BackupConfiguration = tagLabels[util.SnapshotLabelKey(util.TagBackupConfiguration)]
+	return snapshot
+}
+
+// setSnapshotStats sets the size and the number of files of the snapshots of the repository. Computing them requires
+// walking through the whole tree of a snapshot. So, it should be called only for the snapshots that are returned.
+// The stats of the snapshots that are not cached are computed concurrently using at most maxConcurrentStatsComputation
+// workers. The stats of the rest of the snapshots are set even if it fails for some of them.
+// The snapshots of local backend are not accessible from here. So, their stats are not set.
+func (r *REST) setSnapshotStats(repository *stash.Repository, snapshots []repositories.Snapshot) error {
+	if repository.Spec.Backend.Local != nil {
+		return nil
+	}
+	// the content of a snapshot never changes. so, the cached stats are always valid.
+	var missing []int
+	for i := range snapshots {
+		if stat, found := r.cache.getStats(snapshots[i].UID); found {
+			snapshots[i].Status.Size = stat.size
+			snapshots[i].Status.FileCount = stat.fileCount
+		} else {
+			missing = append(missing, i)
 		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
 
-		snapshot.CreationTimestamp.Time = result.Time
-		snapshot.Status.UID = result.UID
-		snapshot.Status.Gid = result.Gid
-		snapshot.Status.Hostname = result.Hostname
-		snapshot.Status.Paths = result.Paths
-		snapshot.Status.Tree = result.Tree
-		snapshot.Status.Username = result.Username
-		snapshot.Status.Tags = result.Tags
+	workers := maxConcurrentStatsComputation
+	if len(missing) < workers {
+		workers = len(missing)
+	}
+	queue := make(chan int, len(missing))
+	for _, i := range missing {
+		queue <- i
+	}
+	close(queue)
 
-		snapshots = append(snapshots, *snapshot)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			errs[w] = r.computeSnapshotStats(repository, snapshots, queue)
+		}(w)
 	}
-	return snapshots, nil
+	wg.Wait()
+	return errors.NewAggregate(errs)
 }
 
-func (r *REST) forgetV1Beta1Snapshots(repository *stash.Repository, snapshotIDs []string) error {
+// computeSnapshotStats computes the stats of the snapshots whose indexes are received from the queue.
+// Each worker uses its own restic wrapper as a wrapper can't run multiple commands at a time.
+func (r *REST) computeSnapshotStats(repository *stash.Repository, snapshots []repositories.Snapshot, queue <-chan int) error {
 	tempDir, err := ioutil.TempDir("", "stash")
 	if err != nil {
 		return err
@@ -337,62 +365,232 @@ func (r *REST) forgetV1Beta1Snapshots(repository *stash.Repository, snapshotIDs
 	// cleanup whole tempDir dir at the end
 	defer os.RemoveAll(tempDir)
 
-	scratchDir := filepath.Join(tempDir, scratchDirName)
-	secretDir := filepath.Join(tempDir, secretDirName)
+	resticWrapper, err := r.newResticWrapper(repository, tempDir)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for i := range queue {
+		// UID of a snapshot is the full ID of the restic snapshot
+		stat, err := resticWrapper.SnapshotStats(string(snapshots[i].UID))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		r.cache.setStats(snapshots[i].UID, snapshotStats{size: stat.TotalSize, fileCount: stat.TotalFileCount})
+		snapshots[i].Status.Size = stat.TotalSize
+		snapshots[i].Status.FileCount = stat.TotalFileCount
+	}
+	return errors.NewAggregate(errs)
+}
 
-	// get source repository secret
-	secret, err := r.kubeClient.CoreV1().Secrets(repository.Namespace).Get(repository.Spec.Backend.StorageSecretName, metav1.GetOptions{})
+// ForgetSnapshots deletes the snapshots of the repository. If prune is false, only the snapshots are removed
+// and their data are left in the repository to be removed by the next scheduled prune.
+func (r *REST) ForgetSnapshots(repository *stash.Repository, snapshotIDs []string, prune bool) error {
+	tempDir, err := ioutil.TempDir("", "stash")
 	if err != nil {
 		return err
 	}
+	// cleanup whole tempDir dir at the end
+	defer os.RemoveAll(tempDir)
 
-	// write repository secrets in a temp dir
-	if err := os.MkdirAll(secretDir, 0755); err != nil {
+	resticWrapper, err := r.newResticWrapper(repository, tempDir)
+	if err != nil {
 		return err
 	}
-	for key, value := range secret.Data {
-		if err := ioutil.WriteFile(filepath.Join(secretDir, key), value, 0755); err != nil {
-			return err
+	// never delete the snapshots under legal hold
+	snapshots, err := resticWrapper.ListSnapshots(snapshotIDs)
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range snapshots {
+		if isUnderLegalHold(snapshot.Tags) {
+			return fmt.Errorf("snapshot %s is under legal hold. remove %q tag to delete it", snapshot.ID[0:util.SnapshotIDLength], stash.SnapshotTagLegalHold)
 		}
 	}
+	if prune {
+		// delete snapshots along with their data
+		_, err = resticWrapper.DeleteSnapshots(snapshotIDs)
+		return err
+	}
+	_, err = resticWrapper.ForgetSnapshots(snapshotIDs)
+	return err
+}
 
-	// configure restic wrapper
-	extraOpt := util.ExtraOptions{
-		SecretDir:   secretDir,
-		EnableCache: false,
-		ScratchDir:  scratchDir,
+// hasScheduledPrune returns true if a prune task has been scheduled for the repository
+func hasScheduledPrune(repository *stash.Repository) bool {
+	return repository.Spec.Maintenance != nil &&
+		repository.Spec.Maintenance.Prune != nil &&
+		repository.Spec.Maintenance.Prune.Schedule != ""
+}
+
+// addPendingPrune records in the Repository status that the data of the deleted snapshots are waiting for the next prune
+func (r *REST) addPendingPrune(repository *stash.Repository, snapshotsDeleted int) error {
+	_, err := stash_util.UpdateRepositoryStatus(r.stashClient.StashV1alpha1(), repository, func(in *stash.RepositoryStatus) *stash.RepositoryStatus {
+		if in.PendingPrune == nil {
+			now := metav1.Now()
+			in.PendingPrune = &stash.PendingPruneStatus{
+				Since: &now,
+			}
+		}
+		in.PendingPrune.SnapshotsDeleted += snapshotsDeleted
+		return in
+	}, apis.EnableStatusSubresource)
+	return err
+}
+
+func isUnderLegalHold(tags []string) bool {
+	for _, tag := range tags {
+		if tag == stash.SnapshotTagLegalHold {
+			return true
+		}
 	}
-	// configure setupOption
-	setupOpt, err := util.SetupOptionsForRepository(*repository, extraOpt)
+	return false
+}
+
+// updateSnapshotTags adds and removes tags of a snapshot. It returns the updated snapshot which has a new ID.
+func (r *REST) updateSnapshotTags(repository *stash.Repository, snapshotID string, add, remove []string) (*repositories.Snapshot, error) {
+	tempDir, err := ioutil.TempDir("", "stash")
 	if err != nil {
-		return fmt.Errorf("setup option for repository failed, reason: %s", err)
+		return nil, err
 	}
-	// init restic wrapper
-	resticWrapper, err := restic.NewResticWrapper(setupOpt)
+	// cleanup whole tempDir dir at the end
+	defer os.RemoveAll(tempDir)
+
+	resticWrapper, err := r.newResticWrapper(repository, tempDir)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	// delete snapshots
-	_, err = resticWrapper.DeleteSnapshots(snapshotIDs)
-	return err
+	result, err := resticWrapper.UpdateTags(snapshotID, add, remove)
+	// the snapshot may have been replaced even if updating the tags fails. clear the cache only after the command
+	// has finished. otherwise, a concurrent listing may cache the snapshots again before they have been replaced.
+	r.cache.invalidate(repository.Namespace, repository.Name)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := toSnapshot(repository, *result)
+	return &snapshot, nil
+}
+
+// getRepositoryForSnapshot returns the Repository of a snapshot and the ID of the snapshot. The snapshots of
+// a local backend are accessible only from the workload pods. So, they can't be accessed directly.
+func (r *REST) getRepositoryForSnapshot(ctx context.Context, namespace, name, verb string) (*stash.Repository, string, error) {
+	repoName, snapshotId, err := util.GetRepoNameAndSnapshotID(name)
+	if err != nil {
+		return nil, "", apierrors.NewBadRequest(err.Error())
+	}
+
+	repo, err := r.stashClient.StashV1alpha1().Repositories(namespace).Get(repoName, metav1.GetOptions{})
+	if err != nil {
+		if kerr.IsNotFound(err) {
+			return nil, "", apierrors.NewNotFound(stash.Resource(stash.ResourceSingularRepository), repoName)
+		}
+		return nil, "", apierrors.NewInternalError(err)
+	}
+	if err = r.authorize(ctx, repo, verb); err != nil {
+		return nil, "", err
+	}
+	if repo.Spec.Backend.Local != nil {
+		return nil, "", apierrors.NewBadRequest("this operation is not supported for the snapshots of local backend")
+	}
+	return repo, snapshotId, nil
+}
+
+// writeAuditEvent records an access to the snapshots of the repository as an event of the Repository
+func (r *REST) writeAuditEvent(repository *stash.Repository, reason, msg string) {
+	_, err := eventer.CreateEvent(
+		r.kubeClient,
+		eventer.EventSourceSnapshotAPI,
+		repository,
+		core.EventTypeNormal,
+		reason,
+		msg,
+	)
+	if err != nil {
+		log.Errorf("failed to write event for Repository %s/%s. Reason: %v", repository.Namespace, repository.Name, err)
+	}
+}
+
+// getSnapshotFiles lists all the files of a snapshot. It returns false if the snapshot does not exist.
+func (r *REST) getSnapshotFiles(repository *stash.Repository, snapshotID string) ([]restic.Node, bool, error) {
+	tempDir, err := ioutil.TempDir("", "stash")
+	if err != nil {
+		return nil, false, err
+	}
+	// cleanup whole tempDir dir at the end
+	defer os.RemoveAll(tempDir)
+
+	resticWrapper, err := r.newResticWrapper(repository, tempDir)
+	if err != nil {
+		return nil, false, err
+	}
+	snapshots, err := resticWrapper.ListSnapshots([]string{snapshotID})
+	if err != nil {
+		if repoNotFound(resticWrapper.GetRepo(), err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	if len(snapshots) == 0 {
+		return nil, false, nil
+	}
+	nodes, err := resticWrapper.ListFiles(snapshots[0].ID)
+	return nodes, true, err
+}
+
+// diffSnapshots compares two snapshots of the repository. It returns false if any of the snapshots does not exist.
+func (r *REST) diffSnapshots(repository *stash.Repository, fromSnapshotID, toSnapshotID string) (*restic.SnapshotDiff, bool, error) {
+	tempDir, err := ioutil.TempDir("", "stash")
+	if err != nil {
+		return nil, false, err
+	}
+	// cleanup whole tempDir dir at the end
+	defer os.RemoveAll(tempDir)
+
+	resticWrapper, err := r.newResticWrapper(repository, tempDir)
+	if err != nil {
+		return nil, false, err
+	}
+	snapshots, err := resticWrapper.ListSnapshots([]string{fromSnapshotID, toSnapshotID})
+	if err != nil {
+		if repoNotFound(resticWrapper.GetRepo(), err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	if len(snapshots) != 2 {
+		return nil, false, nil
+	}
+	diff, err := resticWrapper.Diff(fromSnapshotID, toSnapshotID)
+	return diff, true, err
 }
 
 func (r *REST) GetVersionedSnapshots(repository *stash.Repository, snapshotIDs []string, inCluster bool) ([]repositories.Snapshot, error) {
 	if repository.Spec.Backend.Local != nil && !inCluster {
 		return r.getSnapshotsFromSidecar(repository, snapshotIDs)
-	} else if isV1Alpha1Repository(*repository) {
-		return r.getSnapshots(repository, snapshotIDs)
 	}
-	return r.getV1Beta1Snapshots(repository, snapshotIDs)
+	return r.getSnapshots(repository, snapshotIDs)
 }
 
 func (r *REST) ForgetVersionedSnapshots(repository *stash.Repository, snapshotIDs []string, inCluster bool) error {
+	r.cache.invalidate(repository.Namespace, repository.Name)
+	// pruning rewrites the packs while holding an exclusive lock. so, if a prune has been scheduled,
+	// remove only the snapshots and leave their data to the next scheduled prune.
+	prune := !hasScheduledPrune(repository)
+	var err error
 	if repository.Spec.Backend.Local != nil && !inCluster {
-		return r.forgetSnapshotsFromSidecar(repository, snapshotIDs)
-	} else if isV1Alpha1Repository(*repository) {
-		return r.forgetSnapshots(repository, snapshotIDs)
+		err = r.forgetSnapshotsFromSidecar(repository, snapshotIDs, prune)
+	} else {
+		err = r.ForgetSnapshots(repository, snapshotIDs, prune)
+	}
+	if err != nil || prune {
+		return err
+	}
+	if err = r.addPendingPrune(repository, len(snapshotIDs)); err != nil {
+		// the snapshots have been removed. the data will be removed by the scheduled prune anyway.
+		log.Errorf("failed to update pending prune status of Repository %s/%s. Reason: %v", repository.Namespace, repository.Name, err)
 	}
-	return r.forgetV1Beta1Snapshots(repository, snapshotIDs)
+	return nil
 }
 
 // v1alpha1 repositories should have 'restic' label
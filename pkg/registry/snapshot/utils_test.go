@@ -0,0 +1,93 @@
+package snapshot
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	store "kmodules.xyz/objectstore-api/api/v1"
+	stash "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+func TestNormalizeV1Alpha1Repository(t *testing.T) {
+	// newRepository returns a Repository as created by the sidecar of a Restic
+	newRepository := func(labels map[string]string, backend store.Backend) *stash.Repository {
+		return &stash.Repository{
+			ObjectMeta: metav1.ObjectMeta{Name: "deployment.app", Namespace: "demo", Labels: labels},
+			Spec:       stash.RepositorySpec{Backend: backend},
+		}
+	}
+	deploymentLabels := map[string]string{"restic": "app-backup", "workload-kind": "Deployment", "workload-name": "app"}
+	s3Backend := func(prefix string) store.Backend {
+		return store.Backend{
+			StorageSecretName: "s3-secret",
+			S3:                &store.S3Spec{Endpoint: "s3.amazonaws.com", Bucket: "stash-backup", Prefix: prefix},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		repository *stash.Repository
+		url        string
+	}{
+		{
+			name:       "s3 with prefix",
+			repository: newRepository(deploymentLabels, s3Backend("stash-backup/demo/deployment/app")),
+			url:        "s3:s3.amazonaws.com/stash-backup/demo/deployment/app",
+		},
+		{
+			name:       "s3 without prefix",
+			repository: newRepository(deploymentLabels, s3Backend("stash-backup/deployment/app")),
+			url:        "s3:s3.amazonaws.com/stash-backup/deployment/app",
+		},
+		{
+			name: "s3 of a statefulset pod",
+			repository: newRepository(
+				map[string]string{"restic": "db-backup", "workload-kind": "StatefulSet", "workload-name": "db", "pod-name": "db-0"},
+				s3Backend("stash-backup/demo/statefulset/db-0"),
+			),
+			url: "s3:s3.amazonaws.com/stash-backup/demo/statefulset/db-0",
+		},
+		{
+			name: "gcs",
+			repository: newRepository(deploymentLabels, store.Backend{
+				StorageSecretName: "gcs-secret",
+				GCS:               &store.GCSSpec{Bucket: "stash-backup", Prefix: "demo/deployment/app"},
+			}),
+			url: "gs:stash-backup:/demo/deployment/app",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repository, err := normalizeV1Alpha1Repository(tc.repository)
+			if !assert.NoError(t, err) {
+				return
+			}
+			setupOpt, err := util.SetupOptionsForRepository(*repository, util.ExtraOptions{})
+			if !assert.NoError(t, err) {
+				return
+			}
+			// same as the repository url used by restic wrapper
+			var url string
+			switch setupOpt.Provider {
+			case restic.ProviderS3:
+				url = fmt.Sprintf("s3:%s/%s", setupOpt.Endpoint, filepath.Join(setupOpt.Bucket, setupOpt.Path))
+			case restic.ProviderGCS:
+				url = fmt.Sprintf("gs:%s:/%s", setupOpt.Bucket, setupOpt.Path)
+			}
+			assert.Equal(t, tc.url, url)
+		})
+	}
+}
+
+func TestNormalizeV1Alpha1RepositoryWithoutWorkload(t *testing.T) {
+	repository := &stash.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "deployment.app", Namespace: "demo", Labels: map[string]string{"restic": "app-backup"}},
+	}
+	_, err := normalizeV1Alpha1Repository(repository)
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package restic
 import (
 	"time"
 
+	"stash.appscode.dev/stash/apis/stash/v1alpha1"
 	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
 )
 
@@ -10,22 +11,22 @@ func (w *ResticWrapper) RunBackup(backupOption BackupOptions) (*BackupOutput, er
 	// Start clock to measure total session duration
 	startTime := time.Now()
 
-	// Initialize restic repository if it does not exist
-	_, err := w.initRepositoryIfAbsent()
-	if err != nil {
-		return nil, err
-	}
-
 	backupOutput := &BackupOutput{
 		HostBackupStats: api_v1beta1.HostBackupStats{
 			Hostname: backupOption.Host,
 		},
 	}
 
+	// Initialize restic repository if it does not exist
+	_, err := w.initRepositoryIfAbsent()
+	if err != nil {
+		return cancelledBackupOutput(backupOutput, startTime, err)
+	}
+
 	if backupOption.StdinPipeCommand.Name != "" { // Backup from stdin
 		out, err := w.backupFromStdin(backupOption)
 		if err != nil {
-			return nil, err
+			return cancelledBackupOutput(backupOutput, startTime, err)
 		}
 		// Extract information from the output of backup command
 		err = backupOutput.extractBackupInfo(out, backupOption.StdinFileName, backupOption.Host)
@@ -34,9 +35,9 @@ func (w *ResticWrapper) RunBackup(backupOption BackupOptions) (*BackupOutput, er
 		}
 	} else { // Backup all target directories
 		for _, dir := range backupOption.BackupDirs {
-			out, err := w.backup(dir, backupOption.Host, nil)
+			out, err := w.backup(dir, backupOption)
 			if err != nil {
-				return nil, err
+				return cancelledBackupOutput(backupOutput, startTime, err)
 			}
 			// Extract information from the output of backup command
 			err = backupOutput.extractBackupInfo(out, dir, backupOption.Host)
@@ -46,41 +47,74 @@ func (w *ResticWrapper) RunBackup(backupOption BackupOptions) (*BackupOutput, er
 		}
 	}
 
-	// Check repository integrity
-	out, err := w.check()
-	if err != nil {
-		return nil, err
+	// Run repository maintenance unless it has been deferred to a coordinated step
+	if !backupOption.SkipRepositoryMaintenance {
+		if err = w.runRepositoryMaintenance(backupOutput, []v1alpha1.RetentionPolicy{backupOption.RetentionPolicy}, backupOption.SkipCheck); err != nil {
+			return cancelledBackupOutput(backupOutput, startTime, err)
+		}
 	}
-	// Extract information from output of "check" command
-	backupOutput.extractCheckInfo(out)
 
-	// Cleanup old snapshot according to retention policy
-	out, err = w.cleanup(backupOption.RetentionPolicy)
-	if err != nil {
+	// Backup complete. Read current time and calculate total backup duration.
+	endTime := time.Now()
+	backupOutput.HostBackupStats.Duration = endTime.Sub(startTime).String()
+	backupOutput.HostBackupStats.Phase = api_v1beta1.HostBackupSucceeded
+
+	return backupOutput, nil
+}
+
+// cancelledBackupOutput returns the stats of the directories that have been backed up so far if the backup
+// has been cancelled. For any other error, no output is returned.
+func cancelledBackupOutput(backupOutput *BackupOutput, startTime time.Time, err error) (*BackupOutput, error) {
+	if err != ErrCancelled {
 		return nil, err
 	}
-	// Extract information from output of cleanup command
-	err = backupOutput.extractCleanupInfo(out)
-	if err != nil {
+	backupOutput.HostBackupStats.Duration = time.Since(startTime).String()
+	backupOutput.HostBackupStats.Phase = api_v1beta1.HostBackupCancelled
+	backupOutput.HostBackupStats.Error = "backup has been cancelled"
+	// repository stats are incomplete for a cancelled backup
+	backupOutput.RepositoryStats = RepositoryStats{}
+	return backupOutput, err
+}
+
+// RunRepositoryMaintenance checks repository integrity, cleans old snapshots according to each of the retention
+// policies and reads the repository statistics. It is used when repository maintenance has been skipped during backup.
+func (w *ResticWrapper) RunRepositoryMaintenance(retentionPolicies []v1alpha1.RetentionPolicy, skipCheck bool) (*RepositoryStats, error) {
+	backupOutput := &BackupOutput{}
+	if err := w.runRepositoryMaintenance(backupOutput, retentionPolicies, skipCheck); err != nil {
 		return nil, err
 	}
+	return &backupOutput.RepositoryStats, nil
+}
 
-	// Read repository statics after cleanup
-	out, err = w.stats()
-	if err != nil {
-		return nil, err
+func (w *ResticWrapper) runRepositoryMaintenance(backupOutput *BackupOutput, retentionPolicies []v1alpha1.RetentionPolicy, skipCheck bool) error {
+	// Check repository integrity unless it has been scheduled separately
+	if !skipCheck {
+		out, err := w.check(CheckOptions{})
+		if err != nil {
+			return err
+		}
+		// Extract information from output of "check" command
+		backupOutput.extractCheckInfo(out)
 	}
 
-	// Extract information from output of "stats" command
-	err = backupOutput.extractStatsInfo(out)
-	if err != nil {
-		return nil, err
+	// Cleanup old snapshot according to each retention policy
+	for _, retentionPolicy := range retentionPolicies {
+		out, err := w.cleanup(retentionPolicy)
+		if err != nil {
+			return err
+		}
+		// Extract information from output of cleanup command
+		if err = backupOutput.extractCleanupInfo(out); err != nil {
+			return err
+		}
 	}
 
-	// Backup complete. Read current time and calculate total backup duration.
-	endTime := time.Now()
-	backupOutput.HostBackupStats.Duration = endTime.Sub(startTime).String()
-	backupOutput.HostBackupStats.Phase = api_v1beta1.HostBackupSucceeded
+	// Read repository statics after cleanup
+	out, err := w.stats()
+	if err != nil {
+		return err
+	}
 
-	return backupOutput, nil
+	// Extract information from output of "stats" command
+	return backupOutput.extractStatsInfo(out)
 }
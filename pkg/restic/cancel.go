@@ -0,0 +1,52 @@
+package restic
+
+import (
+	"errors"
+	"syscall"
+
+	"github.com/appscode/go/log"
+)
+
+// ErrCancelled is returned when the running operation has been interrupted by Cancel()
+var ErrCancelled = errors.New("operation has been cancelled")
+
+// Cancel interrupts the running restic command and prevents any further command from running.
+// Restic removes the lock it holds on the repository when it is interrupted.
+func (w *ResticWrapper) Cancel() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cancelled {
+		return
+	}
+	w.cancelled = true
+	if w.running {
+		log.Infoln("Interrupting running restic command")
+		w.sh.Kill(syscall.SIGINT)
+	}
+}
+
+// IsCancelled returns true if Cancel() has been called
+func (w *ResticWrapper) IsCancelled() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.cancelled
+}
+
+// RemoveStaleLocks removes the locks that an interrupted restic process might have left in the repository.
+// It can be used after the wrapper has been cancelled.
+func (w *ResticWrapper) RemoveStaleLocks() error {
+	w.mu.Lock()
+	cancelled := w.cancelled
+	w.cancelled = false
+	w.mu.Unlock()
+
+	defer func() {
+		w.mu.Lock()
+		w.cancelled = w.cancelled || cancelled
+		w.mu.Unlock()
+	}()
+
+	_, err := w.removeStaleLocks()
+	return err
+}
@@ -1,6 +1,7 @@
 package restic
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,11 +10,14 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/appscode/go/log"
 	"github.com/armon/circbuf"
+	shell "github.com/codeskyblue/go-sh"
 	"stash.appscode.dev/stash/apis/stash/v1alpha1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
 )
 
 const (
@@ -30,6 +34,7 @@ type Snapshot struct {
 	UID      int       `json:"uid"`
 	Gid      int       `json:"gid"`
 	Tags     []string  `json:"tags"`
+	Original string    `json:"original,omitempty"` // ID of the snapshot this one has been derived from by changing its tags
 }
 
 func (w *ResticWrapper) listSnapshots(snapshotIDs []string) ([]Snapshot, error) {
@@ -48,8 +53,33 @@ func (w *ResticWrapper) listSnapshots(snapshotIDs []string) ([]Snapshot, error)
 	return result, err
 }
 
-func (w *ResticWrapper) deleteSnapshots(snapshotIDs []string) ([]byte, error) {
-	args := w.appendCacheDirFlag([]interface{}{"forget", "--quiet", "--prune"})
+// findSnapshots returns the snapshots of the host that have all the tags. Empty host or tags match any snapshot.
+func (w *ResticWrapper) findSnapshots(host string, tags []string) ([]Snapshot, error) {
+	result := make([]Snapshot, 0)
+	args := w.appendCacheDirFlag([]interface{}{"snapshots", "--json", "--quiet", "--no-lock"})
+	args = w.appendCaCertFlag(args)
+	args = w.appendMaxConnectionsFlag(args)
+	if host != "" {
+		args = append(args, "--host")
+		args = append(args, host)
+	}
+	if len(tags) > 0 {
+		args = append(args, "--tag")
+		args = append(args, strings.Join(tags, ","))
+	}
+	out, err := w.run(Command{Name: ResticCMD, Args: args})
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(out, &result)
+	return result, err
+}
+
+func (w *ResticWrapper) deleteSnapshots(snapshotIDs []string, prune bool) ([]byte, error) {
+	args := w.appendCacheDirFlag([]interface{}{"forget", "--quiet"})
+	if prune {
+		args = append(args, "--prune")
+	}
 	args = w.appendCaCertFlag(args)
 	args = w.appendMaxConnectionsFlag(args)
 	for _, id := range snapshotIDs {
@@ -74,24 +104,65 @@ func (w *ResticWrapper) initRepositoryIfAbsent() ([]byte, error) {
 	return nil, nil
 }
 
-func (w *ResticWrapper) backup(path, host string, tags []string) ([]byte, error) {
+func (w *ResticWrapper) backup(path string, options BackupOptions) ([]byte, error) {
 	log.Infoln("Backing up target data")
-	args := []interface{}{"backup", path, "--quiet", "--json"}
-	if host != "" {
+	args := []interface{}{"backup", path, "--json"}
+	// restic does not print the status lines in quiet mode
+	if options.OnProgress == nil {
+		args = append(args, "--quiet")
+	}
+	if options.Host != "" {
 		args = append(args, "--host")
-		args = append(args, host)
+		args = append(args, options.Host)
 	}
 	// add tags if any
-	for _, tag := range tags {
+	for _, tag := range options.Tags {
 		args = append(args, "--tag")
 		args = append(args, tag)
 	}
+	args = appendBackupFilterFlags(args, path, options.Filter)
+	// the snapshot must contain the directory itself. so, the files that don't match the include
+	// patterns are excluded instead of backing up the matching files only.
+	if len(options.Filter.Include) > 0 {
+		excludeFile, err := w.writeIncludeExcludeFile(path, options.Filter.Include)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(excludeFile)
+		args = append(args, "--exclude-file", excludeFile)
+	}
 	args = w.appendCacheDirFlag(args)
 	args = w.appendCleanupCacheFlag(args)
 	args = w.appendCaCertFlag(args)
 	args = w.appendMaxConnectionsFlag(args)
 
-	return w.run(Command{Name: ResticCMD, Args: args})
+	return w.runBackup(options.Host, path, options.OnProgress, Command{Name: ResticCMD, Args: args})
+}
+
+func appendBackupFilterFlags(args []interface{}, dir string, filter BackupFilter) []interface{} {
+	for _, pattern := range filter.Exclude {
+		args = append(args, "--exclude")
+		args = append(args, pattern)
+	}
+	for _, name := range filter.ExcludeIfPresent {
+		args = append(args, "--exclude-if-present")
+		args = append(args, name)
+	}
+	if filter.IgnoreFileName != "" {
+		// the ignore file is optional. so, use it only if it exists in the directory.
+		ignoreFile := filepath.Join(dir, filter.IgnoreFileName)
+		if _, err := os.Stat(ignoreFile); err == nil {
+			args = append(args, "--exclude-file")
+			args = append(args, ignoreFile)
+		}
+	}
+	if filter.ExcludeCaches {
+		args = append(args, "--exclude-caches")
+	}
+	if filter.OneFileSystem {
+		args = append(args, "--one-file-system")
+	}
+	return args
 }
 
 func (w *ResticWrapper) backupFromStdin(options BackupOptions) ([]byte, error) {
@@ -103,7 +174,11 @@ func (w *ResticWrapper) backupFromStdin(options BackupOptions) ([]byte, error) {
 		commands = append(commands, options.StdinPipeCommand)
 	}
 
-	args := []interface{}{"backup", "--stdin", "--quiet", "--json"}
+	args := []interface{}{"backup", "--stdin", "--json"}
+	// restic does not print the status lines in quiet mode
+	if options.OnProgress == nil {
+		args = append(args, "--quiet")
+	}
 	if options.StdinFileName != "" {
 		args = append(args, "--stdin-filename")
 		args = append(args, options.StdinFileName)
@@ -112,20 +187,24 @@ func (w *ResticWrapper) backupFromStdin(options BackupOptions) ([]byte, error) {
 		args = append(args, "--host")
 		args = append(args, options.Host)
 	}
+	// add tags if any
+	for _, tag := range options.Tags {
+		args = append(args, "--tag")
+		args = append(args, tag)
+	}
 	args = w.appendCacheDirFlag(args)
 	args = w.appendCleanupCacheFlag(args)
 	args = w.appendCaCertFlag(args)
 	args = w.appendMaxConnectionsFlag(args)
 
 	commands = append(commands, Command{Name: ResticCMD, Args: args})
-	return w.run(commands...)
+	return w.runBackup(options.Host, options.StdinFileName, options.OnProgress, commands...)
 }
 
-func (w *ResticWrapper) cleanup(retentionPolicy v1alpha1.RetentionPolicy) ([]byte, error) {
-	log.Infoln("Cleaning old snapshots according to retention policy")
-
-	args := []interface{}{"forget", "--quiet", "--json"}
-
+// RetentionPolicyArgs returns the arguments of "restic forget" command that apply the retention policy
+// to the snapshots selected by the filter of the policy.
+func RetentionPolicyArgs(retentionPolicy v1alpha1.RetentionPolicy) []interface{} {
+	var args []interface{}
 	if retentionPolicy.KeepLast > 0 {
 		args = append(args, string(v1alpha1.KeepLast))
 		args = append(args, strconv.Itoa(retentionPolicy.KeepLast))
@@ -154,12 +233,52 @@ func (w *ResticWrapper) cleanup(retentionPolicy v1alpha1.RetentionPolicy) ([]byt
 		args = append(args, string(v1alpha1.KeepTag))
 		args = append(args, tag)
 	}
+	if retentionPolicy.KeepWithin != "" {
+		args = append(args, string(v1alpha1.KeepWithin))
+		args = append(args, retentionPolicy.KeepWithin)
+	}
+	// snapshots under legal hold are never removed by a retention policy
+	if retentionPolicy.HasKeepRule() {
+		args = append(args, string(v1alpha1.KeepTag))
+		args = append(args, v1alpha1.SnapshotTagLegalHold)
+	}
+	if retentionPolicy.Filter != nil {
+		if len(retentionPolicy.Filter.GroupBy) > 0 {
+			var groupBy []string
+			for _, g := range retentionPolicy.Filter.GroupBy {
+				groupBy = append(groupBy, string(g))
+			}
+			args = append(args, "--group-by")
+			args = append(args, strings.Join(groupBy, ","))
+		}
+		if retentionPolicy.Filter.Host != "" {
+			args = append(args, "--host")
+			args = append(args, retentionPolicy.Filter.Host)
+		}
+		// a single --tag flag with comma separated tags matches the snapshots having all of those tags
+		if len(retentionPolicy.Filter.Tags) > 0 {
+			args = append(args, "--tag")
+			args = append(args, strings.Join(retentionPolicy.Filter.Tags, ","))
+		}
+		for _, path := range retentionPolicy.Filter.Paths {
+			args = append(args, "--path")
+			args = append(args, path)
+		}
+	}
 	if retentionPolicy.Prune {
 		args = append(args, "--prune")
 	}
 	if retentionPolicy.DryRun {
 		args = append(args, "--dry-run")
 	}
+	return args
+}
+
+func (w *ResticWrapper) cleanup(retentionPolicy v1alpha1.RetentionPolicy) ([]byte, error) {
+	log.Infoln("Cleaning old snapshots according to retention policy")
+
+	args := []interface{}{"forget", "--quiet", "--json"}
+	args = append(args, RetentionPolicyArgs(retentionPolicy)...)
 
 	if len(args) > 1 {
 		args = w.appendCacheDirFlag(args)
@@ -171,28 +290,20 @@ func (w *ResticWrapper) cleanup(retentionPolicy v1alpha1.RetentionPolicy) ([]byt
 	return nil, nil
 }
 
-func (w *ResticWrapper) restore(path, host, snapshotID, destination string) ([]byte, error) {
+// restore restores a snapshot. The includes are added to the include patterns of the options.
+func (w *ResticWrapper) restore(snapshotID string, restoreOptions RestoreOptions, includes []string) ([]byte, error) {
 	log.Infoln("Restoring backed up data")
 
-	args := []interface{}{"restore"}
-	if snapshotID != "" {
-		args = append(args, snapshotID)
-	} else {
-		args = append(args, "latest")
+	args := []interface{}{"restore", snapshotID}
+	for _, pattern := range append(restoreOptions.Include, includes...) {
+		args = append(args, "--include")
+		args = append(args, pattern)
 	}
-	if path != "" {
-		args = append(args, "--path")
-		args = append(args, path) // source-path specified in restic fileGroup
-	}
-	if host != "" {
-		args = append(args, "--host")
-		args = append(args, host)
+	for _, pattern := range restoreOptions.Exclude {
+		args = append(args, "--exclude")
+		args = append(args, pattern)
 	}
-
-	if destination == "" {
-		destination = "/" // restore in absolute path
-	}
-	args = append(args, "--target", destination)
+	args = append(args, "--target", restoreDestination(restoreOptions))
 
 	args = w.appendCacheDirFlag(args)
 	args = w.appendCaCertFlag(args)
@@ -228,6 +339,10 @@ func (w *ResticWrapper) dump(dumpOptions DumpOptions) ([]byte, error) {
 	args = w.appendCaCertFlag(args)
 	args = w.appendMaxConnectionsFlag(args)
 
+	if dumpOptions.StdoutPipeCommand.Name != "" && dumpOptions.OnProgress != nil {
+		return w.runDumpWithProgress(dumpOptions.Host, dumpOptions.OnProgress, Command{Name: ResticCMD, Args: args}, dumpOptions.StdoutPipeCommand)
+	}
+
 	// first add restic command, then add StdoutPipeCommand
 	commands := []Command{
 		{Name: ResticCMD, Args: args},
@@ -238,15 +353,115 @@ func (w *ResticWrapper) dump(dumpOptions DumpOptions) ([]byte, error) {
 	return w.run(commands...)
 }
 
-func (w *ResticWrapper) check() ([]byte, error) {
+// runDumpWithProgress passes the output of the dump command to the pipe command and reports the amount of
+// data passed so far. The pipe command runs in a separate session so that its input can be measured.
+func (w *ResticWrapper) runDumpWithProgress(host string, onProgress RestoreProgressFunc, dumpCommand, pipeCommand Command) ([]byte, error) {
+	pr, pw := io.Pipe()
+	pipeOut := bytes.NewBuffer(nil)
+	pipeSh := shell.NewSession()
+	for k, v := range w.sh.Env {
+		pipeSh.SetEnv(k, v)
+	}
+	pipeSh.SetDir(w.config.ScratchDir)
+	pipeSh.ShowCMD = true
+	pipeSh.Stdin = pr
+	pipeSh.Stdout = pipeOut
+	pipeSh.Stderr = os.Stderr
+	pipeSh.Command(pipeCommand.Name, pipeCommand.Args...)
+	if err := pipeSh.Start(); err != nil {
+		return nil, err
+	}
+	pipeErr := make(chan error, 1)
+	go func() {
+		err := pipeSh.Wait()
+		// unblock the dump command if the pipe command exits before reading all data
+		pr.CloseWithError(io.ErrClosedPipe)
+		pipeErr <- err
+	}()
+
+	counter := &countingWriter{w: pw}
+	stop := watchRestoreProgress(host, func(progress *api_v1beta1.HostRestoreProgress) {
+		progress.BytesRestored = counter.Count()
+	}, onProgress)
+	err := w.runWithStdout(counter, dumpCommand)
+	stop()
+	if err != nil {
+		// don't let the pipe command treat the partial data as complete
+		pipeSh.Kill(syscall.SIGINT)
+	}
+	pw.CloseWithError(err)
+	if perr := <-pipeErr; err == nil && perr != nil {
+		err = fmt.Errorf("%s failed. Reason: %v", pipeCommand.Name, perr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := pipeOut.Bytes()
+	log.Infoln("sh-output:", string(out))
+	return out, nil
+}
+
+// dumpFile writes the content of a file of the snapshot into out as restic produces it
+func (w *ResticWrapper) dumpFile(snapshotID, filePath string, out io.Writer) error {
+	args := w.appendCacheDirFlag([]interface{}{"dump", "--quiet", "--no-lock", snapshotID, filePath})
+	args = w.appendCaCertFlag(args)
+	args = w.appendMaxConnectionsFlag(args)
+
+	return w.runWithStdout(out, Command{Name: ResticCMD, Args: args})
+}
+
+func (w *ResticWrapper) diff(fromSnapshotID, toSnapshotID string) ([]byte, error) {
+	args := w.appendCacheDirFlag([]interface{}{"diff", "--no-lock", fromSnapshotID, toSnapshotID})
+	args = w.appendCaCertFlag(args)
+	args = w.appendMaxConnectionsFlag(args)
+
+	stdout := bytes.NewBuffer(nil)
+	if err := w.runWithStdout(stdout, Command{Name: ResticCMD, Args: args}); err != nil {
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+func (w *ResticWrapper) tag(snapshotID string, add, remove []string) ([]byte, error) {
+	log.Infoln("Updating tags of snapshot", snapshotID)
+	args := []interface{}{"tag"}
+	for _, t := range add {
+		args = append(args, "--add", t)
+	}
+	for _, t := range remove {
+		args = append(args, "--remove", t)
+	}
+	args = append(args, snapshotID)
+	args = w.appendCacheDirFlag(args)
+	args = w.appendCaCertFlag(args)
+	args = w.appendMaxConnectionsFlag(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
+func (w *ResticWrapper) check(opt CheckOptions) ([]byte, error) {
 	log.Infoln("Checking integrity of repository")
 	args := w.appendCacheDirFlag([]interface{}{"check"})
+	if opt.ReadData {
+		args = append(args, "--read-data")
+	} else if opt.ReadDataSubset != "" {
+		args = append(args, "--read-data-subset", opt.ReadDataSubset)
+	}
 	args = w.appendCaCertFlag(args)
 	args = w.appendMaxConnectionsFlag(args)
 
 	return w.run(Command{Name: ResticCMD, Args: args})
 }
 
+func (w *ResticWrapper) prune() ([]byte, error) {
+	log.Infoln("Removing unreferenced data from repository")
+	args := w.appendCacheDirFlag([]interface{}{"prune"})
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendCaCertFlag(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
 func (w *ResticWrapper) stats() ([]byte, error) {
 	log.Infoln("Reading repository status")
 	args := w.appendCacheDirFlag([]interface{}{"stats"})
@@ -257,6 +472,14 @@ func (w *ResticWrapper) stats() ([]byte, error) {
 	return w.run(Command{Name: ResticCMD, Args: args})
 }
 
+func (w *ResticWrapper) snapshotStats(snapshotID string) ([]byte, error) {
+	args := w.appendCacheDirFlag([]interface{}{"stats", "--no-lock", "--quiet", "--json", snapshotID})
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendCaCertFlag(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
 func (w *ResticWrapper) unlock() ([]byte, error) {
 	log.Infoln("Unlocking restic repository")
 	args := w.appendCacheDirFlag([]interface{}{"unlock", "--remove-all"})
@@ -266,6 +489,56 @@ func (w *ResticWrapper) unlock() ([]byte, error) {
 	return w.run(Command{Name: ResticCMD, Args: args})
 }
 
+// removeStaleLocks removes only the locks whose owner process is no longer running.
+// The locks held by the other running restic processes are kept intact.
+func (w *ResticWrapper) removeStaleLocks() ([]byte, error) {
+	log.Infoln("Removing stale locks from restic repository")
+	args := w.appendCacheDirFlag([]interface{}{"unlock"})
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendCaCertFlag(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
+// listFiles runs "restic ls" for the snapshot. The output is not logged because it can be huge.
+func (w *ResticWrapper) listFiles(snapshotID string) ([]byte, error) {
+	args := w.appendCacheDirFlag([]interface{}{"ls", "--json", "--no-lock", snapshotID})
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendCaCertFlag(args)
+
+	stdout := bytes.NewBuffer(nil)
+	if err := w.runWithStdout(stdout, Command{Name: ResticCMD, Args: args}); err != nil {
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+func (w *ResticWrapper) listKeys() ([]byte, error) {
+	args := w.appendCacheDirFlag([]interface{}{"key", "list", "--no-lock"})
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendCaCertFlag(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
+func (w *ResticWrapper) addKey(newPasswordFile string) ([]byte, error) {
+	log.Infoln("Adding new key to restic repository")
+	args := w.appendCacheDirFlag([]interface{}{"key", "add", "--new-password-file", newPasswordFile})
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendCaCertFlag(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
+func (w *ResticWrapper) removeKey(keyID string) ([]byte, error) {
+	log.Infoln("Removing key from restic repository")
+	args := w.appendCacheDirFlag([]interface{}{"key", "remove", keyID})
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendCaCertFlag(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
 func (w *ResticWrapper) appendCacheDirFlag(args []interface{}) []interface{} {
 	if w.config.EnableCache {
 		cacheDir := filepath.Join(w.config.ScratchDir, resticCacheDir)
@@ -307,33 +580,90 @@ func (w *ResticWrapper) appendCaCertFlag(args []interface{}) []interface{} {
 }
 
 func (w *ResticWrapper) run(commands ...Command) ([]byte, error) {
+	stdout := bytes.NewBuffer(nil)
+	if err := w.runWithStdout(stdout, commands...); err != nil {
+		return nil, err
+	}
+	out := stdout.Bytes()
+	log.Infoln("sh-output:", string(out))
+	return out, nil
+}
+
+// runBackup runs the backup commands. If progress function is specified,
+// the status lines of restic output are passed to it while the backup is running.
+func (w *ResticWrapper) runBackup(host, directory string, onProgress BackupProgressFunc, commands ...Command) ([]byte, error) {
+	if onProgress == nil {
+		return w.run(commands...)
+	}
+	stdout := &backupProgressWriter{
+		host:       host,
+		directory:  directory,
+		onProgress: onProgress,
+	}
+	if err := w.runWithStdout(stdout, commands...); err != nil {
+		return nil, err
+	}
+	out := stdout.Bytes()
+	log.Infoln("sh-output:", string(out))
+	return out, nil
+}
+
+func (w *ResticWrapper) runWithStdout(stdout io.Writer, commands ...Command) error {
 	// write std errors into os.Stderr and buffer
 	errBuff, err := circbuf.NewBuffer(256)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	w.sh.Stderr = io.MultiWriter(os.Stderr, errBuff)
 
+	// hold the lock until the commands have been started so that Cancel() can't miss them
+	w.mu.Lock()
+	if w.cancelled {
+		w.mu.Unlock()
+		return ErrCancelled
+	}
 	for _, cmd := range commands {
 		if cmd.Name == ResticCMD {
 			// first apply NiceSettings, then apply IONiceSettings
 			cmd, err = w.applyNiceSettings(cmd)
 			if err != nil {
-				return nil, err
+				w.mu.Unlock()
+				return err
 			}
 			cmd, err = w.applyIONiceSettings(cmd)
 			if err != nil {
-				return nil, err
+				w.mu.Unlock()
+				return err
 			}
 		}
 		w.sh.Command(cmd.Name, cmd.Args...)
 	}
-	out, err := w.sh.Output()
+
+	oldStdout := w.sh.Stdout
+	defer func() {
+		w.sh.Stdout = oldStdout
+	}()
+	w.sh.Stdout = stdout
+	if err = w.sh.Start(); err != nil {
+		w.mu.Unlock()
+		return formatError(err, errBuff.String())
+	}
+	w.running = true
+	w.mu.Unlock()
+
+	err = w.sh.Wait()
+
+	w.mu.Lock()
+	w.running = false
+	cancelled := w.cancelled
+	w.mu.Unlock()
+	if cancelled {
+		return ErrCancelled
+	}
 	if err != nil {
-		return nil, formatError(err, errBuff.String())
+		return formatError(err, errBuff.String())
 	}
-	log.Infoln("sh-output:", string(out))
-	return out, nil
+	return nil
 }
 
 // return last line of std error as error reason
@@ -0,0 +1,42 @@
+package restic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
+)
+
+func TestRetentionPolicyArgs(t *testing.T) {
+	testCases := []struct {
+		name   string
+		policy api_v1alpha1.RetentionPolicy
+		args   []interface{}
+	}{
+		{name: "no rule"},
+		{
+			name:   "keep rules",
+			policy: api_v1alpha1.RetentionPolicy{KeepLast: 2, KeepWithin: "1d", Prune: true},
+			args:   []interface{}{"--keep-last", "2", "--keep-within", "1d", "--keep-tag", "legal-hold", "--prune"},
+		},
+		{
+			name: "filter",
+			policy: api_v1alpha1.RetentionPolicy{
+				KeepDaily: 7,
+				Filter: &api_v1alpha1.RetentionFilter{
+					GroupBy: []api_v1alpha1.RetentionGroupBy{api_v1alpha1.GroupByHost, api_v1alpha1.GroupByPaths},
+					Host:    "host-0",
+					Tags:    []string{"backup-configuration=app", "env=prod"},
+					Paths:   []string{"/data"},
+				},
+			},
+			args: []interface{}{"--keep-daily", "7", "--keep-tag", "legal-hold", "--group-by", "host,paths",
+				"--host", "host-0", "--tag", "backup-configuration=app,env=prod", "--path", "/data"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.args, RetentionPolicyArgs(tc.policy))
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package restic
 
 import (
+	"sync"
+	"time"
+
 	shell "github.com/codeskyblue/go-sh"
 	ofst "kmodules.xyz/offshoot-api/api/v1"
 	"stash.appscode.dev/stash/apis/stash/v1alpha1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
 )
 
 const (
@@ -15,6 +19,11 @@ const (
 type ResticWrapper struct {
 	sh     *shell.Session
 	config SetupOptions
+
+	// mu guards the cancellation state of the running command
+	mu        sync.Mutex
+	running   bool
+	cancelled bool
 }
 
 type Command struct {
@@ -29,14 +38,55 @@ type BackupOptions struct {
 	StdinPipeCommand Command
 	StdinFileName    string // default "stdin"
 	RetentionPolicy  v1alpha1.RetentionPolicy
+	Tags             []string // tags to add to the snapshots
+	// SkipRepositoryMaintenance skips check, cleanup and stats after backup.
+	// It is used when repository maintenance is run once after all hosts have been backed up.
+	SkipRepositoryMaintenance bool
+	// SkipCheck skips repository integrity check after backup.
+	// It is used when integrity check has been scheduled separately.
+	SkipCheck bool
+	// OnProgress is called with the latest progress while backup is running
+	OnProgress BackupProgressFunc
+	// Filter specifies which files of the BackupDirs should be backed up
+	Filter BackupFilter
+}
+
+// BackupFilter specifies which files of the backup directories should be backed up
+type BackupFilter struct {
+	Exclude          []string // patterns to exclude
+	Include          []string // patterns relative to each backup directory. if specified, only the matching paths are backed up.
+	ExcludeIfPresent []string // exclude a directory if it contains a file with any of these names
+	IgnoreFileName   string   // name of a file in the root of each backup directory that lists the patterns to exclude
+	ExcludeCaches    bool     // exclude the directories containing CACHEDIR.TAG file
+	OneFileSystem    bool     // don't cross the file system boundaries
+}
+
+type CheckOptions struct {
+	ReadData       bool   // read all pack data
+	ReadDataSubset string // read a subset of pack data, format "n/t"
 }
 
 type RestoreOptions struct {
 	Host        string
 	SourceHost  string
 	RestoreDirs []string
-	Snapshots   []string // when Snapshots are specified SourceHost and RestoreDirs will not be used
-	Destination string   // destination path where snapshot will be restored, used in cli
+	Snapshots   []string // when Snapshots are specified SourceHost, RestoreDirs and SnapshotFilter will not be used
+	// SnapshotFilter selects the snapshots to restore for each of the RestoreDirs. If no RestoreDirs
+	// has been specified, the latest matching snapshot of each backed up path is restored.
+	SnapshotFilter *SnapshotFilter
+	Destination    string   // destination path where snapshot will be restored
+	Include        []string // patterns of the files to restore
+	Exclude        []string // patterns of the files not to restore
+	// ConflictPolicy specifies how to handle the files that already exist in the destination. Default is overwrite.
+	ConflictPolicy api_v1beta1.RestoreConflictPolicy
+	// OnProgress is called periodically with the amount of data restored so far
+	OnProgress RestoreProgressFunc
+}
+
+// SnapshotFilter selects the snapshots by tags and time
+type SnapshotFilter struct {
+	Tags   []string   // snapshots must have all of these tags
+	Before *time.Time // select the latest snapshot taken at or before this time
 }
 
 type DumpOptions struct {
@@ -45,6 +95,8 @@ type DumpOptions struct {
 	Path              string
 	FileName          string // default "stdin"
 	StdoutPipeCommand Command
+	// OnProgress is called periodically with the amount of data passed to the StdoutPipeCommand so far
+	OnProgress RestoreProgressFunc
 }
 
 type SetupOptions struct {
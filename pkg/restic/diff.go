@@ -0,0 +1,132 @@
+package restic
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// modifiers used by "restic diff" to indicate the change of an entry
+const (
+	DiffAdded           = "+"
+	DiffRemoved         = "-"
+	DiffModified        = "M"
+	DiffTypeChanged     = "T"
+	DiffMetadataChanged = "U"
+)
+
+type DiffEntry struct {
+	Modifier string
+	Path     string
+}
+
+type DiffStats struct {
+	FilesAdded       int64
+	FilesRemoved     int64
+	FilesChanged     int64
+	DirsAdded        int64
+	DirsRemoved      int64
+	OthersAdded      int64
+	OthersRemoved    int64
+	DataBlobsAdded   int64
+	DataBlobsRemoved int64
+	TreeBlobsAdded   int64
+	TreeBlobsRemoved int64
+	BytesAdded       int64
+	BytesRemoved     int64
+}
+
+type SnapshotDiff struct {
+	Entries []DiffEntry
+	Stats   DiffStats
+}
+
+var (
+	diffEntryRegex   = regexp.MustCompile(`^([-+MTU?])\s+(/.*)$`)
+	diffCountsRegex  = regexp.MustCompile(`^(Files|Dirs|Others|Data Blobs|Tree Blobs):\s+(.*)$`)
+	diffCountRegex   = regexp.MustCompile(`(\d+)\s+(new|removed|changed)`)
+	diffBytesRegex   = regexp.MustCompile(`^(Added|Removed):\s+([\d.]+)\s+(B|KiB|MiB|GiB|TiB)$`)
+	byteUnitMultiple = map[string]float64{
+		"B":   1,
+		"KiB": 1 << 10,
+		"MiB": 1 << 20,
+		"GiB": 1 << 30,
+		"TiB": 1 << 40,
+	}
+)
+
+// Diff returns the changes between two snapshots of the repository
+func (w *ResticWrapper) Diff(fromSnapshotID, toSnapshotID string) (*SnapshotDiff, error) {
+	out, err := w.diff(fromSnapshotID, toSnapshotID)
+	if err != nil {
+		return nil, err
+	}
+	return parseDiff(out)
+}
+
+// parseDiff parses the output of "restic diff" command. Example output:
+//
+//	comparing snapshot 0b7efb2b to 5a7b2c3d:
+//
+//	+    /data/new.txt
+//	M    /data/changed.txt
+//
+//	Files:           1 new,     0 removed,     1 changed
+//	Dirs:            0 new,     0 removed
+//	Others:          0 new,     0 removed
+//	Data Blobs:      3 new,     2 removed
+//	Tree Blobs:      2 new,     2 removed
+//	  Added:   1.234 MiB
+//	  Removed: 456.000 KiB
+func parseDiff(out []byte) (*SnapshotDiff, error) {
+	diff := &SnapshotDiff{}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t")
+		if m := diffEntryRegex.FindStringSubmatch(line); m != nil {
+			diff.Entries = append(diff.Entries, DiffEntry{Modifier: m[1], Path: m[2]})
+			continue
+		}
+		line = strings.TrimSpace(line)
+		if m := diffCountsRegex.FindStringSubmatch(line); m != nil {
+			counts := map[string]int64{}
+			for _, c := range diffCountRegex.FindAllStringSubmatch(m[2], -1) {
+				n, err := strconv.ParseInt(c[1], 10, 64)
+				if err != nil {
+					return nil, err
+				}
+				counts[c[2]] = n
+			}
+			switch m[1] {
+			case "Files":
+				diff.Stats.FilesAdded, diff.Stats.FilesRemoved, diff.Stats.FilesChanged = counts["new"], counts["removed"], counts["changed"]
+			case "Dirs":
+				diff.Stats.DirsAdded, diff.Stats.DirsRemoved = counts["new"], counts["removed"]
+			case "Others":
+				diff.Stats.OthersAdded, diff.Stats.OthersRemoved = counts["new"], counts["removed"]
+			case "Data Blobs":
+				diff.Stats.DataBlobsAdded, diff.Stats.DataBlobsRemoved = counts["new"], counts["removed"]
+			case "Tree Blobs":
+				diff.Stats.TreeBlobsAdded, diff.Stats.TreeBlobsRemoved = counts["new"], counts["removed"]
+			}
+			continue
+		}
+		if m := diffBytesRegex.FindStringSubmatch(line); m != nil {
+			v, err := strconv.ParseFloat(m[2], 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse size %q. Reason: %v", m[2], err)
+			}
+			size := int64(v * byteUnitMultiple[m[3]])
+			if m[1] == "Added" {
+				diff.Stats.BytesAdded = size
+			} else {
+				diff.Stats.BytesRemoved = size
+			}
+		}
+	}
+	return diff, scanner.Err()
+}
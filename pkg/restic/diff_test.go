@@ -0,0 +1,78 @@
+package restic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDiff(t *testing.T) {
+	testCases := []struct {
+		name string
+		out  string
+		diff *SnapshotDiff
+	}{
+		{
+			name: "no change",
+			out: `comparing snapshot 0b7efb2b to 5a7b2c3d:
+
+Files:           0 new,     0 removed,     0 changed
+Dirs:            0 new,     0 removed
+Others:          0 new,     0 removed
+Data Blobs:      0 new,     0 removed
+Tree Blobs:      0 new,     0 removed
+  Added:   0 B
+  Removed: 0 B
+`,
+			diff: &SnapshotDiff{},
+		},
+		{
+			name: "changes",
+			out: `comparing snapshot 0b7efb2b to 5a7b2c3d:
+
++    /data/new.txt
+-    /data/old dir/
+M    /data/changed.txt
+T    /data/link
+U    /data/owner.txt
+
+Files:           1 new,     0 removed,     1 changed
+Dirs:            0 new,     1 removed
+Others:          2 new,     3 removed
+Data Blobs:      3 new,     2 removed
+Tree Blobs:      2 new,     2 removed
+  Added:   1.500 MiB
+  Removed: 456.000 KiB
+`,
+			diff: &SnapshotDiff{
+				Entries: []DiffEntry{
+					{Modifier: DiffAdded, Path: "/data/new.txt"},
+					{Modifier: DiffRemoved, Path: "/data/old dir/"},
+					{Modifier: DiffModified, Path: "/data/changed.txt"},
+					{Modifier: DiffTypeChanged, Path: "/data/link"},
+					{Modifier: DiffMetadataChanged, Path: "/data/owner.txt"},
+				},
+				Stats: DiffStats{
+					FilesAdded:       1,
+					FilesChanged:     1,
+					DirsRemoved:      1,
+					OthersAdded:      2,
+					OthersRemoved:    3,
+					DataBlobsAdded:   3,
+					DataBlobsRemoved: 2,
+					TreeBlobsAdded:   2,
+					TreeBlobsRemoved: 2,
+					BytesAdded:       1.5 * (1 << 20),
+					BytesRemoved:     456 * (1 << 10),
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			diff, err := parseDiff([]byte(tc.out))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.diff, diff)
+		})
+	}
+}
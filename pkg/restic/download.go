@@ -0,0 +1,17 @@
+package restic
+
+import (
+	"io"
+)
+
+// StreamFile writes the content of a file of the snapshot into out
+func (w *ResticWrapper) StreamFile(snapshotID, filePath string, out io.Writer) error {
+	return w.dumpFile(snapshotID, filePath, out)
+}
+
+// RestoreDir restores only a directory of the snapshot inside the target directory.
+// The directory is restored in its full path, i.e. "/data/dir" is restored in "<target>/data/dir".
+func (w *ResticWrapper) RestoreDir(snapshotID, dir, target string) error {
+	_, err := w.restore(snapshotID, RestoreOptions{Include: []string{dir}, Destination: target}, nil)
+	return err
+}
@@ -0,0 +1,54 @@
+package restic
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// Node is a file, directory or symlink of a snapshot as printed by "restic ls --json"
+type Node struct {
+	Name       string      `json:"name"`
+	Type       string      `json:"type"`
+	Path       string      `json:"path"`
+	UID        uint32      `json:"uid"`
+	Gid        uint32      `json:"gid"`
+	Size       uint64      `json:"size,omitempty"`
+	Mode       os.FileMode `json:"mode,omitempty"`
+	ModTime    time.Time   `json:"mtime,omitempty"`
+	StructType string      `json:"struct_type"`
+}
+
+// ListFiles returns all the files and directories of a snapshot in the order they appear in the snapshot tree
+func (w *ResticWrapper) ListFiles(snapshotID string) ([]Node, error) {
+	out, err := w.listFiles(snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	return parseNodes(out)
+}
+
+// parseNodes parses the output of "restic ls --json". Each line is a json object. The first one
+// describes the snapshot and the rest describe the nodes of the snapshot.
+func parseNodes(out []byte) ([]Node, error) {
+	nodes := make([]Node, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var node Node
+		if err := json.Unmarshal(line, &node); err != nil {
+			return nil, err
+		}
+		if node.StructType != "node" {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, scanner.Err()
+}
@@ -0,0 +1,47 @@
+package restic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNodes(t *testing.T) {
+	testCases := []struct {
+		name    string
+		out     string
+		paths   []string
+		invalid bool
+	}{
+		{name: "empty output", out: "", paths: []string{}},
+		{
+			name: "snapshot line is skipped",
+			out: `{"time":"2019-10-01T10:00:00Z","tree":"abc","paths":["/data"],"hostname":"host-0","id":"1234abcd","struct_type":"snapshot"}
+{"name":"data","type":"dir","path":"/data","uid":0,"gid":0,"mode":2147484141,"struct_type":"node"}
+{"name":"a.txt","type":"file","path":"/data/a.txt","uid":0,"gid":0,"size":7,"mode":420,"struct_type":"node"}
+`,
+			paths: []string{"/data", "/data/a.txt"},
+		},
+		{
+			name:  "blank lines",
+			out:   "\n" + `{"name":"data","type":"dir","path":"/data","struct_type":"node"}` + "\n\n",
+			paths: []string{"/data"},
+		},
+		{name: "invalid json", out: "{not json}", invalid: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nodes, err := parseNodes([]byte(tc.out))
+			if tc.invalid {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			paths := make([]string, 0)
+			for _, n := range nodes {
+				paths = append(paths, n.Path)
+			}
+			assert.Equal(t, tc.paths, paths)
+		})
+	}
+}
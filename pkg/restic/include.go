@@ -0,0 +1,133 @@
+package restic
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// writeIncludeExcludeFile writes the exclude patterns that leave only the files matching the include patterns
+// in the backup of the directory. It returns the path of the file that should be passed to "--exclude-file".
+func (w *ResticWrapper) writeIncludeExcludeFile(dir string, include []string) (string, error) {
+	excludes, err := excludesForInclude(dir, include)
+	if err != nil {
+		return "", err
+	}
+	f, err := ioutil.TempFile(w.config.ScratchDir, "include-excludes-")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err = f.WriteString(strings.Join(excludes, "\n") + "\n"); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// excludesForInclude returns the exclude patterns for the files and directories of dir that neither match
+// any of the include patterns nor contain a matching file. The include patterns are relative to dir and follow
+// restic's pattern syntax: "*", "?" and "[...]" match inside a path component and "**" matches any number of components.
+func excludesForInclude(dir string, include []string) ([]string, error) {
+	var patterns [][]string
+	for _, p := range include {
+		var components []string
+		for _, c := range strings.Split(filepath.ToSlash(p), "/") {
+			if c == "" || c == "." {
+				continue
+			}
+			if _, err := filepath.Match(c, ""); err != nil {
+				return nil, fmt.Errorf("invalid include pattern %q. Reason: %v", p, err)
+			}
+			components = append(components, c)
+		}
+		if len(components) == 0 {
+			return nil, fmt.Errorf("invalid include pattern %q", p)
+		}
+		patterns = append(patterns, components)
+	}
+
+	var excludes []string
+	matched := false
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		components := strings.Split(filepath.ToSlash(rel), "/")
+		match, childMayMatch := false, false
+		for _, pattern := range patterns {
+			m, c := matchIncludePattern(pattern, components)
+			match, childMayMatch = match || m, childMayMatch || c
+		}
+		switch {
+		case match:
+			// the whole subtree is included
+			matched = true
+		case childMayMatch && info.IsDir():
+			// look for the matching files inside the directory
+			return nil
+		default:
+			excludes = append(excludes, excludeFilePattern(path))
+		}
+		if info.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !matched {
+		return nil, fmt.Errorf("no file or directory in %s matches the include patterns %v", dir, include)
+	}
+	return excludes, nil
+}
+
+// matchIncludePattern reports whether the path components match the pattern components
+// and whether a descendant of the path may match the pattern.
+func matchIncludePattern(pattern, path []string) (match, childMayMatch bool) {
+	if len(pattern) > 0 && pattern[0] == "**" {
+		// "**" matches zero or more components
+		m, c := matchIncludePattern(pattern[1:], path)
+		if m || len(path) == 0 {
+			return m, true
+		}
+		m2, c2 := matchIncludePattern(pattern, path[1:])
+		return m2, c || c2
+	}
+	if len(path) == 0 {
+		return len(pattern) == 0, len(pattern) > 0
+	}
+	if len(pattern) == 0 {
+		return false, false
+	}
+	if ok, _ := filepath.Match(pattern[0], path[0]); !ok {
+		return false, false
+	}
+	return matchIncludePattern(pattern[1:], path[1:])
+}
+
+// excludeFilePattern returns the pattern that matches exactly the path in an exclude file.
+// restic trims the lines and expands the environment variables of an exclude file. So, "$" and
+// the trailing spaces are replaced by "?" that matches any single character.
+func excludeFilePattern(path string) string {
+	pattern := []rune(escapePattern(path))
+	for i := range pattern {
+		if pattern[i] == '$' {
+			pattern[i] = '?'
+		}
+	}
+	for i := len(pattern) - 1; i >= 0 && (pattern[i] == ' ' || pattern[i] == '\t'); i-- {
+		pattern[i] = '?'
+	}
+	return string(pattern)
+}
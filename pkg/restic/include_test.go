@@ -0,0 +1,100 @@
+package restic
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchIncludePattern(t *testing.T) {
+	testCases := []struct {
+		name          string
+		pattern       string
+		path          string
+		match         bool
+		childMayMatch bool
+	}{
+		{name: "exact file", pattern: "a/b.txt", path: "a/b.txt", match: true},
+		{name: "parent of match", pattern: "a/b.txt", path: "a", childMayMatch: true},
+		{name: "unrelated", pattern: "a/b.txt", path: "c", match: false},
+		{name: "wildcard", pattern: "*.txt", path: "b.txt", match: true},
+		{name: "wildcard doesn't cross directories", pattern: "*.txt", path: "a/b.txt", match: false},
+		{name: "double star matches any depth", pattern: "**/*.txt", path: "a/b/c.txt", match: true, childMayMatch: true},
+		{name: "double star matches zero components", pattern: "**/*.txt", path: "c.txt", match: true, childMayMatch: true},
+		{name: "double star directory", pattern: "**/*.txt", path: "a", childMayMatch: true},
+		{name: "double star in the middle", pattern: "a/**/c", path: "a/b/c", match: true},
+		{name: "double star in the middle mismatch", pattern: "a/**/c", path: "x/b/c", match: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			match, childMayMatch := matchIncludePattern(strings.Split(tc.pattern, "/"), strings.Split(tc.path, "/"))
+			assert.Equal(t, tc.match, match)
+			if !tc.match {
+				assert.Equal(t, tc.childMayMatch, childMayMatch)
+			}
+		})
+	}
+}
+
+func TestExcludesForInclude(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stash-include")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	for _, f := range []string{"data/a.db", "data/a.log", "data/sub/b.db", "logs/x.log", "top.db"} {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, f)), 0755))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, f), []byte("x"), 0644))
+	}
+
+	testCases := []struct {
+		name     string
+		include  []string
+		excludes []string
+		err      bool
+	}{
+		{name: "directory", include: []string{"data"}, excludes: []string{"logs", "top.db"}},
+		{name: "files in a directory", include: []string{"data/*.db"}, excludes: []string{"data/a.log", "data/sub", "logs", "top.db"}},
+		{name: "files at any depth", include: []string{"**/*.db"}, excludes: []string{"data/a.log", "logs/x.log"}},
+		{name: "multiple patterns", include: []string{"top.db", "logs"}, excludes: []string{"data"}},
+		{name: "no match", include: []string{"missing"}, err: true},
+		{name: "invalid pattern", include: []string{"[a"}, err: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			excludes, err := excludesForInclude(dir, tc.include)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			var expected []string
+			for _, e := range tc.excludes {
+				expected = append(expected, escapePattern(filepath.Join(dir, e)))
+			}
+			assert.Equal(t, expected, excludes)
+		})
+	}
+}
+
+func TestExcludeFilePattern(t *testing.T) {
+	testCases := []struct {
+		name    string
+		path    string
+		pattern string
+	}{
+		{name: "plain", path: "/data/file", pattern: "/data/file"},
+		{name: "special characters", path: "/data/a*b?[c]", pattern: `/data/a\*b\?\[c]`},
+		{name: "environment variable", path: "/data/$HOME", pattern: "/data/?HOME"},
+		{name: "trailing space", path: "/data/file  ", pattern: "/data/file??"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.pattern, excludeFilePattern(tc.path))
+		})
+	}
+}
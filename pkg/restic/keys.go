@@ -0,0 +1,81 @@
+package restic
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Key is a key of the restic repository. A repository can have multiple keys, each encrypted with a different password.
+type Key struct {
+	ID      string
+	Current bool // true if it is the key of the password used to open the repository
+}
+
+// ListKeys returns the keys of the repository
+func (w *ResticWrapper) ListKeys() ([]Key, error) {
+	out, err := w.listKeys()
+	if err != nil {
+		return nil, err
+	}
+	return parseKeyList(out), nil
+}
+
+// AddKey adds a key for the password stored in the file. It returns the ID of the new key.
+func (w *ResticWrapper) AddKey(newPasswordFile string) (string, error) {
+	before, err := w.ListKeys()
+	if err != nil {
+		return "", err
+	}
+	if _, err = w.addKey(newPasswordFile); err != nil {
+		return "", err
+	}
+	after, err := w.ListKeys()
+	if err != nil {
+		return "", err
+	}
+	// restic does not print the ID of the new key. so, find the key that did not exist before.
+	existing := make(map[string]bool)
+	for _, key := range before {
+		existing[key.ID] = true
+	}
+	for _, key := range after {
+		if !existing[key.ID] {
+			return key.ID, nil
+		}
+	}
+	return "", fmt.Errorf("failed to find the newly added key")
+}
+
+// RemoveKey removes a key from the repository. The key that has been used to open the repository can't be removed.
+func (w *ResticWrapper) RemoveKey(keyID string) error {
+	_, err := w.removeKey(keyID)
+	return err
+}
+
+// parseKeyList parses the output of "restic key list" command. The key used to open the repository is
+// marked with "*" in front of its ID.
+func parseKeyList(out []byte) []Key {
+	var keys []Key
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] == "ID" || strings.HasPrefix(fields[0], "-") {
+			continue
+		}
+		key := Key{ID: fields[0]}
+		if strings.HasPrefix(key.ID, "*") {
+			key.Current = true
+			key.ID = strings.TrimPrefix(key.ID, "*")
+			if key.ID == "" && len(fields) > 1 {
+				key.ID = fields[1]
+			}
+		}
+		if key.ID != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,39 @@
+package restic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKeyList(t *testing.T) {
+	testCases := []struct {
+		name string
+		out  string
+		keys []Key
+	}{
+		{name: "empty output", out: ""},
+		{
+			name: "current key marked",
+			out: ` ID        User  Host    Created
+----------------------------------------------
+*4b3f0c2a  root  host-0  2019-08-01 10:00:00
+ 9d1e7a55  root  host-0  2019-09-01 10:00:00
+----------------------------------------------
+`,
+			keys: []Key{{ID: "4b3f0c2a", Current: true}, {ID: "9d1e7a55"}},
+		},
+		{
+			name: "separated current marker",
+			out: ` ID        User  Host    Created
+* 4b3f0c2a  root  host-0  2019-08-01 10:00:00
+`,
+			keys: []Key{{ID: "4b3f0c2a", Current: true}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.keys, parseKeyList([]byte(tc.out)))
+		})
+	}
+}
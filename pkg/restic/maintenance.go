@@ -0,0 +1,53 @@
+package restic
+
+import (
+	"github.com/appscode/go/types"
+	"stash.appscode.dev/stash/apis/stash/v1alpha1"
+)
+
+// RunCheck checks integrity of the repository. If specified in the options, it also verifies the pack data.
+func (w *ResticWrapper) RunCheck(checkOpt CheckOptions) (*RepositoryStats, error) {
+	backupOutput := &BackupOutput{}
+	out, err := w.check(checkOpt)
+	if err != nil {
+		// restic exits with non-zero code if the repository has errors
+		backupOutput.RepositoryStats.Integrity = types.BoolP(false)
+		return &backupOutput.RepositoryStats, err
+	}
+	// Extract information from output of "check" command
+	backupOutput.extractCheckInfo(out)
+	return &backupOutput.RepositoryStats, nil
+}
+
+// RunPrune removes the snapshots according to the retention policy and the data that are no longer referenced.
+// If retention policy is nil, only the unreferenced data are removed.
+func (w *ResticWrapper) RunPrune(retentionPolicy *v1alpha1.RetentionPolicy) (*RepositoryStats, error) {
+	backupOutput := &BackupOutput{}
+	if retentionPolicy != nil {
+		policy := *retentionPolicy
+		policy.Prune = true
+		out, err := w.cleanup(policy)
+		if err != nil {
+			return nil, err
+		}
+		// Extract information from output of cleanup command
+		if err = backupOutput.extractCleanupInfo(out); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := w.prune(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Read repository statics after prune
+	out, err := w.stats()
+	if err != nil {
+		return nil, err
+	}
+	// Extract information from output of "stats" command
+	if err = backupOutput.extractStatsInfo(out); err != nil {
+		return nil, err
+	}
+	return &backupOutput.RepositoryStats, nil
+}
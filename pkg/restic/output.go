@@ -164,7 +164,8 @@ func (backupOutput *BackupOutput) extractCheckInfo(out []byte) {
 }
 
 // ExtractCleanupInfo extract information from output of "restic forget" command and
-// save valuable information into backupOutput
+// save valuable information into backupOutput. The counts are added to the counts of the
+// previous "restic forget" commands of the same maintenance.
 func (backupOutput *BackupOutput) extractCleanupInfo(out []byte) error {
 	var fg []ForgetGroup
 	err := json.Unmarshal(out, &fg)
@@ -172,8 +173,6 @@ func (backupOutput *BackupOutput) extractCleanupInfo(out []byte) error {
 		return err
 	}
 
-	backupOutput.RepositoryStats.SnapshotsRemovedOnLastCleanup = 0
-	backupOutput.RepositoryStats.SnapshotCount = 0
 	for i := 0; i < len(fg); i++ {
 		backupOutput.RepositoryStats.SnapshotsRemovedOnLastCleanup += len(fg[i].Remove)
 		backupOutput.RepositoryStats.SnapshotCount += len(fg[i].Keep)
@@ -212,5 +211,6 @@ type ForgetGroup struct {
 }
 
 type StatsContainer struct {
-	TotalSize uint64 `json:"total_size"`
+	TotalSize      uint64 `json:"total_size"`
+	TotalFileCount int64  `json:"total_file_count"`
 }
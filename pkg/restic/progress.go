@@ -0,0 +1,187 @@
+package restic
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/appscode/go/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+)
+
+// RestoreProgressScanInterval is the interval at which the restore directories are scanned to measure restore progress
+const RestoreProgressScanInterval = 15 * time.Second
+
+// BackupProgressFunc is called with the latest progress while restic is backing up a directory
+type BackupProgressFunc func(progress api_v1beta1.HostBackupProgress)
+
+// RestoreProgressFunc is called with the latest progress while restic is restoring
+type RestoreProgressFunc func(progress api_v1beta1.HostRestoreProgress)
+
+// BackupStatus is the status line printed by "restic backup --json" while backup is running
+type BackupStatus struct {
+	MessageType      string  `json:"message_type"` // "status"
+	SecondsElapsed   uint64  `json:"seconds_elapsed"`
+	SecondsRemaining uint64  `json:"seconds_remaining"`
+	PercentDone      float64 `json:"percent_done"`
+	TotalFiles       uint64  `json:"total_files"`
+	FilesDone        uint64  `json:"files_done"`
+	TotalBytes       uint64  `json:"total_bytes"`
+	BytesDone        uint64  `json:"bytes_done"`
+}
+
+// backupProgressWriter collects the output of "restic backup --json" command. The status lines are passed
+// to the progress function as they arrive and rest of the lines are kept in the buffer for further processing.
+type backupProgressWriter struct {
+	host       string
+	directory  string
+	onProgress BackupProgressFunc
+
+	out     bytes.Buffer
+	partial []byte
+}
+
+func (w *backupProgressWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		w.processLine(w.partial[:i+1])
+		w.partial = w.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+func (w *backupProgressWriter) processLine(line []byte) {
+	var status BackupStatus
+	if err := json.Unmarshal(line, &status); err == nil && status.MessageType == "status" {
+		w.onProgress(api_v1beta1.HostBackupProgress{
+			Hostname:       w.host,
+			Directory:      w.directory,
+			PercentDone:    int32(status.PercentDone * 100),
+			TotalBytes:     int64(status.TotalBytes),
+			BytesDone:      int64(status.BytesDone),
+			TotalFiles:     int64(status.TotalFiles),
+			FilesDone:      int64(status.FilesDone),
+			ETA:            formatSeconds(status.SecondsRemaining),
+			LastUpdateTime: metav1.Now(),
+		})
+		return
+	}
+	w.out.Write(line)
+}
+
+// Bytes returns the output except the status lines
+func (w *backupProgressWriter) Bytes() []byte {
+	w.out.Write(w.partial)
+	w.partial = nil
+	return w.out.Bytes()
+}
+
+// watchRestoreProgress measures the amount of data that has been restored so far periodically and
+// reports it. It stops when the returned function is called.
+func watchRestoreProgress(host string, measure func(progress *api_v1beta1.HostRestoreProgress), onProgress RestoreProgressFunc) func() {
+	stopCh := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(RestoreProgressScanInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				progress := api_v1beta1.HostRestoreProgress{
+					Hostname: host,
+				}
+				measure(&progress)
+				progress.LastUpdateTime = metav1.Now()
+				onProgress(progress)
+			}
+		}
+	}()
+	return func() {
+		close(stopCh)
+		wg.Wait()
+	}
+}
+
+// restoreDirScanner measures the files restored into the restore directories. The files that existed
+// before restore are counted only once they have been replaced or modified.
+type restoreDirScanner struct {
+	dirs     []string
+	existing map[string]os.FileInfo
+	restored map[string]bool
+}
+
+func newRestoreDirScanner(dirs []string) *restoreDirScanner {
+	s := &restoreDirScanner{
+		dirs:     dirs,
+		existing: make(map[string]os.FileInfo),
+		restored: make(map[string]bool),
+	}
+	s.walk(func(path string, info os.FileInfo) {
+		s.existing[path] = info
+	})
+	return s
+}
+
+func (s *restoreDirScanner) measure(progress *api_v1beta1.HostRestoreProgress) {
+	s.walk(func(path string, info os.FileInfo) {
+		if old, ok := s.existing[path]; ok && !s.restored[path] && unchanged(old, info) {
+			return
+		}
+		s.restored[path] = true
+		progress.FilesRestored++
+		progress.BytesRestored += info.Size()
+	})
+}
+
+// walk calls fn for each regular file of the directories
+func (s *restoreDirScanner) walk(fn func(path string, info os.FileInfo)) {
+	for _, dir := range s.dirs {
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				// directory may not have been created yet
+				return nil
+			}
+			if info.Mode().IsRegular() {
+				fn(path, info)
+			}
+			return nil
+		})
+		if err != nil {
+			log.Warningf("failed to measure restore progress of %s. Reason: %v", dir, err)
+		}
+	}
+}
+
+func unchanged(old, cur os.FileInfo) bool {
+	return os.SameFile(old, cur) && old.Size() == cur.Size() && old.ModTime().Equal(cur.ModTime())
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w     io.Writer
+	count int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	atomic.AddInt64(&c.count, int64(n))
+	return n, err
+}
+
+func (c *countingWriter) Count() int64 {
+	return atomic.LoadInt64(&c.count)
+}
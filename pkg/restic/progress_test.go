@@ -0,0 +1,60 @@
+package restic
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+)
+
+func TestRestoreDirScanner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stash-progress")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(name, content string) {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	write("existing", "old")
+	write("modified", "old")
+	write("replaced", "old")
+
+	scanner := newRestoreDirScanner([]string{dir, filepath.Join(dir, "not-created-yet")})
+
+	write("new", "restored")
+	write("modified", "restored")
+	assert.NoError(t, os.Remove(filepath.Join(dir, "replaced")))
+	write("replaced", "replacement")
+
+	progress := api_v1beta1.HostRestoreProgress{}
+	scanner.measure(&progress)
+	assert.Equal(t, int64(3), progress.FilesRestored)
+	assert.Equal(t, int64(len("restored")*2+len("replacement")), progress.BytesRestored)
+}
+
+func TestCountingWriter(t *testing.T) {
+	testCases := []struct {
+		name   string
+		writes []string
+		count  int64
+	}{
+		{name: "no write", count: 0},
+		{name: "single write", writes: []string{"hello"}, count: 5},
+		{name: "multiple writes", writes: []string{"hello", " ", "stash"}, count: 11},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &countingWriter{w: ioutil.Discard}
+			for _, w := range tc.writes {
+				_, err := c.Write([]byte(w))
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.count, c.Count())
+		})
+	}
+}
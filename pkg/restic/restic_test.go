@@ -265,3 +265,55 @@ func TestBackupRestoreStdinWithScheduling(t *testing.T) {
 	}
 	fmt.Println("dump output:", dumpOut)
 }
+
+func TestCleanupSharedRepository(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "stash-unit-test-")
+	if err != nil {
+		t.Error(err)
+	}
+
+	w, err := setupTest(tempDir)
+	if err != nil {
+		t.Error(err)
+	}
+	defer cleanup(tempDir)
+
+	// two BackupConfigurations back up into the same repository from the same host
+	for _, config := range []string{"app-a", "app-a", "app-b", "app-b"} {
+		backupOpt := BackupOptions{
+			Host:                      "host-0",
+			BackupDirs:                []string{targetDir},
+			Tags:                      []string{"backup-configuration=" + config},
+			SkipRepositoryMaintenance: true,
+		}
+		if _, err = w.RunBackup(backupOpt); err != nil {
+			t.Error(err)
+		}
+	}
+
+	// retention policy of the first BackupConfiguration must not remove the snapshots of the second one
+	_, err = w.cleanup(api_v1alpha1.RetentionPolicy{
+		Name:     "keep-last-1",
+		KeepLast: 1,
+		Filter: &api_v1alpha1.RetentionFilter{
+			Host: "host-0",
+			Tags: []string{"backup-configuration=app-a"},
+		},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	snapshots, err := w.ListSnapshots(nil)
+	if err != nil {
+		t.Error(err)
+	}
+	count := make(map[string]int)
+	for _, s := range snapshots {
+		for _, tag := range s.Tags {
+			count[tag]++
+		}
+	}
+	assert.Equal(t, 1, count["backup-configuration=app-a"])
+	assert.Equal(t, 2, count["backup-configuration=app-b"])
+}
@@ -1,6 +1,7 @@
 package restic
 
 import (
+	"path/filepath"
 	"time"
 
 	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
@@ -16,18 +17,28 @@ func (w *ResticWrapper) RunRestore(restoreOptions RestoreOptions) (*RestoreOutpu
 		},
 	}
 
-	if len(restoreOptions.Snapshots) != 0 {
-		for _, snapshot := range restoreOptions.Snapshots {
-			// if snapshot is specified then host and path does not matter.
-			if _, err := w.restore("", "", snapshot, restoreOptions.Destination); err != nil {
-				return nil, err
-			}
+	// resolve the snapshots to restore at the beginning so that the same snapshots are restored
+	// even if new backups are taken while restore is running
+	snapshots, err := w.resolveSnapshots(restoreOptions)
+	if err != nil {
+		return nil, err
+	}
+	for _, snapshot := range snapshots {
+		restoreOutput.HostRestoreStats.Snapshots = append(restoreOutput.HostRestoreStats.Snapshots, snapshot.ID)
+	}
+
+	// report the amount of data restored so far if progress function is specified
+	if restoreOptions.OnProgress != nil {
+		if dirs := restoreProgressDirs(snapshots, restoreOptions); len(dirs) > 0 {
+			scanner := newRestoreDirScanner(dirs)
+			stop := watchRestoreProgress(restoreOptions.Host, scanner.measure, restoreOptions.OnProgress)
+			defer stop()
 		}
-	} else if len(restoreOptions.RestoreDirs) != 0 {
-		for _, path := range restoreOptions.RestoreDirs {
-			if _, err := w.restore(path, restoreOptions.SourceHost, "", restoreOptions.Destination); err != nil {
-				return nil, err
-			}
+	}
+
+	for _, snapshot := range snapshots {
+		if err := w.restoreSnapshot(snapshot, restoreOptions); err != nil {
+			return cancelledRestoreOutput(restoreOutput, startTime, err)
 		}
 	}
 
@@ -39,6 +50,28 @@ func (w *ResticWrapper) RunRestore(restoreOptions RestoreOptions) (*RestoreOutpu
 	return restoreOutput, nil
 }
 
+// cancelledRestoreOutput returns the output of a cancelled restore. For any other error, no output is returned.
+func cancelledRestoreOutput(restoreOutput *RestoreOutput, startTime time.Time, err error) (*RestoreOutput, error) {
+	if err != ErrCancelled {
+		return nil, err
+	}
+	restoreOutput.HostRestoreStats.Duration = time.Since(startTime).String()
+	restoreOutput.HostRestoreStats.Phase = api_v1beta1.HostRestoreCancelled
+	restoreOutput.HostRestoreStats.Error = "restore has been cancelled"
+	return restoreOutput, err
+}
+
+// restoreProgressDirs returns the directories where the snapshots will be restored
+func restoreProgressDirs(snapshots []Snapshot, restoreOptions RestoreOptions) []string {
+	var dirs []string
+	for _, snapshot := range snapshots {
+		for _, path := range snapshot.Paths {
+			dirs = append(dirs, filepath.Join(restoreDestination(restoreOptions), path))
+		}
+	}
+	return dirs
+}
+
 func (w *ResticWrapper) Dump(dumpOptions DumpOptions) (*RestoreOutput, error) {
 	// Start clock to measure total restore duration
 	startTime := time.Now()
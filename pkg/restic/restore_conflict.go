@@ -0,0 +1,140 @@
+package restic
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/appscode/go/log"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+)
+
+// maxIncludePatternBytes is the maximum total size of the include patterns passed to a single restic restore command.
+// It keeps the command line well below the ARG_MAX limit of the system.
+const maxIncludePatternBytes = 512 * 1024
+
+// restoreDestination returns the directory where the data will be restored.
+// If no destination has been specified, data is restored into the original path.
+func restoreDestination(restoreOptions RestoreOptions) string {
+	if restoreOptions.Destination == "" {
+		return "/"
+	}
+	return restoreOptions.Destination
+}
+
+// restoreSnapshot restores a snapshot handling the files that already exist in the destination according to
+// the conflict policy. For "CleanTargetFirst" policy, the existing contents are removed first. For "SkipExisting"
+// policy, only the files and directories that don't exist in the destination are restored.
+func (w *ResticWrapper) restoreSnapshot(snapshot Snapshot, restoreOptions RestoreOptions) error {
+	destination := restoreDestination(restoreOptions)
+	switch restoreOptions.ConflictPolicy {
+	case api_v1beta1.ConflictPolicyCleanTargetFirst:
+		for _, path := range snapshot.Paths {
+			if err := cleanDir(filepath.Join(destination, path)); err != nil {
+				return err
+			}
+		}
+	case api_v1beta1.ConflictPolicySkipExisting:
+		nodes, err := w.ListFiles(snapshot.ID)
+		if err != nil {
+			return err
+		}
+		includes, err := missingNodePatterns(nodes, destination)
+		if err != nil {
+			return err
+		}
+		if len(includes) == 0 {
+			log.Infof("All files of snapshot %s already exist in %s. Nothing to restore.", snapshot.ID, destination)
+			return nil
+		}
+		// the missing files are restored in batches so that the command line doesn't exceed the system limit
+		for _, batch := range splitPatterns(includes, maxIncludePatternBytes) {
+			if _, err := w.restore(snapshot.ID, restoreOptions, batch); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	_, err := w.restore(snapshot.ID, restoreOptions, nil)
+	return err
+}
+
+// missingNodePatterns returns the include patterns of the snapshot nodes that don't exist in the destination.
+// A missing directory is included as a whole. So, the patterns of its children are omitted. The nodes must be
+// in the order of "restic ls" where a directory appears before its children.
+func missingNodePatterns(nodes []Node, destination string) ([]string, error) {
+	var patterns []string
+	missingDirs := make(map[string]bool)
+	for _, node := range nodes {
+		if missingDirs[path.Dir(node.Path)] {
+			if node.Type == "dir" {
+				missingDirs[node.Path] = true
+			}
+			continue
+		}
+		_, err := os.Lstat(filepath.Join(destination, filepath.FromSlash(node.Path)))
+		if err == nil {
+			continue
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		if node.Type == "dir" {
+			missingDirs[node.Path] = true
+		}
+		patterns = append(patterns, escapePattern(node.Path))
+	}
+	return patterns, nil
+}
+
+// splitPatterns splits the patterns into batches where the total size of the patterns of a batch doesn't
+// exceed the limit. A pattern larger than the limit gets its own batch.
+func splitPatterns(patterns []string, limit int) [][]string {
+	var batches [][]string
+	var batch []string
+	size := 0
+	for _, p := range patterns {
+		if len(batch) > 0 && size+len(p) > limit {
+			batches = append(batches, batch)
+			batch, size = nil, 0
+		}
+		batch = append(batch, p)
+		size += len(p)
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// cleanDir removes the contents of a directory. The directory itself is kept as it might be a mount point.
+func cleanDir(dir string) error {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapePattern escapes the characters that have special meaning in restic's patterns
+func escapePattern(path string) string {
+	var sb strings.Builder
+	for _, c := range path {
+		switch c {
+		case '\\', '*', '?', '[':
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
@@ -0,0 +1,107 @@
+package restic
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapePattern(t *testing.T) {
+	testCases := []struct {
+		name    string
+		path    string
+		pattern string
+	}{
+		{name: "plain path", path: "/data/file.txt", pattern: "/data/file.txt"},
+		{name: "wildcards", path: "/data/*.txt?", pattern: `/data/\*.txt\?`},
+		{name: "character class", path: "/data/[a]", pattern: `/data/\[a]`},
+		{name: "backslash", path: `/data/a\b`, pattern: `/data/a\\b`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.pattern, escapePattern(tc.path))
+		})
+	}
+}
+
+func TestMissingNodePatterns(t *testing.T) {
+	destination, err := ioutil.TempDir("", "stash-restore")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(destination)
+	assert.NoError(t, os.MkdirAll(filepath.Join(destination, "data", "existing-dir"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(destination, "data", "existing-file"), []byte("x"), 0644))
+
+	nodes := []Node{
+		{Path: "/data", Type: "dir"},
+		{Path: "/data/existing-dir", Type: "dir"},
+		{Path: "/data/existing-dir/missing-file", Type: "file"},
+		{Path: "/data/existing-file", Type: "file"},
+		{Path: "/data/missing-dir", Type: "dir"},
+		{Path: "/data/missing-dir/sub", Type: "dir"},
+		{Path: "/data/missing-dir/sub/file", Type: "file"},
+		{Path: "/data/missing*file", Type: "file"},
+	}
+	testCases := []struct {
+		name     string
+		nodes    []Node
+		patterns []string
+	}{
+		{name: "nothing to restore", nodes: nodes[:2]},
+		{
+			name:     "missing files and directories",
+			nodes:    nodes,
+			patterns: []string{"/data/existing-dir/missing-file", "/data/missing-dir", `/data/missing\*file`},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			patterns, err := missingNodePatterns(tc.nodes, destination)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.patterns, patterns)
+		})
+	}
+}
+
+func TestSplitPatterns(t *testing.T) {
+	testCases := []struct {
+		name     string
+		patterns []string
+		limit    int
+		batches  [][]string
+	}{
+		{name: "no pattern", limit: 10},
+		{name: "single batch", patterns: []string{"/a", "/b"}, limit: 10, batches: [][]string{{"/a", "/b"}}},
+		{name: "multiple batches", patterns: []string{"/aaa", "/bbb", "/ccc"}, limit: 8, batches: [][]string{{"/aaa", "/bbb"}, {"/ccc"}}},
+		{name: "pattern larger than limit", patterns: []string{"/a", "/very-long"}, limit: 4, batches: [][]string{{"/a"}, {"/very-long"}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.batches, splitPatterns(tc.patterns, tc.limit))
+		})
+	}
+}
+
+func TestCleanDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stash-clean")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "file"), []byte("x"), 0644))
+
+	assert.NoError(t, cleanDir(dir))
+	entries, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+	// the directory itself is kept
+	_, err = os.Stat(dir)
+	assert.NoError(t, err)
+	// a missing directory is not an error
+	assert.NoError(t, cleanDir(filepath.Join(dir, "missing")))
+}
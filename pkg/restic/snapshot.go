@@ -1,9 +1,69 @@
 package restic
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 func (w *ResticWrapper) ListSnapshots(snapshotIDs []string) ([]Snapshot, error) {
 	return w.listSnapshots(snapshotIDs)
 }
 
+// DeleteSnapshots removes the snapshots and the data that are no longer referenced
 func (w *ResticWrapper) DeleteSnapshots(snapshotIDs []string) ([]byte, error) {
-	return w.deleteSnapshots(snapshotIDs)
+	return w.deleteSnapshots(snapshotIDs, true)
+}
+
+// ForgetSnapshots removes only the snapshots. Their data remain in the repository until it is pruned.
+func (w *ResticWrapper) ForgetSnapshots(snapshotIDs []string) ([]byte, error) {
+	return w.deleteSnapshots(snapshotIDs, false)
+}
+
+// SnapshotStats returns the total size and the number of files of a snapshot as they would be restored
+func (w *ResticWrapper) SnapshotStats(snapshotID string) (*StatsContainer, error) {
+	out, err := w.snapshotStats(snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	var stat StatsContainer
+	if err = json.Unmarshal(out, &stat); err != nil {
+		return nil, err
+	}
+	return &stat, nil
+}
+
+// UpdateTags adds and removes tags of a snapshot. restic replaces the snapshot with a new one having
+// the updated tags. So, the ID of the snapshot changes. It returns the new snapshot.
+func (w *ResticWrapper) UpdateTags(snapshotID string, add, remove []string) (*Snapshot, error) {
+	snapshots, err := w.listSnapshots([]string{snapshotID})
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("snapshot %s not found", snapshotID)
+	}
+	old := snapshots[0]
+	if len(add) == 0 && len(remove) == 0 {
+		return &old, nil
+	}
+	if _, err = w.tag(old.ID, add, remove); err != nil {
+		return nil, err
+	}
+
+	// restic keeps the ID of the very first snapshot in the "original" field of the derived snapshots
+	original := old.Original
+	if original == "" {
+		original = old.ID
+	}
+	snapshots, err = w.listSnapshots(nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := range snapshots {
+		if snapshots[i].Original == original {
+			return &snapshots[i], nil
+		}
+	}
+	// no snapshot has been modified if the tags were already up to date
+	return &old, nil
 }
@@ -0,0 +1,85 @@
+package restic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// resolveSnapshots returns the snapshots that will be restored for the RestoreOptions.
+// Explicitly specified snapshots are used as it is. Otherwise, the latest snapshot of each of the RestoreDirs
+// that matches the SnapshotFilter is selected.
+func (w *ResticWrapper) resolveSnapshots(restoreOptions RestoreOptions) ([]Snapshot, error) {
+	if len(restoreOptions.Snapshots) != 0 {
+		return w.listSnapshots(restoreOptions.Snapshots)
+	}
+	if len(restoreOptions.RestoreDirs) == 0 && restoreOptions.SnapshotFilter == nil {
+		return nil, nil
+	}
+
+	var filter SnapshotFilter
+	if restoreOptions.SnapshotFilter != nil {
+		filter = *restoreOptions.SnapshotFilter
+	}
+	candidates, err := w.findSnapshots(restoreOptions.SourceHost, filter.Tags)
+	if err != nil {
+		return nil, err
+	}
+	// keep only the snapshots taken at or before the specified time
+	if filter.Before != nil {
+		var filtered []Snapshot
+		for _, snapshot := range candidates {
+			if !snapshot.Time.After(*filter.Before) {
+				filtered = append(filtered, snapshot)
+			}
+		}
+		candidates = filtered
+	}
+	// sort from the newest to the oldest
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Time.After(candidates[j].Time)
+	})
+
+	var selected []Snapshot
+	if len(restoreOptions.RestoreDirs) != 0 {
+		for _, dir := range restoreOptions.RestoreDirs {
+			found := false
+			for _, snapshot := range candidates {
+				if containsPath(snapshot.Paths, dir) {
+					selected = append(selected, snapshot)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("no snapshot found for path %s that matches the selection", dir)
+			}
+		}
+		return selected, nil
+	}
+
+	// no path has been specified. so, select the latest snapshot of each backed up path.
+	seen := make(map[string]bool)
+	for _, snapshot := range candidates {
+		paths := append([]string(nil), snapshot.Paths...)
+		sort.Strings(paths)
+		key := strings.Join(paths, ",")
+		if !seen[key] {
+			seen[key] = true
+			selected = append(selected, snapshot)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no snapshot found that matches the selection")
+	}
+	return selected, nil
+}
+
+func containsPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
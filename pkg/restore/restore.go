@@ -19,7 +19,9 @@ import (
 	stash_scheme "stash.appscode.dev/stash/client/clientset/versioned/scheme"
 	stash_util_v1beta1 "stash.appscode.dev/stash/client/clientset/versioned/typed/stash/v1beta1/util"
 	"stash.appscode.dev/stash/pkg/eventer"
+	"stash.appscode.dev/stash/pkg/hooks"
 	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/status"
 	"stash.appscode.dev/stash/pkg/util"
 )
 
@@ -115,9 +117,9 @@ func (opt *Options) electRestoreLeader(restoreSession *api_v1beta1.RestoreSessio
 			OnStartedLeading: func(ctx context.Context) {
 				log.Infoln("Got leadership, preparing for restore")
 				// run restore process
-				err := opt.runRestore(restoreSession)
+				hookStats, err := opt.runRestore(restoreSession)
 				if err != nil {
-					e2 := HandleRestoreFailure(opt, err)
+					e2 := opt.handleRestoreFailure(hookStats, err)
 					if e2 != nil {
 						err = errors.NewAggregate([]error{err, e2})
 					}
@@ -137,43 +139,89 @@ func (opt *Options) electRestoreLeader(restoreSession *api_v1beta1.RestoreSessio
 	return nil
 }
 
-func (opt *Options) runRestore(restoreSession *api_v1beta1.RestoreSession) error {
+// runRestore restores the host along with executing the preRestore and postRestore hooks.
+// It returns the results of the hooks that has been executed so that they can be reported on failure too.
+func (opt *Options) runRestore(restoreSession *api_v1beta1.RestoreSession) ([]api_v1beta1.HookStats, error) {
+
+	// get the latest RestoreSession as it might have been cancelled while waiting for the leadership
+	restoreSession, err := opt.StashClient.StashV1beta1().RestoreSessions(restoreSession.Namespace).Get(restoreSession.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
 
 	host, err := util.GetHostName(restoreSession.Spec.Target)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// if already restored for this host then don't process further
 	if opt.isRestoredForThisHost(restoreSession, host) {
 		log.Infof("Skipping restore for RestoreSession %s/%s. Reason: RestoreSession already processed for host %q.", restoreSession.Namespace, restoreSession.Name, host)
-		return nil
+		return nil, nil
 	}
 
 	// setup restic wrapper
 	w, err := restic.NewResticWrapper(opt.SetupOpt)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	o := status.UpdateStatusOptions{
+		KubeClient:     opt.KubeClient,
+		StashClient:    opt.StashClient.(*cs.Clientset),
+		Namespace:      opt.Namespace,
+		RestoreSession: restoreSession.Name,
+	}
+	// interrupt the restore if the RestoreSession is cancelled while it is running
+	stopCancelWatcher := o.WatchCancellation(w.Cancel)
+	defer stopCancelWatcher()
+
+	// application containers are not running while the init-container is restoring.
+	// so, exec hooks are executed inside this container.
+	hookExecutor := &hooks.HookExecutor{}
+	restoreHooks := restoreSession.Spec.Hooks
+	if restoreHooks == nil {
+		restoreHooks = &api_v1beta1.RestoreHooks{}
+	}
+
+	// execute preRestore hook. abort restore if the hook fails and its failure policy does not allow to continue.
+	if err = hookExecutor.Execute(api_v1beta1.PreRestoreHook, restoreHooks.PreRestore); err != nil {
+		return hookExecutor.Stats, err
 	}
 
 	// run restore process
-	restoreOutput, err := w.RunRestore(util.RestoreOptionsForHost(host, restoreSession.Spec.Rules))
+	// report progress of this host in RestoreSession status while restore is running
+	progressReporter := o.NewProgressReporter(status.DefaultProgressUpdateInterval)
+	restoreOpt := util.RestoreOptionsForHost(host, restoreSession.Spec.Rules)
+	restoreOpt.OnProgress = progressReporter.ReportRestoreProgress
+	restoreOutput, err := w.RunRestore(restoreOpt)
+	progressReporter.Stop()
+	if err == restic.ErrCancelled {
+		// don't fail the container on cancellation. otherwise, the workload will never start.
+		return hookExecutor.Stats, opt.handleRestoreCancellation(o, w, restoreOutput, hookExecutor.Stats)
+	}
 	if err != nil {
-		return err
+		return hookExecutor.Stats, err
 	}
 
+	// execute postRestore hook
+	if err = hookExecutor.Execute(api_v1beta1.PostRestoreHook, restoreHooks.PostRestore); err != nil {
+		return hookExecutor.Stats, err
+	}
+	restoreOutput.HostRestoreStats.Hooks = hookExecutor.Stats
+
 	// if metrics are enabled then send metrics
 	if opt.Metrics.Enabled {
 		err := restoreOutput.HandleMetrics(&opt.Metrics, nil)
 		if err != nil {
-			return err
+			return hookExecutor.Stats, err
 		}
 	}
 
 	// restore is complete. add/update an entry for this host in RestoreSession status
 	_, err = stash_util_v1beta1.UpdateRestoreSessionStatusForHost(opt.StashClient.StashV1beta1(), restoreSession, restoreOutput.HostRestoreStats)
 	if err != nil {
-		return err
+		return hookExecutor.Stats, err
 	}
 
 	// write success event
@@ -189,10 +237,25 @@ func (opt *Options) runRestore(restoreSession *api_v1beta1.RestoreSession) error
 		)
 	}
 
-	return nil
+	return hookExecutor.Stats, nil
+}
+
+// handleRestoreCancellation removes the stale locks that the interrupted restore might have left in the repository
+// and records the cancellation for this host.
+func (opt *Options) handleRestoreCancellation(o status.UpdateStatusOptions, w *restic.ResticWrapper, restoreOutput *restic.RestoreOutput, hookStats []api_v1beta1.HookStats) error {
+	log.Infof("Restore has been cancelled for RestoreSession %s/%s", o.Namespace, o.RestoreSession)
+	if err := w.RemoveStaleLocks(); err != nil {
+		log.Warningln("failed to remove stale locks from the repository. Reason: ", err.Error())
+	}
+	restoreOutput.HostRestoreStats.Hooks = hookStats
+	return o.UpdatePostRestoreStatus(restoreOutput)
 }
 
 func HandleRestoreFailure(opt *Options, restoreErr error) error {
+	return opt.handleRestoreFailure(nil, restoreErr)
+}
+
+func (opt *Options) handleRestoreFailure(hookStats []api_v1beta1.HookStats, restoreErr error) error {
 	restoreSession, err := opt.StashClient.StashV1beta1().RestoreSessions(opt.Namespace).Get(opt.RestoreSessionName, metav1.GetOptions{})
 	if err != nil {
 		return err
@@ -206,7 +269,8 @@ func HandleRestoreFailure(opt *Options, restoreErr error) error {
 	hostStats := api_v1beta1.HostRestoreStats{
 		Hostname: host,
 		Phase:    api_v1beta1.HostRestoreFailed,
-		Error:    err.Error(),
+		Error:    restoreErr.Error(),
+		Hooks:    hookStats,
 	}
 	// add or update entry for this host in RestoreSession status
 	_, err = stash_util_v1beta1.UpdateRestoreSessionStatusForHost(opt.StashClient.StashV1beta1(), restoreSession, hostStats)
@@ -243,9 +307,15 @@ func (opt *Options) writeRestoreFailureEvent(restoreSession *api_v1beta1.Restore
 
 func (opt *Options) isRestoredForThisHost(restoreSession *api_v1beta1.RestoreSession, host string) bool {
 
-	// if overall restoreSession phase is "Succeeded" or "Failed" then it has been processed already
+	// if overall restoreSession phase is "Succeeded", "Failed" or "Cancelled" then it has been processed already
 	if restoreSession.Status.Phase == api_v1beta1.RestoreSessionSucceeded ||
-		restoreSession.Status.Phase == api_v1beta1.RestoreSessionFailed {
+		restoreSession.Status.Phase == api_v1beta1.RestoreSessionFailed ||
+		restoreSession.Status.Phase == api_v1beta1.RestoreSessionCancelled {
+		return true
+	}
+
+	// don't start restore if the RestoreSession has been cancelled
+	if restoreSession.Spec.Cancel {
 		return true
 	}
 
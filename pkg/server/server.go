@@ -117,6 +117,7 @@ func (c completedConfig) New() (*StashServer, error) {
 			ctrl.NewRecoveryWebhook(),
 			ctrl.NewRepositoryWebhook(),
 			// ctrl.NewBackupSessionWebhook(),
+			ctrl.NewBackupConfigurationWebhook(),
 			ctrl.NewRestoreSessionWebhook(),
 		)
 	}
@@ -238,11 +239,24 @@ func (c completedConfig) New() (*StashServer, error) {
 	}
 
 	{
-		apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(repositories.GroupName, Scheme, metav1.ParameterCodec, Codecs)
+		// options of the subresources are decoded from the query parameters using the scheme of the group
+		apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(repositories.GroupName, Scheme, runtime.NewParameterCodec(Scheme), Codecs)
 		v1alpha1storage := map[string]rest.Storage{}
-		v1alpha1storage[v1alpha1.ResourcePluralSnapshot] = snapregistry.NewREST(c.ExtraConfig.ClientConfig)
+		snapshotStorage := snapregistry.NewREST(c.ExtraConfig.ClientConfig)
+		v1alpha1storage[v1alpha1.ResourcePluralSnapshot] = snapshotStorage
+		v1alpha1storage[v1alpha1.ResourcePluralSnapshot+"/"+v1alpha1.SubresourceSnapshotFiles] = snapregistry.NewFilesREST(snapshotStorage)
+		v1alpha1storage[v1alpha1.ResourcePluralSnapshot+"/"+v1alpha1.SubresourceSnapshotDownload] = snapregistry.NewDownloadREST(snapshotStorage)
+		v1alpha1storage[v1alpha1.ResourcePluralSnapshot+"/"+v1alpha1.SubresourceSnapshotDiff] = snapregistry.NewDiffREST(snapshotStorage)
 		apiGroupInfo.VersionedResourcesStorageMap["v1alpha1"] = v1alpha1storage
 
+		// invalidate the cached snapshots of a repository when a backup into it completes
+		s.GenericAPIServer.AddPostStartHookOrDie("snapshot-cache-invalidator",
+			func(context genericapiserver.PostStartHookContext) error {
+				go snapshotStorage.RunCacheInvalidator(context.StopCh)
+				return nil
+			},
+		)
+
 		if err := s.GenericAPIServer.InstallAPIGroup(&apiGroupInfo); err != nil {
 			return nil, err
 		}
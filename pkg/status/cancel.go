@@ -0,0 +1,65 @@
+package status
+
+import (
+	"sync"
+	"time"
+
+	"github.com/appscode/go/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+)
+
+// CancellationCheckInterval is the interval at which a running session is checked for cancellation request
+const CancellationCheckInterval = 5 * time.Second
+
+// WatchCancellation periodically checks whether cancellation has been requested for the BackupSession
+// or RestoreSession of the options and calls onCancel once it has been requested. A BackupSession that
+// has been failed by the operator for exceeding its active deadline is cancelled too. The session is polled
+// instead of watched as the process that runs backup or restore does not have any informer running.
+// It stops when the returned function is called.
+func (o UpdateStatusOptions) WatchCancellation(onCancel func()) func() {
+	stopCh := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(CancellationCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				cancelled, err := o.isCancellationRequested()
+				if err != nil {
+					log.Warningf("Failed to check cancellation request. Reason: %v", err)
+					continue
+				}
+				if cancelled {
+					log.Infoln("Cancellation has been requested. Cancelling the running operation.")
+					onCancel()
+					return
+				}
+			}
+		}
+	}()
+	return func() {
+		close(stopCh)
+		wg.Wait()
+	}
+}
+
+func (o UpdateStatusOptions) isCancellationRequested() (bool, error) {
+	if o.BackupSession != "" {
+		backupSession, err := o.StashClient.StashV1beta1().BackupSessions(o.Namespace).Get(o.BackupSession, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return backupSession.Spec.Cancel || backupSession.Status.Phase == api_v1beta1.BackupSessionFailed, nil
+	}
+	restoreSession, err := o.StashClient.StashV1beta1().RestoreSessions(o.Namespace).Get(o.RestoreSession, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	return restoreSession.Spec.Cancel, nil
+}
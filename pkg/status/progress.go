@@ -0,0 +1,104 @@
+package status
+
+import (
+	"sync"
+	"time"
+
+	"github.com/appscode/go/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	stash_util_v1beta1 "stash.appscode.dev/stash/client/clientset/versioned/typed/stash/v1beta1/util"
+)
+
+// DefaultProgressUpdateInterval is the minimum interval between two progress updates of a host
+const DefaultProgressUpdateInterval = 10 * time.Second
+
+// ProgressReporter writes the latest progress of a host into the BackupSession or RestoreSession status.
+// To avoid flooding the API server, status is updated at most once in each interval and the
+// intermediate progress are discarded.
+type ProgressReporter struct {
+	opt      UpdateStatusOptions
+	interval time.Duration
+
+	mu     sync.Mutex
+	update func() error
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewProgressReporter starts a ProgressReporter. It must be stopped once backup or restore is completed.
+func (o UpdateStatusOptions) NewProgressReporter(interval time.Duration) *ProgressReporter {
+	r := &ProgressReporter{
+		opt:      o,
+		interval: interval,
+		stopCh:   make(chan struct{}),
+	}
+	r.wg.Add(1)
+	go r.run()
+	return r
+}
+
+func (r *ProgressReporter) run() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+			r.flush()
+		}
+	}
+}
+
+func (r *ProgressReporter) flush() {
+	r.mu.Lock()
+	update := r.update
+	r.update = nil
+	r.mu.Unlock()
+
+	if update == nil {
+		return
+	}
+	if err := update(); err != nil {
+		log.Warningf("Failed to update progress. Reason: %v", err)
+	}
+}
+
+func (r *ProgressReporter) set(update func() error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.update = update
+}
+
+// Stop stops the reporter. Pending progress is discarded as the final stats supersede it.
+func (r *ProgressReporter) Stop() {
+	close(r.stopCh)
+	r.wg.Wait()
+}
+
+// ReportBackupProgress records the latest backup progress of a host
+func (r *ProgressReporter) ReportBackupProgress(progress api_v1beta1.HostBackupProgress) {
+	r.set(func() error {
+		backupSession, err := r.opt.StashClient.StashV1beta1().BackupSessions(r.opt.Namespace).Get(r.opt.BackupSession, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		_, err = stash_util_v1beta1.UpdateBackupSessionProgressForHost(r.opt.StashClient.StashV1beta1(), backupSession, progress)
+		return err
+	})
+}
+
+// ReportRestoreProgress records the latest restore progress of a host
+func (r *ProgressReporter) ReportRestoreProgress(progress api_v1beta1.HostRestoreProgress) {
+	r.set(func() error {
+		restoreSession, err := r.opt.StashClient.StashV1beta1().RestoreSessions(r.opt.Namespace).Get(r.opt.RestoreSession, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		_, err = stash_util_v1beta1.UpdateRestoreSessionProgressForHost(r.opt.StashClient.StashV1beta1(), restoreSession, progress)
+		return err
+	})
+}
@@ -73,7 +73,11 @@ func (o UpdateStatusOptions) UpdatePostBackupStatus(backupOutput *restic.BackupO
 
 	// create event for backup session
 	var eventType, eventReason, eventMessage string
-	if backupOutput.HostBackupStats.Error != "" {
+	if backupOutput.HostBackupStats.Phase == api_v1beta1.HostBackupCancelled {
+		eventType = core.EventTypeWarning
+		eventReason = eventer.EventReasonHostBackupCancelled
+		eventMessage = fmt.Sprintf("backup cancelled for host %s", backupOutput.HostBackupStats.Hostname)
+	} else if backupOutput.HostBackupStats.Error != "" {
 		eventType = core.EventTypeWarning
 		eventReason = eventer.EventReasonHostBackupFailed
 		eventMessage = fmt.Sprintf("backup failed for host %q. Reason: %s", backupOutput.HostBackupStats.Hostname, backupOutput.HostBackupStats.Error)
@@ -94,40 +98,138 @@ func (o UpdateStatusOptions) UpdatePostBackupStatus(backupOutput *restic.BackupO
 		return err
 	}
 
-	// no need to update repository status for failed backup
+	// no need to update repository status for failed or cancelled backup
 	if backupOutput.HostBackupStats.Error != "" {
 		return nil
 	}
+	// repository maintenance has been deferred to a coordinated step which will update repository status
+	if backupOutput.RepositoryStats == (restic.RepositoryStats{}) {
+		return nil
+	}
 	bs, err := o.StashClient.StashV1beta1().BackupConfigurations(o.Namespace).Get(backupSession.Spec.BackupConfiguration.Name, metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
 	if bs.Spec.Driver != api_v1beta1.VolumeSnapshotter {
-		// get repository and update status
-		repository, err := o.StashClient.StashV1alpha1().Repositories(o.Namespace).Get(o.Repository, metav1.GetOptions{})
-		if err != nil {
-			return err
-		}
-		_, err = stash_util.UpdateRepositoryStatus(
-			o.StashClient.StashV1alpha1(),
-			repository,
-			func(in *api.RepositoryStatus) *api.RepositoryStatus {
-				// TODO: fix Restic Wrapper
-				in.Integrity = backupOutput.RepositoryStats.Integrity
-				in.Size = backupOutput.RepositoryStats.Size
-				in.SnapshotCount = backupOutput.RepositoryStats.SnapshotCount
-				in.SnapshotsRemovedOnLastCleanup = backupOutput.RepositoryStats.SnapshotsRemovedOnLastCleanup
-
-				currentTime := metav1.Now()
-				in.LastBackupTime = &currentTime
-
-				if in.FirstBackupTime == nil {
-					in.FirstBackupTime = &currentTime
+		return o.UpdateRepositoryStatus(backupOutput.RepositoryStats)
+	}
+	return nil
+}
+
+// UpdateRepositoryStatus updates the Repository status with the statistics collected by repository maintenance
+func (o UpdateStatusOptions) UpdateRepositoryStatus(repoStats restic.RepositoryStats) error {
+	// get repository and update status
+	repository, err := o.StashClient.StashV1alpha1().Repositories(o.Namespace).Get(o.Repository, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	_, err = stash_util.UpdateRepositoryStatus(
+		o.StashClient.StashV1alpha1(),
+		repository,
+		func(in *api.RepositoryStatus) *api.RepositoryStatus {
+			// TODO: fix Restic Wrapper
+			// integrity is not checked after backup if it has been scheduled separately
+			if repoStats.Integrity != nil {
+				in.Integrity = repoStats.Integrity
+			}
+			in.Size = repoStats.Size
+			in.SnapshotCount = repoStats.SnapshotCount
+			in.SnapshotsRemovedOnLastCleanup = repoStats.SnapshotsRemovedOnLastCleanup
+
+			currentTime := metav1.Now()
+			in.LastBackupTime = &currentTime
+
+			if in.FirstBackupTime == nil {
+				in.FirstBackupTime = &currentTime
+			}
+			return in
+		},
+		apis.EnableStatusSubresource,
+	)
+	return err
+}
+
+// UpdateRepositoryMaintenanceStatus updates the Repository status with the result of a scheduled maintenance task
+func (o UpdateStatusOptions) UpdateRepositoryMaintenanceStatus(task string, repoStats *restic.RepositoryStats, taskStatus api.MaintenanceTaskStatus) error {
+	repository, err := o.StashClient.StashV1alpha1().Repositories(o.Namespace).Get(o.Repository, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	_, err = stash_util.UpdateRepositoryStatus(
+		o.StashClient.StashV1alpha1(),
+		repository,
+		func(in *api.RepositoryStatus) *api.RepositoryStatus {
+			switch task {
+			case api.MaintenanceTaskCheck:
+				in.LastCheck = &taskStatus
+				if repoStats != nil && repoStats.Integrity != nil {
+					in.Integrity = repoStats.Integrity
+				}
+			case api.MaintenanceTaskPrune:
+				in.LastPrune = &taskStatus
+				// the data of the snapshots deleted before this prune have been removed
+				if taskStatus.Phase == api.MaintenanceTaskSucceeded && in.PendingPrune != nil && prunedPendingSnapshots(in.PendingPrune, taskStatus) {
+					in.PendingPrune = nil
 				}
-				return in
-			},
-			apis.EnableStatusSubresource,
-		)
+				if repoStats != nil {
+					in.Size = repoStats.Size
+					// snapshot count is known only if snapshots have been removed according to a retention policy
+					if repoStats.SnapshotCount > 0 {
+						in.SnapshotCount = repoStats.SnapshotCount
+						in.SnapshotsRemovedOnLastCleanup = repoStats.SnapshotsRemovedOnLastCleanup
+					}
+				}
+			}
+			return in
+		},
+		apis.EnableStatusSubresource,
+	)
+	if err != nil {
+		return err
+	}
+
+	eventType, eventReason, eventMessage := core.EventTypeNormal, eventer.EventReasonRepositoryMaintenanceSucceeded,
+		fmt.Sprintf("Repository %s task has been completed successfully in %s", task, taskStatus.Duration)
+	if taskStatus.Phase == api.MaintenanceTaskFailed {
+		eventType, eventReason, eventMessage = core.EventTypeWarning, eventer.EventReasonRepositoryMaintenanceFailed,
+			fmt.Sprintf("Repository %s task has failed. Reason: %s", task, taskStatus.Error)
+	}
+	_, err = eventer.CreateEvent(o.KubeClient, eventer.EventSourceRepositoryMaintenanceJob, repository, eventType, eventReason, eventMessage)
+	return err
+}
+
+// UpdateRepositoryPasswordRotationStatus updates the progress of password rotation in the Repository status.
+// It writes an event when the rotation has been completed.
+func (o UpdateStatusOptions) UpdateRepositoryPasswordRotationStatus(transform func(in *api.PasswordRotationStatus)) error {
+	repository, err := o.StashClient.StashV1alpha1().Repositories(o.Namespace).Get(o.Repository, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	var rotationStatus api.PasswordRotationStatus
+	_, err = stash_util.UpdateRepositoryStatus(
+		o.StashClient.StashV1alpha1(),
+		repository,
+		func(in *api.RepositoryStatus) *api.RepositoryStatus {
+			if in.PasswordRotation == nil {
+				in.PasswordRotation = &api.PasswordRotationStatus{}
+			}
+			transform(in.PasswordRotation)
+			rotationStatus = *in.PasswordRotation
+			return in
+		},
+		apis.EnableStatusSubresource,
+	)
+	if err != nil {
+		return err
+	}
+
+	switch rotationStatus.Phase {
+	case api.PasswordRotationSucceeded:
+		_, err = eventer.CreateEvent(o.KubeClient, eventer.EventSourcePasswordRotationJob, repository, core.EventTypeNormal,
+			eventer.EventReasonPasswordRotationSucceeded, "Repository password has been rotated successfully")
+	case api.PasswordRotationFailed:
+		_, err = eventer.CreateEvent(o.KubeClient, eventer.EventSourcePasswordRotationJob, repository, core.EventTypeWarning,
+			eventer.EventReasonPasswordRotationFailed, fmt.Sprintf("Repository password rotation has failed. Reason: %s", rotationStatus.Error))
 	}
 	return err
 }
@@ -147,7 +249,11 @@ func (o UpdateStatusOptions) UpdatePostRestoreStatus(restoreOutput *restic.Resto
 
 	// create event for restore session
 	var eventType, eventReason, eventMessage string
-	if restoreOutput.HostRestoreStats.Error != "" {
+	if restoreOutput.HostRestoreStats.Phase == api_v1beta1.HostRestoreCancelled {
+		eventType = core.EventTypeWarning
+		eventReason = eventer.EventReasonHostRestoreCancelled
+		eventMessage = fmt.Sprintf("restore cancelled for host %q", restoreOutput.HostRestoreStats.Hostname)
+	} else if restoreOutput.HostRestoreStats.Error != "" {
 		eventType = core.EventTypeWarning
 		eventReason = eventer.EventReasonHostRestoreFailed
 		eventMessage = fmt.Sprintf("restore failed for host %q. Reason: %s", restoreOutput.HostRestoreStats.Hostname, restoreOutput.HostRestoreStats.Error)
@@ -166,3 +272,16 @@ func (o UpdateStatusOptions) UpdatePostRestoreStatus(restoreOutput *restic.Resto
 	)
 	return err
 }
+
+// prunedPendingSnapshots returns true if the prune has removed the data of the pending snapshots. The snapshots
+// deleted after the prune has started may not have been pruned. So, it returns true only if the first of the
+// pending snapshots has been deleted before the prune has started.
+func prunedPendingSnapshots(pending *api.PendingPruneStatus, prune api.MaintenanceTaskStatus) bool {
+	if pending.Since == nil {
+		return true
+	}
+	if prune.StartTime == nil {
+		return false
+	}
+	return pending.Since.Before(prune.StartTime)
+}
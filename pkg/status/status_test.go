@@ -0,0 +1,35 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "stash.appscode.dev/stash/apis/stash/v1alpha1"
+)
+
+func TestPrunedPendingSnapshots(t *testing.T) {
+	start := metav1.NewTime(time.Date(2019, 10, 1, 10, 0, 0, 0, time.UTC))
+	before := metav1.NewTime(start.Add(-time.Minute))
+	after := metav1.NewTime(start.Add(time.Minute))
+
+	testCases := []struct {
+		name    string
+		since   *metav1.Time
+		start   *metav1.Time
+		cleared bool
+	}{
+		{name: "deleted before the prune has started", since: &before, start: &start, cleared: true},
+		{name: "deleted after the prune has started", since: &after, start: &start, cleared: false},
+		{name: "deletion time unknown", since: nil, start: &start, cleared: true},
+		{name: "start time of the prune unknown", since: &before, start: nil, cleared: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pending := &api.PendingPruneStatus{SnapshotsDeleted: 1, Since: tc.since}
+			prune := api.MaintenanceTaskStatus{Phase: api.MaintenanceTaskSucceeded, StartTime: tc.start}
+			assert.Equal(t, tc.cleared, prunedPendingSnapshots(pending, prune))
+		})
+	}
+}
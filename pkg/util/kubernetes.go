@@ -37,6 +37,9 @@ const (
 	ScaledownCronPrefix = "stash-scaledown-cron-"
 	CheckJobPrefix      = "stash-check-"
 
+	MaintenanceCronJobPrefix  = "stash-maintenance-"
+	PasswordRotationJobPrefix = "stash-rotate-password-"
+
 	AnnotationRestic     = "restic"
 	AnnotationRecovery   = "recovery"
 	AnnotationOperation  = "operation"
@@ -45,6 +48,9 @@ const (
 	OperationRecovery = "recovery"
 	OperationCheck    = "check"
 
+	OperationRepositoryMaintenance = "repository-maintenance"
+	OperationPasswordRotation      = "password-rotation"
+
 	AppLabelStash        = "stash"
 	AppLabelStashV1Beta1 = "stash-v1beta1"
 	OperationScaleDown   = "scale-down"
@@ -1,7 +1,11 @@
 package util
 
 import (
+	"fmt"
+	"strings"
+
 	go_str "github.com/appscode/go/strings"
+	"stash.appscode.dev/stash/apis"
 	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
 	api "stash.appscode.dev/stash/apis/stash/v1beta1"
 	"stash.appscode.dev/stash/pkg/restic"
@@ -19,14 +23,125 @@ type ExtraOptions struct {
 func BackupOptionsForBackupConfig(backupConfig api.BackupConfiguration, extraOpt ExtraOptions) restic.BackupOptions {
 	backupOpt := restic.BackupOptions{
 		Host:            extraOpt.Host,
-		RetentionPolicy: backupConfig.Spec.RetentionPolicy,
+		RetentionPolicy: RetentionPolicyForBackupConfig(backupConfig, extraOpt.Host),
 	}
 	if backupConfig.Spec.Target != nil {
 		backupOpt.BackupDirs = backupConfig.Spec.Target.Directories
+		backupOpt.Filter = BackupFilterForTarget(*backupConfig.Spec.Target)
 	}
 	return backupOpt
 }
 
+// BackupFilterForTarget returns the options that specify which files of the target directories should be backed up
+func BackupFilterForTarget(target api.BackupTarget) restic.BackupFilter {
+	return restic.BackupFilter{
+		Exclude:          target.Exclude,
+		Include:          target.Include,
+		ExcludeIfPresent: target.ExcludeIfPresent,
+		IgnoreFileName:   target.IgnoreFileName,
+		ExcludeCaches:    target.ExcludeCaches,
+		OneFileSystem:    target.OneFileSystem,
+	}
+}
+
+// RetentionPolicyForBackupConfig returns the retention policy of the BackupConfiguration. If no filter is specified
+// in the policy, it restricts the policy to the snapshots of the host that has been taken by this BackupConfiguration.
+// So, a BackupConfiguration can't remove the snapshots of other BackupConfigurations or hosts sharing the same Repository.
+func RetentionPolicyForBackupConfig(backupConfig api.BackupConfiguration, host string) api_v1alpha1.RetentionPolicy {
+	retentionPolicy := *backupConfig.Spec.RetentionPolicy.DeepCopy()
+	if retentionPolicy.Filter == nil {
+		retentionPolicy.Filter = &api_v1alpha1.RetentionFilter{
+			Host: host,
+			Tags: []string{fmt.Sprintf("%s=%s", TagBackupConfiguration, backupConfig.Name)},
+		}
+	}
+	return retentionPolicy
+}
+
+// RetentionFilterFlags holds the retention filter specified through command line flags
+type RetentionFilterFlags struct {
+	GroupBy []string
+	Host    string
+	Tags    []string
+	Paths   []string
+}
+
+// RetentionFilter returns nil if no filter has been specified
+func (f RetentionFilterFlags) RetentionFilter() *api_v1alpha1.RetentionFilter {
+	if len(f.GroupBy) == 0 && f.Host == "" && len(f.Tags) == 0 && len(f.Paths) == 0 {
+		return nil
+	}
+	filter := &api_v1alpha1.RetentionFilter{
+		Host:  f.Host,
+		Tags:  f.Tags,
+		Paths: f.Paths,
+	}
+	for _, g := range f.GroupBy {
+		filter.GroupBy = append(filter.GroupBy, api_v1alpha1.RetentionGroupBy(g))
+	}
+	return filter
+}
+
+// tags that are added to each snapshot taken for a BackupConfiguration.
+// each tag has "<key>=<value>" format.
+const (
+	TagBackupSession       = "backup-session"
+	TagBackupConfiguration = "backup-configuration"
+	TagTargetKind          = "target-kind"
+	TagTargetName          = "target-name"
+)
+
+// SnapshotTags returns the tags to add to the snapshots taken by a BackupSession of a BackupConfiguration.
+// It includes the user provided tags of the backup target.
+func SnapshotTags(backupSessionName string, backupConfig api.BackupConfiguration) []string {
+	tags := []string{
+		fmt.Sprintf("%s=%s", TagBackupSession, backupSessionName),
+		fmt.Sprintf("%s=%s", TagBackupConfiguration, backupConfig.Name),
+	}
+	if backupConfig.Spec.Target != nil {
+		tags = append(tags,
+			fmt.Sprintf("%s=%s", TagTargetKind, backupConfig.Spec.Target.Ref.Kind),
+			fmt.Sprintf("%s=%s", TagTargetName, backupConfig.Spec.Target.Ref.Name),
+		)
+		tags = append(tags, backupConfig.Spec.Target.Tags...)
+	}
+	return tags
+}
+
+// SnapshotLabelsFromTags converts the tags added by Stash into snapshot labels (i.e. "stash.appscode.com/backup-session")
+// so that snapshots can be selected by them. Other tags are ignored.
+func SnapshotLabelsFromTags(tags []string) map[string]string {
+	labels := make(map[string]string)
+	for _, tag := range tags {
+		parts := strings.SplitN(tag, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		switch parts[0] {
+		case TagBackupSession, TagBackupConfiguration, TagTargetKind, TagTargetName:
+			labels[SnapshotLabelKey(parts[0])] = parts[1]
+		}
+	}
+	return labels
+}
+
+// SnapshotLabelKey returns the snapshot label key for a tag key
+func SnapshotLabelKey(tagKey string) string {
+	return apis.StashKey + "/" + tagKey
+}
+
+// IsSnapshotTagLabel returns true if the label key is derived from a snapshot tag
+func IsSnapshotTagLabel(key string) bool {
+	switch key {
+	case SnapshotLabelKey(TagBackupSession),
+		SnapshotLabelKey(TagBackupConfiguration),
+		SnapshotLabelKey(TagTargetKind),
+		SnapshotLabelKey(TagTargetName):
+		return true
+	}
+	return false
+}
+
 func RestoreOptionForRestoreSession(restoreSession api.RestoreSession, extraOpt ExtraOptions) restic.RestoreOptions {
 	return RestoreOptionsForHost(extraOpt.Host, restoreSession.Spec.Rules)
 }
@@ -45,10 +160,15 @@ func RestoreOptionsForHost(hostname string, rules []api.Rule) restic.RestoreOpti
 
 		if len(rule.TargetHosts) == 0 || go_str.Contains(rule.TargetHosts, hostname) {
 			matchedRule = restic.RestoreOptions{
-				Host:        hostname,
-				SourceHost:  sourceHost,
-				RestoreDirs: rule.Paths,
-				Snapshots:   rule.Snapshots,
+				Host:           hostname,
+				SourceHost:     sourceHost,
+				RestoreDirs:    rule.Paths,
+				Snapshots:      rule.Snapshots,
+				Destination:    rule.Destination,
+				Include:        rule.Include,
+				Exclude:        rule.Exclude,
+				ConflictPolicy: rule.ConflictPolicy,
+				SnapshotFilter: SnapshotFilterForSelector(rule.SnapshotSelector),
 			}
 			// if rule has empty targetHost then check further rules to see if any other rule with non-empty targetHost matches
 			if len(rule.TargetHosts) == 0 {
@@ -62,6 +182,11 @@ func RestoreOptionsForHost(hostname string, rules []api.Rule) restic.RestoreOpti
 	return matchedRule
 }
 
+// SkipCheckAfterBackup returns true if integrity check after each backup has been disabled for the repository
+func SkipCheckAfterBackup(repository api_v1alpha1.Repository) bool {
+	return repository.Spec.Maintenance != nil && repository.Spec.Maintenance.DisableCheckAfterBackup
+}
+
 func SetupOptionsForRepository(repository api_v1alpha1.Repository, extraOpt ExtraOptions) (restic.SetupOptions, error) {
 	provider, err := GetProvider(repository.Spec.Backend)
 	if err != nil {
@@ -84,3 +209,23 @@ func SetupOptionsForRepository(repository api_v1alpha1.Repository, extraOpt Extr
 		URL:            GetRestUrl(repository.Spec.Backend),
 	}, nil
 }
+
+// SnapshotFilterForSelector converts the snapshot selector of a restore rule into the filter of restic snapshots.
+// A BackupSession is selected by the tag that has been added to its snapshots.
+func SnapshotFilterForSelector(selector *api.SnapshotSelector) *restic.SnapshotFilter {
+	if selector == nil {
+		return nil
+	}
+	// copy the tags so that appending to them does not modify the selector
+	filter := &restic.SnapshotFilter{
+		Tags: append([]string(nil), selector.Tags...),
+	}
+	if selector.BackupSession != "" {
+		filter.Tags = append(filter.Tags, fmt.Sprintf("%s=%s", TagBackupSession, selector.BackupSession))
+	}
+	if selector.Before != nil {
+		before := selector.Before.Time
+		filter.Before = &before
+	}
+	return filter
+}
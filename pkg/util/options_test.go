@@ -0,0 +1,150 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	api "stash.appscode.dev/stash/apis/stash/v1beta1"
+)
+
+func TestSnapshotLabelsFromTags(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tags     []string
+		expected map[string]string
+	}{
+		{
+			name:     "no tags",
+			expected: map[string]string{},
+		},
+		{
+			name: "stash tags",
+			tags: []string{"backup-session=sample-1", "backup-configuration=sample", "target-kind=Deployment", "target-name=app"},
+			expected: map[string]string{
+				"stash.appscode.com/backup-session":       "sample-1",
+				"stash.appscode.com/backup-configuration": "sample",
+				"stash.appscode.com/target-kind":          "Deployment",
+				"stash.appscode.com/target-name":          "app",
+			},
+		},
+		{
+			name:     "user tags are ignored",
+			tags:     []string{"daily", "env=prod", "backup-session=sample-1"},
+			expected: map[string]string{"stash.appscode.com/backup-session": "sample-1"},
+		},
+		{
+			name:     "value containing '='",
+			tags:     []string{"backup-configuration=a=b"},
+			expected: map[string]string{"stash.appscode.com/backup-configuration": "a=b"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := SnapshotLabelsFromTags(tc.tags); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRetentionPolicyForBackupConfig(t *testing.T) {
+	// two BackupConfigurations back up into the same Repository from the same host
+	newBackupConfig := func(name string, filter *api_v1alpha1.RetentionFilter) api.BackupConfiguration {
+		return api.BackupConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "demo"},
+			Spec: api.BackupConfigurationSpec{
+				Repository: core.LocalObjectReference{Name: "shared-repo"},
+				Target: &api.BackupTarget{
+					Ref: api.TargetRef{Kind: "Deployment", Name: name},
+				},
+				RetentionPolicy: api_v1alpha1.RetentionPolicy{Name: "keep-last-1", KeepLast: 1, Filter: filter},
+			},
+		}
+	}
+	appA := newBackupConfig("app-a", nil)
+	appB := newBackupConfig("app-b", nil)
+	custom := newBackupConfig("custom", &api_v1alpha1.RetentionFilter{Host: "host-1"})
+
+	type snapshot struct {
+		host string
+		tags []string
+	}
+	snapshots := []snapshot{
+		{host: "host-0", tags: SnapshotTags("app-a-1", appA)},
+		{host: "host-0", tags: SnapshotTags("app-a-2", appA)},
+		{host: "host-0", tags: SnapshotTags("app-b-1", appB)},
+		{host: "host-0", tags: SnapshotTags("app-b-2", appB)},
+	}
+	// selected mimics how restic selects the snapshots for "restic forget --host <host> --tag <tags>"
+	selected := func(filter *api_v1alpha1.RetentionFilter) []string {
+		var sessions []string
+		for _, s := range snapshots {
+			if filter.Host != "" && filter.Host != s.host {
+				continue
+			}
+			found := 0
+			for _, tag := range filter.Tags {
+				for _, t := range s.tags {
+					if t == tag {
+						found++
+						break
+					}
+				}
+			}
+			if found == len(filter.Tags) {
+				sessions = append(sessions, SnapshotLabelsFromTags(s.tags)[SnapshotLabelKey(TagBackupSession)])
+			}
+		}
+		return sessions
+	}
+
+	testCases := []struct {
+		name     string
+		config   api.BackupConfiguration
+		filter   *api_v1alpha1.RetentionFilter
+		sessions []string
+	}{
+		{
+			name:     "first config",
+			config:   appA,
+			filter:   &api_v1alpha1.RetentionFilter{Host: "host-0", Tags: []string{"backup-configuration=app-a"}},
+			sessions: []string{"app-a-1", "app-a-2"},
+		},
+		{
+			name:     "second config",
+			config:   appB,
+			filter:   &api_v1alpha1.RetentionFilter{Host: "host-0", Tags: []string{"backup-configuration=app-b"}},
+			sessions: []string{"app-b-1", "app-b-2"},
+		},
+		{
+			name:   "user specified filter",
+			config: custom,
+			filter: &api_v1alpha1.RetentionFilter{Host: "host-1"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := RetentionPolicyForBackupConfig(tc.config, "host-0")
+			assert.Equal(t, tc.filter, policy.Filter)
+			assert.Equal(t, tc.sessions, selected(policy.Filter))
+			// the policy of the BackupConfiguration is not modified
+			assert.Equal(t, tc.config.Name == "custom", tc.config.Spec.RetentionPolicy.Filter != nil)
+		})
+	}
+}
+
+func TestSnapshotFilterForSelector(t *testing.T) {
+	tags := make([]string, 1, 2)
+	tags[0] = "env=prod"
+	selector := &api.SnapshotSelector{Tags: tags, BackupSession: "app-1"}
+
+	filter := SnapshotFilterForSelector(selector)
+	assert.Equal(t, []string{"env=prod", TagBackupSession + "=app-1"}, filter.Tags)
+	// the selector must stay untouched as it may be shared with the cached object
+	assert.Equal(t, []string{"env=prod"}, selector.Tags)
+	assert.Empty(t, tags[:2][1])
+}
@@ -76,8 +76,20 @@ func (f *Invocation) PvcBackupFunction() v1beta1.Function {
 				fmt.Sprintf("--scratch-dir=%s", tmpDir),
 				fmt.Sprintf("--hostname=${%s:=host-0}", apis.Hostname),
 				fmt.Sprintf("--backup-dirs=${%s:=}", apis.TargetDirectories),
+				fmt.Sprintf("--exclude=${%s:=}", apis.TargetExclude),
+				fmt.Sprintf("--include=${%s:=}", apis.TargetInclude),
+				fmt.Sprintf("--exclude-if-present=${%s:=}", apis.TargetExcludeIfPresent),
+				fmt.Sprintf("--ignore-file-name=${%s:=}", apis.TargetIgnoreFileName),
+				fmt.Sprintf("--exclude-caches=${%s:=false}", apis.TargetExcludeCaches),
+				fmt.Sprintf("--one-file-system=${%s:=false}", apis.TargetOneFileSystem),
 				fmt.Sprintf("--retention-keep-last=${%s:=0}", apis.RetentionKeepLast),
 				fmt.Sprintf("--retention-prune=${%s:=false}", apis.RetentionPrune),
+				fmt.Sprintf("--retention-filter-host=${%s:=}", apis.RetentionFilterHost),
+				fmt.Sprintf("--retention-filter-tags=${%s:=}", apis.RetentionFilterTags),
+				fmt.Sprintf("--skip-integrity-check=${%s:=false}", apis.SkipIntegrityCheck),
+				fmt.Sprintf("--tags=${%s:=}", apis.SnapshotTags),
+				fmt.Sprintf("--namespace=${%s:=default}", apis.Namespace),
+				fmt.Sprintf("--backup-session=${%s:=}", apis.BackupSession),
 				fmt.Sprintf("--output-dir=${%s:=}", outputDir),
 				fmt.Sprintf("--enable-cache=${%s:=true}", apis.EnableCache),
 			},
@@ -113,6 +125,14 @@ func (f *Invocation) PvcRestoreFunction() v1beta1.Function {
 				fmt.Sprintf("--hostname=${%s:=host-0}", apis.Hostname),
 				fmt.Sprintf("--restore-dirs=${%s:=}", apis.RestoreDirectories),
 				fmt.Sprintf("--snapshots=${%s:=}", apis.RestoreSnapshots),
+				fmt.Sprintf("--include=${%s:=}", apis.RestoreInclude),
+				fmt.Sprintf("--exclude=${%s:=}", apis.RestoreExclude),
+				fmt.Sprintf("--destination=${%s:=}", apis.RestoreDestination),
+				fmt.Sprintf("--conflict-policy=${%s:=}", apis.RestoreConflictPolicy),
+				fmt.Sprintf("--snapshot-tags=${%s:=}", apis.RestoreSnapshotTags),
+				fmt.Sprintf("--snapshot-before=${%s:=}", apis.RestoreSnapshotBefore),
+				fmt.Sprintf("--namespace=${%s:=default}", apis.Namespace),
+				fmt.Sprintf("--restore-session=${%s:=}", apis.RestoreSession),
 				fmt.Sprintf("--output-dir=${%s:=}", outputDir),
 				fmt.Sprintf("--enable-cache=${%s:=true}", apis.EnableCache),
 			},